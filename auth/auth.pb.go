@@ -9,6 +9,7 @@
 package auth
 
 import (
+	v1 "github.com/trancecho/mundo-proto-sdk/common/v1"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
@@ -90,6 +91,7 @@ type LoginResponse struct {
 	Uid           int64                  `protobuf:"varint,3,opt,name=uid,proto3" json:"uid,omitempty"`
 	Muid          string                 `protobuf:"bytes,4,opt,name=muid,proto3" json:"muid,omitempty"`                                  // 全局唯一用户ID (UUIDv7)
 	LoginMethod   string                 `protobuf:"bytes,5,opt,name=login_method,json=loginMethod,proto3" json:"login_method,omitempty"` // 首次登录途径 (email/hduhelp)
+	User          *v1.UserRef            `protobuf:"bytes,6,opt,name=user,proto3" json:"user,omitempty"`                                  // 统一用户标识（uid + muid）
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -159,25 +161,33 @@ func (x *LoginResponse) GetLoginMethod() string {
 	return ""
 }
 
+func (x *LoginResponse) GetUser() *v1.UserRef {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
 var File_auth_auth_proto protoreflect.FileDescriptor
 
 const file_auth_auth_proto_rawDesc = "" +
 	"\n" +
-	"\x0fauth/auth.proto\x12\x04auth\"_\n" +
+	"\x0fauth/auth.proto\x12\x04auth\x1a\x16common/v1/common.proto\"_\n" +
 	"\fLoginRequest\x12\x1d\n" +
 	"\n" +
 	"grpc_token\x18\x01 \x01(\tR\tgrpcToken\x12\x14\n" +
 	"\x05email\x18\x02 \x01(\tR\x05email\x12\x1a\n" +
-	"\bpassword\x18\x03 \x01(\tR\bpassword\"\x8a\x01\n" +
+	"\bpassword\x18\x03 \x01(\tR\bpassword\"\xb2\x01\n" +
 	"\rLoginResponse\x12\x14\n" +
 	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1a\n" +
 	"\busername\x18\x02 \x01(\tR\busername\x12\x10\n" +
 	"\x03uid\x18\x03 \x01(\x03R\x03uid\x12\x12\n" +
 	"\x04muid\x18\x04 \x01(\tR\x04muid\x12!\n" +
-	"\flogin_method\x18\x05 \x01(\tR\vloginMethod2?\n" +
+	"\flogin_method\x18\x05 \x01(\tR\vloginMethod\x12&\n" +
+	"\x04user\x18\x06 \x01(\v2\x12.common.v1.UserRefR\x04user2?\n" +
 	"\vAuthService\x120\n" +
-	"\x05Login\x12\x12.auth.LoginRequest\x1a\x13.auth.LoginResponseBt\n" +
-	"\bcom.authB\tAuthProtoP\x01Z-github.com/betterde/focusly/internal/gen/auth\xa2\x02\x03AXX\xaa\x02\x04Auth\xca\x02\x04Auth\xe2\x02\x10Auth\\GPBMetadata\xea\x02\x04Authb\x06proto3"
+	"\x05Login\x12\x12.auth.LoginRequest\x1a\x13.auth.LoginResponseBp\n" +
+	"\bcom.authB\tAuthProtoP\x01Z)github.com/trancecho/mundo-proto-sdk/auth\xa2\x02\x03AXX\xaa\x02\x04Auth\xca\x02\x04Auth\xe2\x02\x10Auth\\GPBMetadata\xea\x02\x04Authb\x06proto3"
 
 var (
 	file_auth_auth_proto_rawDescOnce sync.Once
@@ -195,15 +205,17 @@ var file_auth_auth_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
 var file_auth_auth_proto_goTypes = []any{
 	(*LoginRequest)(nil),  // 0: auth.LoginRequest
 	(*LoginResponse)(nil), // 1: auth.LoginResponse
+	(*v1.UserRef)(nil),    // 2: common.v1.UserRef
 }
 var file_auth_auth_proto_depIdxs = []int32{
-	0, // 0: auth.AuthService.Login:input_type -> auth.LoginRequest
-	1, // 1: auth.AuthService.Login:output_type -> auth.LoginResponse
-	1, // [1:2] is the sub-list for method output_type
-	0, // [0:1] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	2, // 0: auth.LoginResponse.user:type_name -> common.v1.UserRef
+	0, // 1: auth.AuthService.Login:input_type -> auth.LoginRequest
+	1, // 2: auth.AuthService.Login:output_type -> auth.LoginResponse
+	2, // [2:3] is the sub-list for method output_type
+	1, // [1:2] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
 }
 
 func init() { file_auth_auth_proto_init() }
@@ -0,0 +1,184 @@
+// Package paging 遍历任意分页 RPC 的所有页。
+//
+// 支持两种分页约定：请求中的 common.v1.PageRequest pagination 字段，
+// 以及历史接口中平铺的 page_token / next_page_token 字段（如 ListPointTransactions、GetUserTimeline）。
+package paging
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"iter"
+	"strconv"
+
+	commonv1 "github.com/trancecho/mundo-proto-sdk/common/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var (
+	ErrNotPaginated = errors.New("paging: message has no page token field")
+	ErrInvalidToken = errors.New("paging: invalid page token")
+)
+
+// Call 分页 RPC 的客户端方法签名，如 client.ListPointTransactions
+type Call[Req, Resp proto.Message] func(ctx context.Context, req Req, opts ...grpc.CallOption) (Resp, error)
+
+// All 从 req 指定的页开始依次请求，逐条返回 items 取出的元素。
+// req 不会被修改；出错时返回一次错误后结束；调用方 break 时不再请求下一页。
+func All[Req, Resp proto.Message, T any](ctx context.Context, call Call[Req, Resp], req Req,
+	items func(Resp) []T, opts ...grpc.CallOption) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		for resp, err := range Pages(ctx, call, req, opts...) {
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			for _, item := range items(resp) {
+				if !yield(item, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Pages 从 req 指定的页开始依次请求，逐页返回响应
+func Pages[Req, Resp proto.Message](ctx context.Context, call Call[Req, Resp], req Req,
+	opts ...grpc.CallOption) iter.Seq2[Resp, error] {
+	return func(yield func(Resp, error) bool) {
+		var zero Resp
+		cur := proto.Clone(req).(Req)
+		seen := make(map[string]bool)
+		for {
+			resp, err := call(ctx, cur, opts...)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			if !yield(resp, nil) {
+				return
+			}
+			next, err := NextToken(resp)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			if next == "" {
+				return
+			}
+			// 服务端返回重复游标时停止，避免死循环
+			if seen[next] {
+				yield(zero, fmt.Errorf("%w: repeated token %q", ErrInvalidToken, next))
+				return
+			}
+			seen[next] = true
+			if err := SetToken(cur, next); err != nil {
+				yield(zero, err)
+				return
+			}
+		}
+	}
+}
+
+// Collect 收集所有元素，limit > 0 时最多收集 limit 个
+func Collect[Req, Resp proto.Message, T any](ctx context.Context, call Call[Req, Resp], req Req,
+	items func(Resp) []T, limit int, opts ...grpc.CallOption) ([]T, error) {
+	var out []T
+	for item, err := range All(ctx, call, req, items, opts...) {
+		if err != nil {
+			return out, err
+		}
+		out = append(out, item)
+		if limit > 0 && len(out) >= limit {
+			break
+		}
+	}
+	return out, nil
+}
+
+// SetToken 设置请求的分页游标，优先使用 pagination 字段
+func SetToken(req proto.Message, token string) error {
+	m := req.ProtoReflect()
+	if fd := m.Descriptor().Fields().ByName("pagination"); fd != nil && isMessage(fd, pageRequestName) {
+		pr := m.Mutable(fd).Message()
+		pr.Set(pr.Descriptor().Fields().ByName("page_token"), protoreflect.ValueOfString(token))
+		return nil
+	}
+	if fd := m.Descriptor().Fields().ByName("page_token"); fd != nil && fd.Kind() == protoreflect.StringKind {
+		m.Set(fd, protoreflect.ValueOfString(token))
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrNotPaginated, m.Descriptor().FullName())
+}
+
+// NextToken 读取响应的下一页游标，优先使用 pagination 字段
+func NextToken(resp proto.Message) (string, error) {
+	m := resp.ProtoReflect()
+	if fd := m.Descriptor().Fields().ByName("pagination"); fd != nil && isMessage(fd, pageResponseName) {
+		if !m.Has(fd) {
+			return "", nil
+		}
+		pr := m.Get(fd).Message()
+		return pr.Get(pr.Descriptor().Fields().ByName("next_page_token")).String(), nil
+	}
+	if fd := m.Descriptor().Fields().ByName("next_page_token"); fd != nil && fd.Kind() == protoreflect.StringKind {
+		return m.Get(fd).String(), nil
+	}
+	return "", fmt.Errorf("%w: %s", ErrNotPaginated, m.Descriptor().FullName())
+}
+
+var (
+	pageRequestName  = (&commonv1.PageRequest{}).ProtoReflect().Descriptor().FullName()
+	pageResponseName = (&commonv1.PageResponse{}).ProtoReflect().Descriptor().FullName()
+)
+
+func isMessage(fd protoreflect.FieldDescriptor, name protoreflect.FullName) bool {
+	return fd.Kind() == protoreflect.MessageKind && !fd.IsList() && fd.Message().FullName() == name
+}
+
+// PageSize 读取每页数量，未设置时返回 def，超过 maxSize 时返回 maxSize
+func PageSize(req *commonv1.PageRequest, def, maxSize int32) int32 {
+	n := req.GetPageSize()
+	if n <= 0 {
+		return def
+	}
+	return min(n, maxSize)
+}
+
+// EncodeOffset 将偏移量编码为不透明游标，供底层按 offset 分页的服务端使用
+func EncodeOffset(offset int) string {
+	if offset <= 0 {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte("o:" + strconv.Itoa(offset)))
+}
+
+// DecodeOffset 解析 EncodeOffset 生成的游标，空游标返回 0
+func DecodeOffset(token string) (int, error) {
+	if token == "" {
+		return 0, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(b) < 3 || string(b[:2]) != "o:" {
+		return 0, ErrInvalidToken
+	}
+	n, err := strconv.Atoi(string(b[2:]))
+	if err != nil || n < 0 {
+		return 0, ErrInvalidToken
+	}
+	return n, nil
+}
+
+// OffsetResponse 按 offset 分页时组装 PageResponse：本页返回 n 条，
+// 满页时才生成下一页游标；total < 0 表示不统计
+func OffsetResponse(offset, pageSize, n int, total int64) *commonv1.PageResponse {
+	resp := &commonv1.PageResponse{Total: total}
+	if n >= pageSize && (total < 0 || int64(offset+n) < total) {
+		resp.NextPageToken = EncodeOffset(offset + n)
+	}
+	return resp
+}
@@ -0,0 +1,186 @@
+package paging
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	commonv1 "github.com/trancecho/mundo-proto-sdk/common/v1"
+	messagev1 "github.com/trancecho/mundo-proto-sdk/message/v1"
+	pointv1 "github.com/trancecho/mundo-proto-sdk/points-system/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestTokens(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     proto.Message
+		resp    proto.Message
+		want    string
+		wantErr error
+	}{
+		{"pagination field", &messagev1.GetMessagesRequest{},
+			&messagev1.GetMessagesResponse{Pagination: &commonv1.PageResponse{NextPageToken: "n"}}, "n", nil},
+		{"pagination unset", &messagev1.GetMessagesRequest{}, &messagev1.GetMessagesResponse{}, "", nil},
+		{"flat token", &pointv1.ListPointTransactionsRequest{},
+			&pointv1.ListPointTransactionsResponse{NextPageToken: "n"}, "n", nil},
+		{"not paginated", &pointv1.GetUserInfoRequest{}, &pointv1.UserInfo{}, "", ErrNotPaginated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := SetToken(tt.req, "tok"); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SetToken err = %v, want %v", err, tt.wantErr)
+			}
+			got, err := NextToken(tt.resp)
+			if !errors.Is(err, tt.wantErr) || got != tt.want {
+				t.Fatalf("NextToken = %q, %v; want %q, %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+	req := &messagev1.GetMessagesRequest{}
+	_ = SetToken(req, "tok")
+	if req.GetPagination().GetPageToken() != "tok" {
+		t.Fatalf("pagination token = %q", req.GetPagination().GetPageToken())
+	}
+	flat := &pointv1.ListPointTransactionsRequest{}
+	_ = SetToken(flat, "tok")
+	if flat.GetPageToken() != "tok" {
+		t.Fatalf("page_token = %q", flat.GetPageToken())
+	}
+}
+
+// fakePages 按游标返回固定的页，记录请求过的游标
+type fakePages struct {
+	pages map[string]*pointv1.ListPointTransactionsResponse
+	fail  string // 请求该游标时返回错误
+	seen  []string
+}
+
+var errBackend = errors.New("backend down")
+
+func (f *fakePages) call(_ context.Context, req *pointv1.ListPointTransactionsRequest, _ ...grpc.CallOption) (*pointv1.ListPointTransactionsResponse, error) {
+	f.seen = append(f.seen, req.GetPageToken())
+	if req.GetPageToken() == f.fail {
+		return nil, errBackend
+	}
+	return f.pages[req.GetPageToken()], nil
+}
+
+func page(next string, ids ...string) *pointv1.ListPointTransactionsResponse {
+	resp := &pointv1.ListPointTransactionsResponse{NextPageToken: next}
+	for _, id := range ids {
+		resp.Transactions = append(resp.Transactions, &pointv1.PointTransaction{Id: id})
+	}
+	return resp
+}
+
+func TestCollect(t *testing.T) {
+	tests := []struct {
+		name     string
+		fake     *fakePages
+		limit    int
+		want     []string
+		wantSeen []string
+		wantErr  error
+	}{
+		{"all pages", &fakePages{pages: map[string]*pointv1.ListPointTransactionsResponse{
+			"": page("a", "1", "2"), "a": page("b", "3"), "b": page("", "4"),
+		}, fail: "-"}, 0, []string{"1", "2", "3", "4"}, []string{"", "a", "b"}, nil},
+		{"limit stops early", &fakePages{pages: map[string]*pointv1.ListPointTransactionsResponse{
+			"": page("a", "1", "2"), "a": page("", "3"),
+		}, fail: "-"}, 2, []string{"1", "2"}, []string{""}, nil},
+		{"repeated token", &fakePages{pages: map[string]*pointv1.ListPointTransactionsResponse{
+			"": page("a", "1"), "a": page("a", "2"),
+		}, fail: "-"}, 0, []string{"1", "2"}, []string{"", "a"}, ErrInvalidToken},
+		{"call error", &fakePages{pages: map[string]*pointv1.ListPointTransactionsResponse{
+			"": page("a", "1"),
+		}, fail: "a"}, 0, []string{"1"}, []string{"", "a"}, errBackend},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &pointv1.ListPointTransactionsRequest{UserId: "1"}
+			items, err := Collect(context.Background(), tt.fake.call, req,
+				(*pointv1.ListPointTransactionsResponse).GetTransactions, tt.limit)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			var got []string
+			for _, it := range items {
+				got = append(got, it.GetId())
+			}
+			if !slices.Equal(got, tt.want) || !slices.Equal(tt.fake.seen, tt.wantSeen) {
+				t.Fatalf("items = %v, seen = %q; want %v, %q", got, tt.fake.seen, tt.want, tt.wantSeen)
+			}
+			if req.GetPageToken() != "" {
+				t.Fatal("request was modified")
+			}
+		})
+	}
+}
+
+func TestOffset(t *testing.T) {
+	tests := []struct {
+		name    string
+		token   string
+		want    int
+		wantErr error
+	}{
+		{"empty", "", 0, nil},
+		{"round trip", EncodeOffset(40), 40, nil},
+		{"not base64", "!!", 0, ErrInvalidToken},
+		{"wrong prefix", "eDo0MA", 0, ErrInvalidToken}, // "x:40"
+		{"negative", "bzotMQ", 0, ErrInvalidToken},     // "o:-1"
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeOffset(tt.token)
+			if !errors.Is(err, tt.wantErr) || got != tt.want {
+				t.Fatalf("DecodeOffset = %d, %v; want %d, %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+	if EncodeOffset(0) != "" {
+		t.Fatal("EncodeOffset(0) should be empty")
+	}
+}
+
+func TestOffsetResponse(t *testing.T) {
+	tests := []struct {
+		name            string
+		offset, size, n int
+		total           int64
+		wantNext        int
+	}{
+		{"full page unknown total", 0, 10, 10, -1, 10},
+		{"short page", 0, 10, 3, -1, 0},
+		{"full page reaches total", 10, 10, 10, 20, 0},
+		{"full page below total", 10, 10, 10, 25, 20},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := OffsetResponse(tt.offset, tt.size, tt.n, tt.total)
+			if resp.GetNextPageToken() != EncodeOffset(tt.wantNext) || resp.GetTotal() != tt.total {
+				t.Fatalf("resp = %+v, want next offset %d", resp, tt.wantNext)
+			}
+		})
+	}
+}
+
+func TestPageSize(t *testing.T) {
+	tests := []struct {
+		req  *commonv1.PageRequest
+		want int32
+	}{
+		{nil, 20},
+		{&commonv1.PageRequest{}, 20},
+		{&commonv1.PageRequest{PageSize: 5}, 5},
+		{&commonv1.PageRequest{PageSize: 500}, 100},
+	}
+	for _, tt := range tests {
+		if got := PageSize(tt.req, 20, 100); got != tt.want {
+			t.Errorf("PageSize(%v) = %d, want %d", tt.req, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+// Package userref 在 common.v1.UserRef 与各服务历史上的用户 ID 类型之间安全转换。
+//
+// 规范类型为 int64（与 auth 一致）；转换到更窄或无符号的类型时做溢出检查，
+// 不会静默截断。各服务在迁移期间用 XxxOf 系列函数统一读取 "UserRef 优先、旧字段兜底" 的用户 ID。
+package userref
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	commonv1 "github.com/trancecho/mundo-proto-sdk/common/v1"
+)
+
+var (
+	ErrEmpty    = errors.New("userref: empty user id")
+	ErrOverflow = errors.New("userref: user id out of range")
+	ErrInvalid  = errors.New("userref: invalid user id")
+)
+
+// FromInt64 由 auth/forum 的 int64 uid 构造
+func FromInt64(uid int64) *commonv1.UserRef {
+	return &commonv1.UserRef{Uid: uid}
+}
+
+// FromUint32 由 stat 的 uint32 user_id 构造
+func FromUint32(uid uint32) *commonv1.UserRef {
+	return &commonv1.UserRef{Uid: int64(uid)}
+}
+
+// FromUint64 由 timerme 的 uint64 uid 构造，超出 int64 时返回 ErrOverflow
+func FromUint64(uid uint64) (*commonv1.UserRef, error) {
+	if uid > math.MaxInt64 {
+		return nil, fmt.Errorf("%w: %d", ErrOverflow, uid)
+	}
+	return &commonv1.UserRef{Uid: int64(uid)}, nil
+}
+
+// FromString 由 points/message 的字符串 user_id 构造，必须是十进制整数
+func FromString(uid string) (*commonv1.UserRef, error) {
+	if uid == "" {
+		return nil, ErrEmpty
+	}
+	n, err := strconv.ParseInt(uid, 10, 64)
+	if err != nil {
+		if errors.Is(err, strconv.ErrRange) {
+			return nil, fmt.Errorf("%w: %s", ErrOverflow, uid)
+		}
+		return nil, fmt.Errorf("%w: %q", ErrInvalid, uid)
+	}
+	return &commonv1.UserRef{Uid: n}, nil
+}
+
+// WithMuid 构造同时带 uid 和 muid 的 UserRef
+func WithMuid(uid int64, muid string) *commonv1.UserRef {
+	return &commonv1.UserRef{Uid: uid, Muid: muid}
+}
+
+// IsZero 判断 UserRef 是否未设置（nil 或 uid、muid 均为空）
+func IsZero(ref *commonv1.UserRef) bool {
+	return ref.GetUid() == 0 && ref.GetMuid() == ""
+}
+
+// Int64 返回 uid，未设置时返回 ErrEmpty（仅有 muid 时同样返回 ErrEmpty，需调用方自行解析 muid）
+func Int64(ref *commonv1.UserRef) (int64, error) {
+	if ref.GetUid() == 0 {
+		return 0, ErrEmpty
+	}
+	return ref.GetUid(), nil
+}
+
+// Uint32 转换为 stat 使用的 uint32，负数或超出范围时返回 ErrOverflow
+func Uint32(ref *commonv1.UserRef) (uint32, error) {
+	uid, err := Int64(ref)
+	if err != nil {
+		return 0, err
+	}
+	if uid < 0 || uid > math.MaxUint32 {
+		return 0, fmt.Errorf("%w: %d", ErrOverflow, uid)
+	}
+	return uint32(uid), nil
+}
+
+// Uint64 转换为 timerme 使用的 uint64，负数时返回 ErrOverflow
+func Uint64(ref *commonv1.UserRef) (uint64, error) {
+	uid, err := Int64(ref)
+	if err != nil {
+		return 0, err
+	}
+	if uid < 0 {
+		return 0, fmt.Errorf("%w: %d", ErrOverflow, uid)
+	}
+	return uint64(uid), nil
+}
+
+// String 转换为 points/message 使用的十进制字符串
+func String(ref *commonv1.UserRef) (string, error) {
+	uid, err := Int64(ref)
+	if err != nil {
+		return "", err
+	}
+	return strconv.FormatInt(uid, 10), nil
+}
+
+// Int64Of 读取 "UserRef 优先、旧 int64 字段兜底" 的用户 ID
+func Int64Of(ref *commonv1.UserRef, legacy int64) (int64, error) {
+	if ref.GetUid() != 0 {
+		return ref.GetUid(), nil
+	}
+	if legacy == 0 {
+		return 0, ErrEmpty
+	}
+	return legacy, nil
+}
+
+// Uint32Of 读取 "UserRef 优先、旧 uint32 字段兜底" 的用户 ID
+func Uint32Of(ref *commonv1.UserRef, legacy uint32) (uint32, error) {
+	if ref.GetUid() != 0 {
+		return Uint32(ref)
+	}
+	if legacy == 0 {
+		return 0, ErrEmpty
+	}
+	return legacy, nil
+}
+
+// Uint64Of 读取 "UserRef 优先、旧 uint64 字段兜底" 的用户 ID
+func Uint64Of(ref *commonv1.UserRef, legacy uint64) (uint64, error) {
+	if ref.GetUid() != 0 {
+		return Uint64(ref)
+	}
+	if legacy == 0 {
+		return 0, ErrEmpty
+	}
+	return legacy, nil
+}
+
+// StringOf 读取 "UserRef 优先、旧字符串字段兜底" 的用户 ID
+func StringOf(ref *commonv1.UserRef, legacy string) (string, error) {
+	if ref.GetUid() != 0 {
+		return String(ref)
+	}
+	if legacy == "" {
+		return "", ErrEmpty
+	}
+	return legacy, nil
+}
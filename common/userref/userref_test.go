@@ -0,0 +1,94 @@
+package userref
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	commonv1 "github.com/trancecho/mundo-proto-sdk/common/v1"
+)
+
+func TestFromString(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int64
+		wantErr error
+	}{
+		{"42", 42, nil},
+		{"-1", -1, nil},
+		{"", 0, ErrEmpty},
+		{"abc", 0, ErrInvalid},
+		{"9223372036854775808", 0, ErrOverflow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			ref, err := FromString(tt.in)
+			if !errors.Is(err, tt.wantErr) || ref.GetUid() != tt.want {
+				t.Fatalf("FromString = %v, %v; want %d, %v", ref, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+	if _, err := FromUint64(math.MaxInt64 + 1); !errors.Is(err, ErrOverflow) {
+		t.Fatalf("FromUint64 err = %v, want ErrOverflow", err)
+	}
+}
+
+func TestNarrowing(t *testing.T) {
+	tests := []struct {
+		name       string
+		ref        *commonv1.UserRef
+		wantUint32 error
+		wantUint64 error
+		wantString string
+	}{
+		{"nil", nil, ErrEmpty, ErrEmpty, ""},
+		{"muid only", &commonv1.UserRef{Muid: "m"}, ErrEmpty, ErrEmpty, ""},
+		{"small", FromInt64(7), nil, nil, "7"},
+		{"above uint32", FromInt64(math.MaxUint32 + 1), ErrOverflow, nil, "4294967296"},
+		{"negative", FromInt64(-1), ErrOverflow, ErrOverflow, "-1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Uint32(tt.ref); !errors.Is(err, tt.wantUint32) {
+				t.Fatalf("Uint32 err = %v, want %v", err, tt.wantUint32)
+			}
+			if _, err := Uint64(tt.ref); !errors.Is(err, tt.wantUint64) {
+				t.Fatalf("Uint64 err = %v, want %v", err, tt.wantUint64)
+			}
+			if s, _ := String(tt.ref); s != tt.wantString {
+				t.Fatalf("String = %q, want %q", s, tt.wantString)
+			}
+		})
+	}
+}
+
+func TestOf(t *testing.T) {
+	tests := []struct {
+		name       string
+		ref        *commonv1.UserRef
+		legacy     uint32
+		want       uint32
+		wantErr    error
+		wantString string
+	}{
+		{"ref wins", FromInt64(5), 9, 5, nil, "5"},
+		{"legacy fallback", nil, 9, 9, nil, "9"},
+		{"both empty", nil, 0, 0, ErrEmpty, ""},
+		{"ref overflow not masked by legacy", FromInt64(-3), 9, 0, ErrOverflow, "-3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Uint32Of(tt.ref, tt.legacy)
+			if !errors.Is(err, tt.wantErr) || got != tt.want {
+				t.Fatalf("Uint32Of = %d, %v; want %d, %v", got, err, tt.want, tt.wantErr)
+			}
+			legacy := ""
+			if tt.legacy != 0 {
+				legacy = "9"
+			}
+			if s, _ := StringOf(tt.ref, legacy); s != tt.wantString {
+				t.Fatalf("StringOf = %q, want %q", s, tt.wantString)
+			}
+		})
+	}
+}
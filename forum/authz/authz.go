@@ -0,0 +1,125 @@
+package authz
+
+import (
+	"context"
+	"errors"
+
+	forum "github.com/trancecho/mundo-proto-sdk/forum_pb"
+	"github.com/trancecho/mundo-proto-sdk/rauth"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Domain ErrorInfo 中的错误域
+const Domain = "forum.mundo"
+
+var (
+	ErrPostNotFound    = errors.New("authz: post not found")
+	ErrCommentNotFound = errors.New("authz: comment not found")
+)
+
+// OwnerLookup 查询资源作者，由论坛服务基于自身存储实现；
+// 资源不存在时返回 ErrPostNotFound / ErrCommentNotFound
+type OwnerLookup interface {
+	PostOwner(ctx context.Context, postID int64) (int64, error)
+	CommentOwner(ctx context.Context, commentID int64) (int64, error)
+}
+
+// Error 构造带 ErrorInfo 的 gRPC 错误，reason 为 ForumErrorCode 的枚举名
+func Error(c codes.Code, code forum.ForumErrorCode, msg string) error {
+	st, err := status.New(c, msg).WithDetails(&errdetails.ErrorInfo{
+		Reason: code.String(),
+		Domain: Domain,
+	})
+	if err != nil {
+		return status.Error(c, msg)
+	}
+	return st.Err()
+}
+
+// ErrorCode 从 gRPC 错误中取出 ForumErrorCode，不是论坛错误时返回 FORUM_ERROR_CODE_UNSPECIFIED
+func ErrorCode(err error) forum.ForumErrorCode {
+	st, ok := status.FromError(err)
+	if !ok {
+		return forum.ForumErrorCode_FORUM_ERROR_CODE_UNSPECIFIED
+	}
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok && info.GetDomain() == Domain {
+			return forum.ForumErrorCode(forum.ForumErrorCode_value[info.GetReason()])
+		}
+	}
+	return forum.ForumErrorCode_FORUM_ERROR_CODE_UNSPECIFIED
+}
+
+// Author 返回当前已认证用户的 uid，创建帖子和评论时用作作者
+func Author(ctx context.Context) (int64, error) {
+	p, ok := rauth.FromContext(ctx)
+	if !ok {
+		return 0, Error(codes.Unauthenticated, forum.ForumErrorCode_FORUM_ERROR_CODE_UNAUTHENTICATED, "authentication required")
+	}
+	return p.Uid, nil
+}
+
+// CheckOwner 校验当前用户是否为 owner 本人或版主
+func CheckOwner(ctx context.Context, owner int64) error {
+	p, ok := rauth.FromContext(ctx)
+	if !ok {
+		return Error(codes.Unauthenticated, forum.ForumErrorCode_FORUM_ERROR_CODE_UNAUTHENTICATED, "authentication required")
+	}
+	if p.Uid != owner && !p.IsModerator() {
+		return Error(codes.PermissionDenied, forum.ForumErrorCode_FORUM_ERROR_CODE_NOT_OWNER, "only the author or a moderator can modify this resource")
+	}
+	return nil
+}
+
+// UnaryServerInterceptor 对 ForumService 的写操作鉴权，需放在 rauth.UnaryServerInterceptor 之后：
+// 创建帖子/评论要求已登录，更新/删除帖子和删除评论要求作者本人或版主。
+func UnaryServerInterceptor(owners OwnerLookup) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		var err error
+		switch info.FullMethod {
+		case forum.ForumService_CreateForumPost_FullMethodName, forum.ForumService_CreateComment_FullMethodName:
+			_, err = Author(ctx)
+		case forum.ForumService_UpdateForumPost_FullMethodName:
+			err = checkPost(ctx, owners, req.(*forum.UpdateForumPostRequest).GetId())
+		case forum.ForumService_DeleteForumPost_FullMethodName:
+			err = checkPost(ctx, owners, req.(*forum.DeleteForumPostRequest).GetId())
+		case forum.ForumService_DeleteComment_FullMethodName:
+			err = checkComment(ctx, owners, req.(*forum.DeleteCommentRequest).GetId())
+		}
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func checkPost(ctx context.Context, owners OwnerLookup, id int64) error {
+	if _, err := Author(ctx); err != nil {
+		return err
+	}
+	owner, err := owners.PostOwner(ctx, id)
+	if errors.Is(err, ErrPostNotFound) {
+		return Error(codes.NotFound, forum.ForumErrorCode_FORUM_ERROR_CODE_POST_NOT_FOUND, "post not found")
+	}
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	return CheckOwner(ctx, owner)
+}
+
+func checkComment(ctx context.Context, owners OwnerLookup, id int64) error {
+	if _, err := Author(ctx); err != nil {
+		return err
+	}
+	owner, err := owners.CommentOwner(ctx, id)
+	if errors.Is(err, ErrCommentNotFound) {
+		return Error(codes.NotFound, forum.ForumErrorCode_FORUM_ERROR_CODE_COMMENT_NOT_FOUND, "comment not found")
+	}
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	return CheckOwner(ctx, owner)
+}
@@ -0,0 +1,111 @@
+package authz
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	forum "github.com/trancecho/mundo-proto-sdk/forum_pb"
+	"github.com/trancecho/mundo-proto-sdk/rauth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// owners 帖子 1、评论 1 的作者为 uid 1；id 99 查询出错
+type owners struct{}
+
+func (owners) PostOwner(_ context.Context, id int64) (int64, error) {
+	switch id {
+	case 1:
+		return 1, nil
+	case 99:
+		return 0, errors.New("db down")
+	}
+	return 0, ErrPostNotFound
+}
+
+func (owners) CommentOwner(_ context.Context, id int64) (int64, error) {
+	if id == 1 {
+		return 1, nil
+	}
+	return 0, ErrCommentNotFound
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	var (
+		author    = &rauth.Principal{Uid: 1}
+		stranger  = &rauth.Principal{Uid: 2}
+		moderator = &rauth.Principal{Uid: 3, Roles: []string{rauth.RoleModerator}}
+		admin     = &rauth.Principal{Uid: 4, Roles: []string{rauth.RoleAdmin}}
+	)
+	tests := []struct {
+		name     string
+		method   string
+		req      any
+		p        *rauth.Principal
+		wantCode codes.Code
+		wantErr  forum.ForumErrorCode
+	}{
+		{"create anonymous", forum.ForumService_CreateForumPost_FullMethodName, &forum.CreateForumPostRequest{}, nil,
+			codes.Unauthenticated, forum.ForumErrorCode_FORUM_ERROR_CODE_UNAUTHENTICATED},
+		{"create comment", forum.ForumService_CreateComment_FullMethodName, &forum.CreateCommentRequest{}, stranger, codes.OK, 0},
+		{"update own post", forum.ForumService_UpdateForumPost_FullMethodName, &forum.UpdateForumPostRequest{Id: 1}, author, codes.OK, 0},
+		{"update others post", forum.ForumService_UpdateForumPost_FullMethodName, &forum.UpdateForumPostRequest{Id: 1}, stranger,
+			codes.PermissionDenied, forum.ForumErrorCode_FORUM_ERROR_CODE_NOT_OWNER},
+		{"moderator deletes post", forum.ForumService_DeleteForumPost_FullMethodName, &forum.DeleteForumPostRequest{Id: 1}, moderator, codes.OK, 0},
+		{"admin deletes comment", forum.ForumService_DeleteComment_FullMethodName, &forum.DeleteCommentRequest{Id: 1}, admin, codes.OK, 0},
+		{"missing post", forum.ForumService_DeleteForumPost_FullMethodName, &forum.DeleteForumPostRequest{Id: 2}, author,
+			codes.NotFound, forum.ForumErrorCode_FORUM_ERROR_CODE_POST_NOT_FOUND},
+		{"missing comment", forum.ForumService_DeleteComment_FullMethodName, &forum.DeleteCommentRequest{Id: 2}, author,
+			codes.NotFound, forum.ForumErrorCode_FORUM_ERROR_CODE_COMMENT_NOT_FOUND},
+		{"lookup error", forum.ForumService_UpdateForumPost_FullMethodName, &forum.UpdateForumPostRequest{Id: 99}, author, codes.Internal, 0},
+		{"delete anonymous", forum.ForumService_DeleteComment_FullMethodName, &forum.DeleteCommentRequest{Id: 1}, nil,
+			codes.Unauthenticated, forum.ForumErrorCode_FORUM_ERROR_CODE_UNAUTHENTICATED},
+		{"read is not checked", forum.ForumService_ListForumPosts_FullMethodName, &forum.ListForumPostsRequest{}, nil, codes.OK, 0},
+	}
+	interceptor := UnaryServerInterceptor(owners{})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.p != nil {
+				ctx = rauth.NewContext(ctx, tt.p)
+			}
+			called := false
+			_, err := interceptor(ctx, tt.req, &grpc.UnaryServerInfo{FullMethod: tt.method},
+				func(context.Context, any) (any, error) {
+					called = true
+					return nil, nil
+				})
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("code = %v, want %v (%v)", got, tt.wantCode, err)
+			}
+			if got := ErrorCode(err); got != tt.wantErr {
+				t.Fatalf("ErrorCode = %v, want %v", got, tt.wantErr)
+			}
+			if called != (tt.wantCode == codes.OK) {
+				t.Fatalf("handler called = %v", called)
+			}
+		})
+	}
+}
+
+func TestErrorCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want forum.ForumErrorCode
+	}{
+		{"forum error", Error(codes.NotFound, forum.ForumErrorCode_FORUM_ERROR_CODE_POST_NOT_FOUND, "x"), forum.ForumErrorCode_FORUM_ERROR_CODE_POST_NOT_FOUND},
+		{"plain status", status.Error(codes.NotFound, "x"), forum.ForumErrorCode_FORUM_ERROR_CODE_UNSPECIFIED},
+		{"non status", errors.New("x"), forum.ForumErrorCode_FORUM_ERROR_CODE_UNSPECIFIED},
+		{"nil", nil, forum.ForumErrorCode_FORUM_ERROR_CODE_UNSPECIFIED},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ErrorCode(tt.err); got != tt.want {
+				t.Fatalf("ErrorCode = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
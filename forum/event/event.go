@@ -0,0 +1,97 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+	forum "github.com/trancecho/mundo-proto-sdk/forum_pb"
+	"github.com/trancecho/mundo-proto-sdk/rconst"
+)
+
+// dataField 事件 JSON 在 stream 消息中的字段名
+const dataField = "data"
+
+// MaxStreamLen stream 的近似最大长度，超出后裁剪最早的消息
+const MaxStreamLen = 100000
+
+var ErrMalformed = errors.New("event: malformed stream message")
+
+// Publisher 向 Redis Stream 发布论坛事件
+type Publisher struct {
+	rdb redis.Cmdable
+}
+
+func NewPublisher(rdb redis.Cmdable) *Publisher {
+	return &Publisher{rdb: rdb}
+}
+
+// PublishComment 向 rconst.StreamComment 发布评论事件，返回消息 ID
+func (p *Publisher) PublishComment(ctx context.Context, ev rconst.CommentEvent) (string, error) {
+	return p.publish(ctx, rconst.StreamComment, ev)
+}
+
+// PublishPost 向 rconst.StreamPost 发布帖子事件，返回消息 ID
+func (p *Publisher) PublishPost(ctx context.Context, ev rconst.PostEvent) (string, error) {
+	return p.publish(ctx, rconst.StreamPost, ev)
+}
+
+func (p *Publisher) publish(ctx context.Context, stream string, ev any) (string, error) {
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return "", err
+	}
+	return p.rdb.XAdd(ctx, &redis.XAddArgs{
+		Stream: stream,
+		MaxLen: MaxStreamLen,
+		Approx: true,
+		Values: map[string]any{dataField: data},
+	}).Result()
+}
+
+// CommentEventFrom 由 CreateComment 返回的评论构造事件
+func CommentEventFrom(c *forum.Comment) rconst.CommentEvent {
+	return rconst.CommentEvent{
+		PostId:    uint(c.GetPostId()),
+		CommentId: uint(c.GetId()),
+		ParentId:  uint(c.GetParentId()),
+		RootId:    uint(c.GetRootId()),
+		Uid:       c.GetUid(),
+	}
+}
+
+// DecodeComment 解析消费者从 rconst.StreamComment 读到的消息
+func DecodeComment(msg redis.XMessage) (rconst.CommentEvent, error) {
+	var ev rconst.CommentEvent
+	err := decode(msg, &ev)
+	return ev, err
+}
+
+// DecodePost 解析消费者从 rconst.StreamPost 读到的消息
+func DecodePost(msg redis.XMessage) (rconst.PostEvent, error) {
+	var ev rconst.PostEvent
+	err := decode(msg, &ev)
+	return ev, err
+}
+
+func decode(msg redis.XMessage, v any) error {
+	raw, ok := msg.Values[dataField].(string)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrMalformed, msg.ID)
+	}
+	if err := json.Unmarshal([]byte(raw), v); err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrMalformed, msg.ID, err)
+	}
+	return nil
+}
+
+// EnsureGroup 创建消费者组（如 rconst.NavyGroup），已存在时忽略
+func EnsureGroup(ctx context.Context, rdb redis.Cmdable, stream, group string) error {
+	err := rdb.XGroupCreateMkStream(ctx, stream, group, "0").Err()
+	if redis.HasErrorPrefix(err, "BUSYGROUP") {
+		return nil
+	}
+	return err
+}
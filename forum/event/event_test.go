@@ -0,0 +1,58 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/trancecho/mundo-proto-sdk/rconst"
+)
+
+func TestEnsureGroup(t *testing.T) {
+	rdb := redis.NewClient(&redis.Options{Addr: miniredis.RunT(t).Addr()})
+	for i := range 2 {
+		if err := EnsureGroup(context.Background(), rdb, rconst.StreamComment, rconst.NavyGroup); err != nil {
+			t.Fatalf("call %d: %v", i+1, err)
+		}
+	}
+}
+
+func TestPublishAndDecode(t *testing.T) {
+	rdb := redis.NewClient(&redis.Options{Addr: miniredis.RunT(t).Addr()})
+	ctx := context.Background()
+	p := NewPublisher(rdb)
+	want := rconst.PostEvent{PostId: 3, Action: rconst.PostActionUpdate, Uid: 7}
+	if _, err := p.PublishPost(ctx, want); err != nil {
+		t.Fatal(err)
+	}
+	msgs, err := rdb.XRange(ctx, rconst.StreamPost, "-", "+").Result()
+	if err != nil || len(msgs) != 1 {
+		t.Fatalf("XRange = %v, %v", msgs, err)
+	}
+	got, err := DecodePost(msgs[0])
+	if err != nil || got != want {
+		t.Fatalf("DecodePost = %+v, %v; want %+v", got, err, want)
+	}
+}
+
+func TestDecodeMalformed(t *testing.T) {
+	tests := []struct {
+		name   string
+		values map[string]any
+	}{
+		{"missing data", map[string]any{"other": "x"}},
+		{"not a string", map[string]any{dataField: 1}},
+		{"invalid json", map[string]any{dataField: "{"}},
+		{"wrong type", map[string]any{dataField: `{"post_id": "x"}`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DecodeComment(redis.XMessage{ID: "1-0", Values: tt.values})
+			if !errors.Is(err, ErrMalformed) {
+				t.Fatalf("err = %v, want ErrMalformed", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package post
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/trancecho/mundo-proto-sdk/common/paging"
+	forum "github.com/trancecho/mundo-proto-sdk/forum_pb"
+	"github.com/trancecho/mundo-proto-sdk/rauth"
+)
+
+const (
+	MaxTags      = 10
+	MaxTagLength = 20 // 单个标签的最大字符数
+
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+var (
+	ErrTooManyTags     = errors.New("post: too many tags")
+	ErrTagTooLong      = errors.New("post: tag too long")
+	ErrInvalidStatus   = errors.New("post: invalid status")
+	ErrStatusForbidden = errors.New("post: status not visible to caller")
+)
+
+// NormalizeTags 去除首尾空白、转小写、去重（保留首次出现的顺序），丢弃空标签
+func NormalizeTags(tags []string) ([]string, error) {
+	out := make([]string, 0, len(tags))
+	for _, t := range tags {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t == "" || slices.Contains(out, t) {
+			continue
+		}
+		if utf8.RuneCountInString(t) > MaxTagLength {
+			return nil, fmt.Errorf("%w: %q", ErrTagTooLong, t)
+		}
+		out = append(out, t)
+	}
+	if len(out) > MaxTags {
+		return nil, ErrTooManyTags
+	}
+	return out, nil
+}
+
+// CreateStatus 发帖时的初始状态，未设置时为 PUBLISHED，只允许 DRAFT 或 PUBLISHED
+func CreateStatus(s forum.PostStatus) (forum.PostStatus, error) {
+	switch s {
+	case forum.PostStatus_POST_STATUS_UNSPECIFIED:
+		return forum.PostStatus_POST_STATUS_PUBLISHED, nil
+	case forum.PostStatus_POST_STATUS_DRAFT, forum.PostStatus_POST_STATUS_PUBLISHED:
+		return s, nil
+	}
+	return 0, ErrInvalidStatus
+}
+
+// CheckStatusChange 校验更新帖子时的状态变更：作者可在 DRAFT/PUBLISHED 间切换，
+// HIDDEN 仅版主可设置或解除，DELETED 只能通过 DeleteForumPost 设置
+func CheckStatusChange(p *rauth.Principal, from, to forum.PostStatus) error {
+	switch to {
+	case forum.PostStatus_POST_STATUS_UNSPECIFIED, from:
+		return nil
+	case forum.PostStatus_POST_STATUS_DELETED:
+		return ErrInvalidStatus
+	case forum.PostStatus_POST_STATUS_HIDDEN:
+		if !p.IsModerator() {
+			return ErrStatusForbidden
+		}
+		return nil
+	}
+	if from == forum.PostStatus_POST_STATUS_HIDDEN && !p.IsModerator() {
+		return ErrStatusForbidden
+	}
+	return nil
+}
+
+// Visible 判断帖子对调用方是否可见，p 为空表示未登录
+func Visible(p *rauth.Principal, post *forum.ForumPostResponse) bool {
+	switch post.GetStatus() {
+	case forum.PostStatus_POST_STATUS_UNSPECIFIED, forum.PostStatus_POST_STATUS_PUBLISHED:
+		return true
+	case forum.PostStatus_POST_STATUS_DRAFT:
+		return p != nil && p.Uid == post.GetUid()
+	case forum.PostStatus_POST_STATUS_HIDDEN:
+		return p != nil && (p.Uid == post.GetUid() || p.IsModerator())
+	}
+	return p.IsModerator()
+}
+
+// Filter 解析并校验后的帖子列表查询条件
+type Filter struct {
+	Tag       string
+	Category  string
+	AuthorUid int64
+	Statuses  []forum.PostStatus
+	Sort      forum.PostSortOrder
+	Offset    int
+	Limit     int
+}
+
+// ParseList 解析 ListForumPostsRequest：补全默认值，并按调用方身份校验可查询的状态。
+// 优先使用 pagination，未设置时兼容旧的 limit/offset。
+func ParseList(req *forum.ListForumPostsRequest, p *rauth.Principal) (Filter, error) {
+	f := Filter{
+		Tag:       strings.ToLower(strings.TrimSpace(req.GetTag())),
+		Category:  req.GetCategory(),
+		AuthorUid: req.GetAuthorUid(),
+		Statuses:  req.GetStatuses(),
+		Sort:      req.GetSort(),
+	}
+	if len(f.Statuses) == 0 {
+		f.Statuses = []forum.PostStatus{forum.PostStatus_POST_STATUS_PUBLISHED}
+	}
+	for _, s := range f.Statuses {
+		if err := checkListStatus(p, f.AuthorUid, s); err != nil {
+			return Filter{}, err
+		}
+	}
+	if f.Sort == forum.PostSortOrder_POST_SORT_ORDER_UNSPECIFIED {
+		f.Sort = forum.PostSortOrder_POST_SORT_ORDER_NEWEST
+	}
+	if pr := req.GetPagination(); pr != nil {
+		offset, err := paging.DecodeOffset(pr.GetPageToken())
+		if err != nil {
+			return Filter{}, err
+		}
+		f.Offset = offset
+		f.Limit = int(paging.PageSize(pr, DefaultPageSize, MaxPageSize))
+		return f, nil
+	}
+	f.Offset = int(max(req.GetOffset(), 0))
+	f.Limit = DefaultPageSize
+	if req.GetLimit() > 0 {
+		f.Limit = int(min(req.GetLimit(), MaxPageSize))
+	}
+	return f, nil
+}
+
+func checkListStatus(p *rauth.Principal, author int64, s forum.PostStatus) error {
+	switch s {
+	case forum.PostStatus_POST_STATUS_PUBLISHED:
+		return nil
+	case forum.PostStatus_POST_STATUS_DRAFT:
+		if p != nil && author == p.Uid {
+			return nil
+		}
+		return ErrStatusForbidden
+	case forum.PostStatus_POST_STATUS_HIDDEN:
+		if p.IsModerator() || (p != nil && author == p.Uid) {
+			return nil
+		}
+		return ErrStatusForbidden
+	case forum.PostStatus_POST_STATUS_DELETED:
+		if p.IsModerator() {
+			return nil
+		}
+		return ErrStatusForbidden
+	}
+	return ErrInvalidStatus
+}
+
+// OrderBy 排序对应的 SQL ORDER BY 子句，列名与 rmodel.BaseModel 及计数字段一致
+func OrderBy(s forum.PostSortOrder) string {
+	switch s {
+	case forum.PostSortOrder_POST_SORT_ORDER_OLDEST:
+		return "created_at ASC, id ASC"
+	case forum.PostSortOrder_POST_SORT_ORDER_RECENTLY_UPDATED:
+		return "updated_at DESC, id DESC"
+	case forum.PostSortOrder_POST_SORT_ORDER_MOST_VIEWED:
+		return "view_count DESC, id DESC"
+	case forum.PostSortOrder_POST_SORT_ORDER_MOST_LIKED:
+		return "like_count DESC, id DESC"
+	case forum.PostSortOrder_POST_SORT_ORDER_MOST_COMMENTED:
+		return "comment_count DESC, id DESC"
+	}
+	return "created_at DESC, id DESC"
+}
@@ -0,0 +1,106 @@
+package post
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/trancecho/mundo-proto-sdk/common/paging"
+	commonv1 "github.com/trancecho/mundo-proto-sdk/common/v1"
+	forum "github.com/trancecho/mundo-proto-sdk/forum_pb"
+	"github.com/trancecho/mundo-proto-sdk/rauth"
+)
+
+var (
+	author    = &rauth.Principal{Uid: 1}
+	stranger  = &rauth.Principal{Uid: 2}
+	moderator = &rauth.Principal{Uid: 3, Roles: []string{rauth.RoleModerator}}
+)
+
+func TestVisible(t *testing.T) {
+	tests := []struct {
+		status forum.PostStatus
+		want   map[*rauth.Principal]bool // nil 键表示未登录
+	}{
+		{forum.PostStatus_POST_STATUS_PUBLISHED, map[*rauth.Principal]bool{nil: true, author: true, stranger: true, moderator: true}},
+		{forum.PostStatus_POST_STATUS_DRAFT, map[*rauth.Principal]bool{nil: false, author: true, stranger: false, moderator: false}},
+		{forum.PostStatus_POST_STATUS_HIDDEN, map[*rauth.Principal]bool{nil: false, author: true, stranger: false, moderator: true}},
+		{forum.PostStatus_POST_STATUS_DELETED, map[*rauth.Principal]bool{nil: false, author: false, stranger: false, moderator: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.status.String(), func(t *testing.T) {
+			post := &forum.ForumPostResponse{Uid: author.Uid, Status: tt.status}
+			for p, want := range tt.want {
+				if got := Visible(p, post); got != want {
+					t.Errorf("Visible(%+v) = %v, want %v", p, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckStatusChange(t *testing.T) {
+	tests := []struct {
+		name     string
+		p        *rauth.Principal
+		from, to forum.PostStatus
+		want     error
+	}{
+		{"unchanged", author, forum.PostStatus_POST_STATUS_DRAFT, forum.PostStatus_POST_STATUS_UNSPECIFIED, nil},
+		{"publish draft", author, forum.PostStatus_POST_STATUS_DRAFT, forum.PostStatus_POST_STATUS_PUBLISHED, nil},
+		{"author hides", author, forum.PostStatus_POST_STATUS_PUBLISHED, forum.PostStatus_POST_STATUS_HIDDEN, ErrStatusForbidden},
+		{"moderator hides", moderator, forum.PostStatus_POST_STATUS_PUBLISHED, forum.PostStatus_POST_STATUS_HIDDEN, nil},
+		{"author unhides", author, forum.PostStatus_POST_STATUS_HIDDEN, forum.PostStatus_POST_STATUS_PUBLISHED, ErrStatusForbidden},
+		{"delete via update", moderator, forum.PostStatus_POST_STATUS_PUBLISHED, forum.PostStatus_POST_STATUS_DELETED, ErrInvalidStatus},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := CheckStatusChange(tt.p, tt.from, tt.to); !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseList(t *testing.T) {
+	page2 := paging.EncodeOffset(40)
+	tests := []struct {
+		name       string
+		req        *forum.ListForumPostsRequest
+		p          *rauth.Principal
+		wantErr    error
+		wantStatus []forum.PostStatus
+		wantOffset int
+		wantLimit  int
+	}{
+		{"defaults", &forum.ListForumPostsRequest{}, nil, nil,
+			[]forum.PostStatus{forum.PostStatus_POST_STATUS_PUBLISHED}, 0, DefaultPageSize},
+		{"legacy limit capped", &forum.ListForumPostsRequest{Limit: 500, Offset: 5}, nil, nil,
+			[]forum.PostStatus{forum.PostStatus_POST_STATUS_PUBLISHED}, 5, MaxPageSize},
+		{"pagination wins", &forum.ListForumPostsRequest{Limit: 5, Pagination: &commonv1.PageRequest{PageSize: 10, PageToken: page2}}, nil, nil,
+			[]forum.PostStatus{forum.PostStatus_POST_STATUS_PUBLISHED}, 40, 10},
+		{"own drafts", &forum.ListForumPostsRequest{AuthorUid: 1, Statuses: []forum.PostStatus{forum.PostStatus_POST_STATUS_DRAFT}}, author, nil,
+			[]forum.PostStatus{forum.PostStatus_POST_STATUS_DRAFT}, 0, DefaultPageSize},
+		{"others drafts", &forum.ListForumPostsRequest{AuthorUid: 1, Statuses: []forum.PostStatus{forum.PostStatus_POST_STATUS_DRAFT}}, stranger, ErrStatusForbidden, nil, 0, 0},
+		{"moderator cannot list others drafts", &forum.ListForumPostsRequest{AuthorUid: 1, Statuses: []forum.PostStatus{forum.PostStatus_POST_STATUS_DRAFT}}, moderator, ErrStatusForbidden, nil, 0, 0},
+		{"moderator lists hidden and deleted", &forum.ListForumPostsRequest{Statuses: []forum.PostStatus{forum.PostStatus_POST_STATUS_HIDDEN, forum.PostStatus_POST_STATUS_DELETED}}, moderator, nil,
+			[]forum.PostStatus{forum.PostStatus_POST_STATUS_HIDDEN, forum.PostStatus_POST_STATUS_DELETED}, 0, DefaultPageSize},
+		{"anonymous hidden", &forum.ListForumPostsRequest{Statuses: []forum.PostStatus{forum.PostStatus_POST_STATUS_HIDDEN}}, nil, ErrStatusForbidden, nil, 0, 0},
+		{"unknown status", &forum.ListForumPostsRequest{Statuses: []forum.PostStatus{forum.PostStatus(99)}}, moderator, ErrInvalidStatus, nil, 0, 0},
+		{"bad page token", &forum.ListForumPostsRequest{Pagination: &commonv1.PageRequest{PageToken: "!!"}}, nil, paging.ErrInvalidToken, nil, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := ParseList(tt.req, tt.p)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !slices.Equal(f.Statuses, tt.wantStatus) || f.Offset != tt.wantOffset || f.Limit != tt.wantLimit {
+				t.Fatalf("filter = %+v", f)
+			}
+		})
+	}
+}
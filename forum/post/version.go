@@ -0,0 +1,163 @@
+package post
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/trancecho/mundo-proto-sdk/forum/authz"
+	forum "github.com/trancecho/mundo-proto-sdk/forum_pb"
+	"github.com/trancecho/mundo-proto-sdk/rauth"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+// If-Match 请求头经 grpc-gateway 默认 header matcher 转换后的 metadata key
+const (
+	MetadataIfMatch        = "if-match"
+	MetadataGatewayIfMatch = "grpcgateway-if-match"
+)
+
+// UpdatePaths update_mask 支持的字段
+var UpdatePaths = []string{"title", "content", "tags", "category", "status"}
+
+// ETag 由版本号生成 ETag
+func ETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// ParseETag 解析 ETag，支持弱校验前缀 W/；"*" 及无法解析的值返回 0, false
+func ParseETag(etag string) (int64, bool) {
+	etag = strings.TrimPrefix(strings.TrimSpace(etag), "W/")
+	v, err := strconv.ParseInt(strings.Trim(etag, `"`), 10, 64)
+	if err != nil || v <= 0 {
+		return 0, false
+	}
+	return v, true
+}
+
+// ExpectedVersion 读取客户端期望的版本号：优先使用 req.version，其次使用 If-Match；
+// 返回 0 表示客户端未提供版本，不做校验
+func ExpectedVersion(ctx context.Context, req *forum.UpdateForumPostRequest) int64 {
+	if v := req.GetVersion(); v > 0 {
+		return v
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, key := range []string{MetadataIfMatch, MetadataGatewayIfMatch} {
+		for _, etag := range md.Get(key) {
+			if v, ok := ParseETag(etag); ok {
+				return v
+			}
+		}
+	}
+	return 0
+}
+
+// CheckVersion 校验版本号，expected 为 0 时不校验；不一致时返回 FailedPrecondition
+func CheckVersion(expected, current int64) error {
+	if expected == 0 || expected == current {
+		return nil
+	}
+	return authz.Error(codes.FailedPrecondition, forum.ForumErrorCode_FORUM_ERROR_CODE_VERSION_CONFLICT,
+		"post has been modified, current version is "+strconv.FormatInt(current, 10))
+}
+
+// SetVersion 设置帖子的版本号和 ETag
+func SetVersion(p *forum.ForumPostResponse, version int64) {
+	p.Version = version
+	p.Etag = ETag(version)
+}
+
+// ForwardETag 供 grpc-gateway 的 runtime.WithForwardResponseOption 使用，将帖子的 ETag 写入响应头
+func ForwardETag(_ context.Context, w http.ResponseWriter, m proto.Message) error {
+	if p, ok := m.(*forum.ForumPostResponse); ok && p.GetEtag() != "" {
+		w.Header().Set("ETag", p.GetEtag())
+	}
+	return nil
+}
+
+// ApplyUpdate 按 update_mask 将请求中的字段写入 dst，返回实际更新的字段；
+// update_mask 为空时更新请求中所有非零值字段。不校验权限和状态变更，见 CheckStatusChange。
+func ApplyUpdate(dst *forum.ForumPostResponse, req *forum.UpdateForumPostRequest) ([]string, error) {
+	paths := req.GetUpdateMask().GetPaths()
+	if len(paths) == 0 {
+		paths = setPaths(req)
+	}
+	for _, p := range paths {
+		if !slices.Contains(UpdatePaths, p) {
+			return nil, authz.Error(codes.InvalidArgument, forum.ForumErrorCode_FORUM_ERROR_CODE_INVALID_UPDATE_MASK,
+				"unsupported update_mask path: "+p)
+		}
+	}
+	for _, p := range paths {
+		switch p {
+		case "title":
+			dst.Title = req.GetTitle()
+		case "content":
+			dst.Content = req.GetContent()
+		case "tags":
+			tags, err := NormalizeTags(req.GetTags())
+			if err != nil {
+				return nil, authz.Error(codes.InvalidArgument, forum.ForumErrorCode_FORUM_ERROR_CODE_UNSPECIFIED, err.Error())
+			}
+			dst.Tags = tags
+		case "category":
+			dst.Category = req.GetCategory()
+		case "status":
+			dst.Status = req.GetStatus()
+		}
+	}
+	return paths, nil
+}
+
+func setPaths(req *forum.UpdateForumPostRequest) []string {
+	var paths []string
+	if req.GetTitle() != "" {
+		paths = append(paths, "title")
+	}
+	if req.GetContent() != "" {
+		paths = append(paths, "content")
+	}
+	if len(req.GetTags()) > 0 {
+		paths = append(paths, "tags")
+	}
+	if req.GetCategory() != "" {
+		paths = append(paths, "category")
+	}
+	if req.GetStatus() != forum.PostStatus_POST_STATUS_UNSPECIFIED {
+		paths = append(paths, "status")
+	}
+	return paths
+}
+
+// ErrorHandler 在默认错误处理的基础上，将版本冲突映射为 HTTP 412 Precondition Failed
+func ErrorHandler(ctx context.Context, mux *runtime.ServeMux, m runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	if authz.ErrorCode(err) == forum.ForumErrorCode_FORUM_ERROR_CODE_VERSION_CONFLICT {
+		w = &statusWriter{ResponseWriter: w, code: http.StatusPreconditionFailed}
+	}
+	runtime.DefaultHTTPErrorHandler(ctx, mux, m, w, r, err)
+}
+
+type statusWriter struct {
+	http.ResponseWriter
+	code int
+}
+
+func (w *statusWriter) WriteHeader(int) {
+	w.ResponseWriter.WriteHeader(w.code)
+}
+
+// GatewayOptions 论坛 HTTP 网关推荐的 ServeMux 选项：透传 If-Match，回写 ETag 响应头，版本冲突返回 412。
+// 默认丢弃客户端自带的 X-User-* 请求头；网关位于会覆盖这些请求头的可信代理之后时，
+// 追加 runtime.WithIncomingHeaderMatcher(rauth.HeaderMatcher(true)) 以透传身份
+func GatewayOptions() []runtime.ServeMuxOption {
+	return []runtime.ServeMuxOption{
+		runtime.WithIncomingHeaderMatcher(rauth.GatewayHeaderMatcher),
+		runtime.WithForwardResponseOption(ForwardETag),
+		runtime.WithErrorHandler(ErrorHandler),
+	}
+}
@@ -0,0 +1,168 @@
+package post
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/trancecho/mundo-proto-sdk/forum/authz"
+	forum "github.com/trancecho/mundo-proto-sdk/forum_pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestParseETag(t *testing.T) {
+	tests := []struct {
+		etag string
+		want int64
+		ok   bool
+	}{
+		{ETag(3), 3, true},
+		{`W/"3"`, 3, true},
+		{` "12" `, 12, true},
+		{"*", 0, false},
+		{`"0"`, 0, false},
+		{`"abc"`, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.etag, func(t *testing.T) {
+			if got, ok := ParseETag(tt.etag); got != tt.want || ok != tt.ok {
+				t.Fatalf("ParseETag = %d, %v; want %d, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestExpectedVersion(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *forum.UpdateForumPostRequest
+		md   metadata.MD
+		want int64
+	}{
+		{"none", &forum.UpdateForumPostRequest{}, nil, 0},
+		{"request wins", &forum.UpdateForumPostRequest{Version: 2}, metadata.Pairs(MetadataIfMatch, `"5"`), 2},
+		{"if-match", &forum.UpdateForumPostRequest{}, metadata.Pairs(MetadataIfMatch, `"5"`), 5},
+		{"gateway if-match", &forum.UpdateForumPostRequest{}, metadata.Pairs(MetadataGatewayIfMatch, `W/"6"`), 6},
+		{"wildcard ignored", &forum.UpdateForumPostRequest{}, metadata.Pairs(MetadataIfMatch, "*"), 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), tt.md)
+			if got := ExpectedVersion(ctx, tt.req); got != tt.want {
+				t.Fatalf("ExpectedVersion = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckVersion(t *testing.T) {
+	tests := []struct {
+		name              string
+		expected, current int64
+		wantConflict      bool
+	}{
+		{"unchecked", 0, 3, false},
+		{"match", 3, 3, false},
+		{"stale", 2, 3, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckVersion(tt.expected, tt.current)
+			if (err != nil) != tt.wantConflict {
+				t.Fatalf("err = %v, want conflict %v", err, tt.wantConflict)
+			}
+			if tt.wantConflict && (status.Code(err) != codes.FailedPrecondition ||
+				authz.ErrorCode(err) != forum.ForumErrorCode_FORUM_ERROR_CODE_VERSION_CONFLICT) {
+				t.Fatalf("err = %v, want VERSION_CONFLICT", err)
+			}
+		})
+	}
+}
+
+func TestApplyUpdate(t *testing.T) {
+	tests := []struct {
+		name      string
+		req       *forum.UpdateForumPostRequest
+		wantPaths []string
+		want      *forum.ForumPostResponse
+		wantErr   bool
+	}{
+		{"non-zero fields", &forum.UpdateForumPostRequest{Title: "new", Tags: []string{" Go ", "go"}},
+			[]string{"title", "tags"}, &forum.ForumPostResponse{Title: "new", Content: "body", Tags: []string{"go"}, Category: "c"}, false},
+		{"mask clears field", &forum.UpdateForumPostRequest{Title: "ignored", UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"category"}}},
+			[]string{"category"}, &forum.ForumPostResponse{Title: "old", Content: "body", Tags: []string{"a"}}, false},
+		{"unsupported path", &forum.UpdateForumPostRequest{UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"uid"}}},
+			nil, nil, true},
+		{"too many tags", &forum.UpdateForumPostRequest{Tags: manyTags(MaxTags + 1)}, nil, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dst := &forum.ForumPostResponse{Title: "old", Content: "body", Tags: []string{"a"}, Category: "c"}
+			paths, err := ApplyUpdate(dst, tt.req)
+			if (err != nil) != tt.wantErr || (err != nil && status.Code(err) != codes.InvalidArgument) {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !slices.Equal(paths, tt.wantPaths) {
+				t.Fatalf("paths = %v, want %v", paths, tt.wantPaths)
+			}
+			if dst.Title != tt.want.Title || dst.Content != tt.want.Content || dst.Category != tt.want.Category ||
+				!slices.Equal(dst.Tags, tt.want.Tags) {
+				t.Fatalf("dst = %+v, want %+v", dst, tt.want)
+			}
+		})
+	}
+}
+
+func manyTags(n int) []string {
+	tags := make([]string, n)
+	for i := range tags {
+		tags[i] = "t" + strconv.Itoa(i)
+	}
+	return tags
+}
+
+func TestErrorHandler(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"version conflict", CheckVersion(1, 2), http.StatusPreconditionFailed},
+		{"other failed precondition", status.Error(codes.FailedPrecondition, "x"), http.StatusBadRequest},
+		{"not found", status.Error(codes.NotFound, "x"), http.StatusNotFound},
+	}
+	mux := runtime.NewServeMux()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPatch, "/v1/posts/1", nil)
+			ErrorHandler(context.Background(), mux, &runtime.JSONPb{}, w, r, tt.err)
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestForwardETag(t *testing.T) {
+	w := httptest.NewRecorder()
+	p := &forum.ForumPostResponse{}
+	SetVersion(p, 4)
+	if err := ForwardETag(context.Background(), w, p); err != nil {
+		t.Fatal(err)
+	}
+	if got := w.Header().Get("ETag"); got != `"4"` || !strings.HasPrefix(p.GetEtag(), `"`) {
+		t.Fatalf("ETag = %q", got)
+	}
+}
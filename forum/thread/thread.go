@@ -0,0 +1,116 @@
+package thread
+
+import (
+	"errors"
+	"sort"
+
+	forum "github.com/trancecho/mundo-proto-sdk/forum_pb"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	DefaultReplyPreview = 3
+	MaxReplyPreview     = 10
+)
+
+var ErrParentMismatch = errors.New("thread: parent comment belongs to another post")
+
+// ReplyPreview 读取 ListCommentsRequest.reply_preview，未设置时返回默认值，负数返回 0
+func ReplyPreview(req *forum.ListCommentsRequest) int {
+	n := int(req.GetReplyPreview())
+	switch {
+	case n < 0:
+		return 0
+	case n == 0:
+		return DefaultReplyPreview
+	}
+	return min(n, MaxReplyPreview)
+}
+
+// Attach 根据被回复的评论补全新评论的 parent_id、root_id 和 reply_to_uid，
+// parent 为空时 c 为顶层评论，root_id 需在获得自身 ID 后由调用方设置
+func Attach(c, parent *forum.Comment) error {
+	if parent == nil {
+		c.ParentId, c.RootId, c.ReplyToUid = 0, 0, 0
+		return nil
+	}
+	if parent.GetPostId() != c.GetPostId() {
+		return ErrParentMismatch
+	}
+	c.ParentId = parent.GetId()
+	c.RootId = parent.GetRootId()
+	if c.RootId == 0 {
+		c.RootId = parent.GetId()
+	}
+	c.ReplyToUid = parent.GetUid()
+	return nil
+}
+
+// Build 将同一帖子下的评论（楼层及楼层内的回复，顺序不限）组装为楼层列表：
+// 楼层按 ID 正序，每层附带最早的 preview 条未删除回复，reply_count 只统计未删除的回复；
+// 预览中的回复所回复的已删除回复作为清空内容的占位一并返回（不计入 reply_count），使回复关系保持完整。
+// 已删除且没有回复的楼层被省略，保留的已删除楼层清空内容。
+// 返回的是 comments 的副本，不修改传入的评论。
+func Build(comments []*forum.Comment, preview int) []*forum.Comment {
+	var roots []*forum.Comment
+	replies := make(map[int64][]*forum.Comment)
+	deleted := make(map[int64]*forum.Comment)
+	for _, c := range comments {
+		switch {
+		case c.GetParentId() == 0:
+			roots = append(roots, c)
+		case c.GetDeleted():
+			deleted[c.GetId()] = c
+		default:
+			replies[c.GetRootId()] = append(replies[c.GetRootId()], c)
+		}
+	}
+	sort.Slice(roots, func(i, j int) bool { return roots[i].GetId() < roots[j].GetId() })
+
+	out := make([]*forum.Comment, 0, len(roots))
+	for _, root := range roots {
+		rs := replies[root.GetId()]
+		if root.GetDeleted() && len(rs) == 0 {
+			continue
+		}
+		sort.Slice(rs, func(i, j int) bool { return rs[i].GetId() < rs[j].GetId() })
+		floor := clone(root)
+		floor.ReplyCount = int32(len(rs))
+		shown := rs[:min(preview, len(rs))]
+		for _, r := range shown {
+			floor.Replies = append(floor.Replies, clone(r))
+		}
+		floor.Replies = append(floor.Replies, placeholders(shown, deleted, root.GetId())...)
+		sort.Slice(floor.Replies, func(i, j int) bool { return floor.Replies[i].GetId() < floor.Replies[j].GetId() })
+		out = append(out, floor)
+	}
+	return out
+}
+
+// placeholders 沿 parent_id 向上查找 shown 回复所回复的已删除回复，返回清空内容的副本
+func placeholders(shown []*forum.Comment, deleted map[int64]*forum.Comment, rootID int64) []*forum.Comment {
+	var out []*forum.Comment
+	seen := make(map[int64]bool)
+	for _, r := range shown {
+		for id := r.GetParentId(); id != rootID && !seen[id]; {
+			d, ok := deleted[id]
+			if !ok {
+				break
+			}
+			seen[id] = true
+			out = append(out, clone(d))
+			id = d.GetParentId()
+		}
+	}
+	return out
+}
+
+// clone 复制评论本身，不含 replies，已删除的评论清空内容
+func clone(c *forum.Comment) *forum.Comment {
+	c = proto.Clone(c).(*forum.Comment)
+	c.Replies = nil
+	if c.GetDeleted() {
+		c.Content = ""
+	}
+	return c
+}
@@ -0,0 +1,159 @@
+package thread
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	forum "github.com/trancecho/mundo-proto-sdk/forum_pb"
+)
+
+func TestReplyPreview(t *testing.T) {
+	tests := []struct {
+		in   int32
+		want int
+	}{
+		{-1, 0},
+		{0, DefaultReplyPreview},
+		{5, 5},
+		{100, MaxReplyPreview},
+	}
+	for _, tt := range tests {
+		if got := ReplyPreview(&forum.ListCommentsRequest{ReplyPreview: tt.in}); got != tt.want {
+			t.Errorf("ReplyPreview(%d) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestAttach(t *testing.T) {
+	root := &forum.Comment{Id: 1, PostId: 9, Uid: 100}
+	reply := &forum.Comment{Id: 2, PostId: 9, Uid: 200, ParentId: 1, RootId: 1}
+	tests := []struct {
+		name    string
+		parent  *forum.Comment
+		postID  int64
+		want    *forum.Comment
+		wantErr error
+	}{
+		{"top level", nil, 9, &forum.Comment{PostId: 9}, nil},
+		{"reply to root", root, 9, &forum.Comment{PostId: 9, ParentId: 1, RootId: 1, ReplyToUid: 100}, nil},
+		{"reply to reply", reply, 9, &forum.Comment{PostId: 9, ParentId: 2, RootId: 1, ReplyToUid: 200}, nil},
+		{"other post", root, 8, nil, ErrParentMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &forum.Comment{PostId: tt.postID, ParentId: 5, RootId: 5, ReplyToUid: 5}
+			err := Attach(c, tt.parent)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && (c.GetParentId() != tt.want.GetParentId() || c.GetRootId() != tt.want.GetRootId() || c.GetReplyToUid() != tt.want.GetReplyToUid()) {
+				t.Fatalf("comment = %v, want %v", c, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuild(t *testing.T) {
+	comments := func() []*forum.Comment {
+		return []*forum.Comment{
+			{Id: 7, ParentId: 1, RootId: 1, Content: "r3"},
+			{Id: 1, Content: "floor 1"},
+			{Id: 4, ParentId: 1, RootId: 1, Content: "r2", Deleted: true},
+			{Id: 3, ParentId: 1, RootId: 1, Content: "r1"},
+			{Id: 2, Content: "floor 2", Deleted: true},
+			{Id: 5, Content: "floor 5", Deleted: true},
+			{Id: 6, ParentId: 5, RootId: 5, Content: "r5"},
+			{Id: 8, Content: "floor 8", Deleted: true},
+			{Id: 9, ParentId: 8, RootId: 8, Content: "gone", Deleted: true},
+		}
+	}
+	type floor struct {
+		id      int64
+		content string
+		count   int32
+		replies []int64
+	}
+	tests := []struct {
+		name    string
+		preview int
+		want    []floor
+	}{
+		{"preview 1", 1, []floor{{1, "floor 1", 2, []int64{3}}, {5, "", 1, []int64{6}}}},
+		{"preview all", 10, []floor{{1, "floor 1", 2, []int64{3, 7}}, {5, "", 1, []int64{6}}}},
+		{"no preview", 0, []floor{{1, "floor 1", 2, nil}, {5, "", 1, nil}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := Build(comments(), tt.preview)
+			var got []floor
+			for _, c := range out {
+				var ids []int64
+				for _, r := range c.GetReplies() {
+					ids = append(ids, r.GetId())
+				}
+				got = append(got, floor{c.GetId(), c.GetContent(), c.GetReplyCount(), ids})
+			}
+			if !slices.EqualFunc(got, tt.want, func(a, b floor) bool {
+				return a.id == b.id && a.content == b.content && a.count == b.count && slices.Equal(a.replies, b.replies)
+			}) {
+				t.Fatalf("Build = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildPlaceholders(t *testing.T) {
+	comments := []*forum.Comment{
+		{Id: 1, Content: "floor 1"},
+		{Id: 2, ParentId: 1, RootId: 1, Content: "deleted a", Deleted: true},
+		{Id: 3, ParentId: 2, RootId: 1, Content: "deleted b", Deleted: true},
+		{Id: 4, ParentId: 3, RootId: 1, Content: "reply to b"},
+		{Id: 5, ParentId: 1, RootId: 1, Content: "unreferenced", Deleted: true},
+		{Id: 6, ParentId: 2, RootId: 1, Content: "reply to a"},
+		{Id: 7, ParentId: 1, RootId: 1, Content: "r7"},
+	}
+	type reply struct {
+		id      int64
+		content string
+	}
+	tests := []struct {
+		name    string
+		preview int
+		want    []reply
+	}{
+		{"chain of deleted parents", 1, []reply{{2, ""}, {3, ""}, {4, "reply to b"}}},
+		{"shared placeholder", 2, []reply{{2, ""}, {3, ""}, {4, "reply to b"}, {6, "reply to a"}}},
+		{"no preview", 0, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := Build(comments, tt.preview)
+			if len(out) != 1 || out[0].GetReplyCount() != 3 {
+				t.Fatalf("Build = %v, want one floor with reply_count 3", out)
+			}
+			var got []reply
+			for _, r := range out[0].GetReplies() {
+				got = append(got, reply{r.GetId(), r.GetContent()})
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("replies = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildDoesNotMutate(t *testing.T) {
+	root := &forum.Comment{Id: 1, Content: "floor 1", Deleted: true}
+	reply := &forum.Comment{Id: 2, ParentId: 1, RootId: 1, Content: "r2"}
+	out := Build([]*forum.Comment{root, reply}, 3)
+	if len(out) != 1 || out[0].GetContent() != "" || len(out[0].GetReplies()) != 1 {
+		t.Fatalf("Build = %v", out)
+	}
+	if root.GetContent() != "floor 1" || root.GetReplyCount() != 0 || root.GetReplies() != nil {
+		t.Fatalf("input comment modified: %v", root)
+	}
+	if out[0] == root || out[0].GetReplies()[0] == reply {
+		t.Fatal("Build returned the input comments instead of copies")
+	}
+}
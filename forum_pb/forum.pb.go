@@ -7,9 +7,13 @@
 package forum_pb
 
 import (
+	v1 "github.com/trancecho/mundo-proto-sdk/common/v1"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -22,10 +26,195 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// 帖子状态
+type PostStatus int32
+
+const (
+	PostStatus_POST_STATUS_UNSPECIFIED PostStatus = 0
+	PostStatus_POST_STATUS_DRAFT       PostStatus = 1 // 草稿，仅作者可见
+	PostStatus_POST_STATUS_PUBLISHED   PostStatus = 2 // 已发布
+	PostStatus_POST_STATUS_HIDDEN      PostStatus = 3 // 被版主隐藏，作者和版主可见
+	PostStatus_POST_STATUS_DELETED     PostStatus = 4 // 已删除，仅版主可见
+)
+
+// Enum value maps for PostStatus.
+var (
+	PostStatus_name = map[int32]string{
+		0: "POST_STATUS_UNSPECIFIED",
+		1: "POST_STATUS_DRAFT",
+		2: "POST_STATUS_PUBLISHED",
+		3: "POST_STATUS_HIDDEN",
+		4: "POST_STATUS_DELETED",
+	}
+	PostStatus_value = map[string]int32{
+		"POST_STATUS_UNSPECIFIED": 0,
+		"POST_STATUS_DRAFT":       1,
+		"POST_STATUS_PUBLISHED":   2,
+		"POST_STATUS_HIDDEN":      3,
+		"POST_STATUS_DELETED":     4,
+	}
+)
+
+func (x PostStatus) Enum() *PostStatus {
+	p := new(PostStatus)
+	*p = x
+	return p
+}
+
+func (x PostStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PostStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_forum_pb_forum_proto_enumTypes[0].Descriptor()
+}
+
+func (PostStatus) Type() protoreflect.EnumType {
+	return &file_forum_pb_forum_proto_enumTypes[0]
+}
+
+func (x PostStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PostStatus.Descriptor instead.
+func (PostStatus) EnumDescriptor() ([]byte, []int) {
+	return file_forum_pb_forum_proto_rawDescGZIP(), []int{0}
+}
+
+// 帖子排序
+type PostSortOrder int32
+
+const (
+	PostSortOrder_POST_SORT_ORDER_UNSPECIFIED      PostSortOrder = 0 // 默认按发布时间倒序
+	PostSortOrder_POST_SORT_ORDER_NEWEST           PostSortOrder = 1
+	PostSortOrder_POST_SORT_ORDER_OLDEST           PostSortOrder = 2
+	PostSortOrder_POST_SORT_ORDER_RECENTLY_UPDATED PostSortOrder = 3
+	PostSortOrder_POST_SORT_ORDER_MOST_VIEWED      PostSortOrder = 4
+	PostSortOrder_POST_SORT_ORDER_MOST_LIKED       PostSortOrder = 5
+	PostSortOrder_POST_SORT_ORDER_MOST_COMMENTED   PostSortOrder = 6
+)
+
+// Enum value maps for PostSortOrder.
+var (
+	PostSortOrder_name = map[int32]string{
+		0: "POST_SORT_ORDER_UNSPECIFIED",
+		1: "POST_SORT_ORDER_NEWEST",
+		2: "POST_SORT_ORDER_OLDEST",
+		3: "POST_SORT_ORDER_RECENTLY_UPDATED",
+		4: "POST_SORT_ORDER_MOST_VIEWED",
+		5: "POST_SORT_ORDER_MOST_LIKED",
+		6: "POST_SORT_ORDER_MOST_COMMENTED",
+	}
+	PostSortOrder_value = map[string]int32{
+		"POST_SORT_ORDER_UNSPECIFIED":      0,
+		"POST_SORT_ORDER_NEWEST":           1,
+		"POST_SORT_ORDER_OLDEST":           2,
+		"POST_SORT_ORDER_RECENTLY_UPDATED": 3,
+		"POST_SORT_ORDER_MOST_VIEWED":      4,
+		"POST_SORT_ORDER_MOST_LIKED":       5,
+		"POST_SORT_ORDER_MOST_COMMENTED":   6,
+	}
+)
+
+func (x PostSortOrder) Enum() *PostSortOrder {
+	p := new(PostSortOrder)
+	*p = x
+	return p
+}
+
+func (x PostSortOrder) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PostSortOrder) Descriptor() protoreflect.EnumDescriptor {
+	return file_forum_pb_forum_proto_enumTypes[1].Descriptor()
+}
+
+func (PostSortOrder) Type() protoreflect.EnumType {
+	return &file_forum_pb_forum_proto_enumTypes[1]
+}
+
+func (x PostSortOrder) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PostSortOrder.Descriptor instead.
+func (PostSortOrder) EnumDescriptor() ([]byte, []int) {
+	return file_forum_pb_forum_proto_rawDescGZIP(), []int{1}
+}
+
+// 论坛错误码，放在 gRPC 错误的 google.rpc.ErrorInfo.reason 中（取枚举名），
+// domain 为 "forum.mundo"，客户端应据此判断错误类型而不是解析错误信息
+type ForumErrorCode int32
+
+const (
+	ForumErrorCode_FORUM_ERROR_CODE_UNSPECIFIED         ForumErrorCode = 0
+	ForumErrorCode_FORUM_ERROR_CODE_UNAUTHENTICATED     ForumErrorCode = 1 // 未登录
+	ForumErrorCode_FORUM_ERROR_CODE_NOT_OWNER           ForumErrorCode = 2 // 非作者且非版主
+	ForumErrorCode_FORUM_ERROR_CODE_POST_NOT_FOUND      ForumErrorCode = 3
+	ForumErrorCode_FORUM_ERROR_CODE_COMMENT_NOT_FOUND   ForumErrorCode = 4
+	ForumErrorCode_FORUM_ERROR_CODE_VERSION_CONFLICT    ForumErrorCode = 5 // 版本号不一致，需重新读取后再更新
+	ForumErrorCode_FORUM_ERROR_CODE_INVALID_UPDATE_MASK ForumErrorCode = 6 // update_mask 包含不支持的字段
+)
+
+// Enum value maps for ForumErrorCode.
+var (
+	ForumErrorCode_name = map[int32]string{
+		0: "FORUM_ERROR_CODE_UNSPECIFIED",
+		1: "FORUM_ERROR_CODE_UNAUTHENTICATED",
+		2: "FORUM_ERROR_CODE_NOT_OWNER",
+		3: "FORUM_ERROR_CODE_POST_NOT_FOUND",
+		4: "FORUM_ERROR_CODE_COMMENT_NOT_FOUND",
+		5: "FORUM_ERROR_CODE_VERSION_CONFLICT",
+		6: "FORUM_ERROR_CODE_INVALID_UPDATE_MASK",
+	}
+	ForumErrorCode_value = map[string]int32{
+		"FORUM_ERROR_CODE_UNSPECIFIED":         0,
+		"FORUM_ERROR_CODE_UNAUTHENTICATED":     1,
+		"FORUM_ERROR_CODE_NOT_OWNER":           2,
+		"FORUM_ERROR_CODE_POST_NOT_FOUND":      3,
+		"FORUM_ERROR_CODE_COMMENT_NOT_FOUND":   4,
+		"FORUM_ERROR_CODE_VERSION_CONFLICT":    5,
+		"FORUM_ERROR_CODE_INVALID_UPDATE_MASK": 6,
+	}
+)
+
+func (x ForumErrorCode) Enum() *ForumErrorCode {
+	p := new(ForumErrorCode)
+	*p = x
+	return p
+}
+
+func (x ForumErrorCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ForumErrorCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_forum_pb_forum_proto_enumTypes[2].Descriptor()
+}
+
+func (ForumErrorCode) Type() protoreflect.EnumType {
+	return &file_forum_pb_forum_proto_enumTypes[2]
+}
+
+func (x ForumErrorCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ForumErrorCode.Descriptor instead.
+func (ForumErrorCode) EnumDescriptor() ([]byte, []int) {
+	return file_forum_pb_forum_proto_rawDescGZIP(), []int{2}
+}
+
+// 发帖请求，作者由服务端从已认证用户（rauth.Principal）获取，客户端无法指定
 type CreateForumPostRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
 	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	Tags          []string               `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`                            // 标签，最多 10 个
+	Category      string                 `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`                    // 分类
+	Status        PostStatus             `protobuf:"varint,5,opt,name=status,proto3,enum=forum.PostStatus" json:"status,omitempty"` // 仅可为 DRAFT 或 PUBLISHED，未设置时为 PUBLISHED
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -74,6 +263,27 @@ func (x *CreateForumPostRequest) GetContent() string {
 	return ""
 }
 
+func (x *CreateForumPostRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *CreateForumPostRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *CreateForumPostRequest) GetStatus() PostStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PostStatus_POST_STATUS_UNSPECIFIED
+}
+
 type GetForumPostByIDRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -120,8 +330,14 @@ func (x *GetForumPostByIDRequest) GetId() int64 {
 
 type ListForumPostsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset        int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`                                    // 已废弃，请使用 pagination
+	Offset        int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`                                  // 已废弃，请使用 pagination
+	Pagination    *v1.PageRequest        `protobuf:"bytes,3,opt,name=pagination,proto3" json:"pagination,omitempty"`                           // 设置时忽略 limit/offset
+	Tag           string                 `protobuf:"bytes,4,opt,name=tag,proto3" json:"tag,omitempty"`                                         // 按标签过滤
+	Category      string                 `protobuf:"bytes,5,opt,name=category,proto3" json:"category,omitempty"`                               // 按分类过滤
+	AuthorUid     int64                  `protobuf:"varint,6,opt,name=author_uid,json=authorUid,proto3" json:"author_uid,omitempty"`           // 按作者过滤
+	Statuses      []PostStatus           `protobuf:"varint,7,rep,packed,name=statuses,proto3,enum=forum.PostStatus" json:"statuses,omitempty"` // 按状态过滤，为空时仅返回 PUBLISHED；DRAFT 仅作者本人可查询，HIDDEN 作者本人及版主可查询，DELETED 仅版主可查询（版主可查看除他人草稿外的全部状态）
+	Sort          PostSortOrder          `protobuf:"varint,8,opt,name=sort,proto3,enum=forum.PostSortOrder" json:"sort,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -170,9 +386,52 @@ func (x *ListForumPostsRequest) GetOffset() int32 {
 	return 0
 }
 
+func (x *ListForumPostsRequest) GetPagination() *v1.PageRequest {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+func (x *ListForumPostsRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *ListForumPostsRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *ListForumPostsRequest) GetAuthorUid() int64 {
+	if x != nil {
+		return x.AuthorUid
+	}
+	return 0
+}
+
+func (x *ListForumPostsRequest) GetStatuses() []PostStatus {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *ListForumPostsRequest) GetSort() PostSortOrder {
+	if x != nil {
+		return x.Sort
+	}
+	return PostSortOrder_POST_SORT_ORDER_UNSPECIFIED
+}
+
 type ListForumPostsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Posts         []*ForumPostResponse   `protobuf:"bytes,1,rep,name=posts,proto3" json:"posts,omitempty"`
+	Pagination    *v1.PageResponse       `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -214,11 +473,28 @@ func (x *ListForumPostsResponse) GetPosts() []*ForumPostResponse {
 	return nil
 }
 
+func (x *ListForumPostsResponse) GetPagination() *v1.PageResponse {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+// 更新帖子，仅作者本人或版主可操作
 type UpdateForumPostRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Content       string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title    string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Content  string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	Tags     []string               `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
+	Category string                 `protobuf:"bytes,5,opt,name=category,proto3" json:"category,omitempty"`
+	Status   PostStatus             `protobuf:"varint,6,opt,name=status,proto3,enum=forum.PostStatus" json:"status,omitempty"` // 作者可改为 DRAFT/PUBLISHED，HIDDEN 仅版主可设置
+	// 客户端读取到的版本号，与当前版本不一致时返回 FailedPrecondition（FORUM_ERROR_CODE_VERSION_CONFLICT）；
+	// 为 0 时使用 HTTP If-Match 请求头中的 ETag，两者都没有时不做校验（兼容旧客户端）
+	Version int64 `protobuf:"varint,7,opt,name=version,proto3" json:"version,omitempty"`
+	// 需要更新的字段：title、content、tags、category、status；
+	// 为空时更新请求中所有非零值字段
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,8,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -274,6 +550,42 @@ func (x *UpdateForumPostRequest) GetContent() string {
 	return ""
 }
 
+func (x *UpdateForumPostRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *UpdateForumPostRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *UpdateForumPostRequest) GetStatus() PostStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PostStatus_POST_STATUS_UNSPECIFIED
+}
+
+func (x *UpdateForumPostRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *UpdateForumPostRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+// 删除帖子，仅作者本人或版主可操作
 type DeleteForumPostRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -321,9 +633,21 @@ func (x *DeleteForumPostRequest) GetId() int64 {
 type ForumPostResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Uid           int64                  `protobuf:"varint,2,opt,name=uid,proto3" json:"uid,omitempty"`
+	Uid           int64                  `protobuf:"varint,2,opt,name=uid,proto3" json:"uid,omitempty"` // 作者 ID，由服务端写入
 	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
 	Content       string                 `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
+	Author        *v1.UserRef            `protobuf:"bytes,5,opt,name=author,proto3" json:"author,omitempty"` // 作者统一标识，uid 与 author.uid 一致
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Tags          []string               `protobuf:"bytes,8,rep,name=tags,proto3" json:"tags,omitempty"`
+	Category      string                 `protobuf:"bytes,9,opt,name=category,proto3" json:"category,omitempty"`
+	Status        PostStatus             `protobuf:"varint,10,opt,name=status,proto3,enum=forum.PostStatus" json:"status,omitempty"`
+	ViewCount     int64                  `protobuf:"varint,11,opt,name=view_count,json=viewCount,proto3" json:"view_count,omitempty"`
+	LikeCount     int64                  `protobuf:"varint,12,opt,name=like_count,json=likeCount,proto3" json:"like_count,omitempty"`
+	CommentCount  int64                  `protobuf:"varint,13,opt,name=comment_count,json=commentCount,proto3" json:"comment_count,omitempty"`
+	AuthorInfo    *AuthorInfo            `protobuf:"bytes,14,opt,name=author_info,json=authorInfo,proto3" json:"author_info,omitempty"` // 作者展示信息，列表接口同样返回
+	Version       int64                  `protobuf:"varint,15,opt,name=version,proto3" json:"version,omitempty"`                        // 版本号，每次更新加 1
+	Etag          string                 `protobuf:"bytes,16,opt,name=etag,proto3" json:"etag,omitempty"`                               // 由 version 生成的 ETag，HTTP 响应同时写入 ETag 响应头
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -386,6 +710,520 @@ func (x *ForumPostResponse) GetContent() string {
 	return ""
 }
 
+func (x *ForumPostResponse) GetAuthor() *v1.UserRef {
+	if x != nil {
+		return x.Author
+	}
+	return nil
+}
+
+func (x *ForumPostResponse) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ForumPostResponse) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *ForumPostResponse) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ForumPostResponse) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *ForumPostResponse) GetStatus() PostStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PostStatus_POST_STATUS_UNSPECIFIED
+}
+
+func (x *ForumPostResponse) GetViewCount() int64 {
+	if x != nil {
+		return x.ViewCount
+	}
+	return 0
+}
+
+func (x *ForumPostResponse) GetLikeCount() int64 {
+	if x != nil {
+		return x.LikeCount
+	}
+	return 0
+}
+
+func (x *ForumPostResponse) GetCommentCount() int64 {
+	if x != nil {
+		return x.CommentCount
+	}
+	return 0
+}
+
+func (x *ForumPostResponse) GetAuthorInfo() *AuthorInfo {
+	if x != nil {
+		return x.AuthorInfo
+	}
+	return nil
+}
+
+func (x *ForumPostResponse) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *ForumPostResponse) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+// 作者展示信息
+type AuthorInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uid           int64                  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	AvatarUrl     string                 `protobuf:"bytes,3,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty"`
+	Level         int32                  `protobuf:"varint,4,opt,name=level,proto3" json:"level,omitempty"` // 积分系统等级
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuthorInfo) Reset() {
+	*x = AuthorInfo{}
+	mi := &file_forum_pb_forum_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthorInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthorInfo) ProtoMessage() {}
+
+func (x *AuthorInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_forum_pb_forum_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthorInfo.ProtoReflect.Descriptor instead.
+func (*AuthorInfo) Descriptor() ([]byte, []int) {
+	return file_forum_pb_forum_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *AuthorInfo) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *AuthorInfo) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *AuthorInfo) GetAvatarUrl() string {
+	if x != nil {
+		return x.AvatarUrl
+	}
+	return ""
+}
+
+func (x *AuthorInfo) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+// 评论。楼中楼结构：顶层评论为楼层（parent_id = 0），
+// 楼层内的所有回复 root_id 指向楼层，parent_id 指向被直接回复的评论
+type Comment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	PostId        int64                  `protobuf:"varint,2,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	Uid           int64                  `protobuf:"varint,3,opt,name=uid,proto3" json:"uid,omitempty"`                                   // 作者 ID
+	Author        *v1.UserRef            `protobuf:"bytes,4,opt,name=author,proto3" json:"author,omitempty"`                              // 作者统一标识
+	ParentId      int64                  `protobuf:"varint,5,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`         // 被回复的评论 ID，顶层评论为 0
+	RootId        int64                  `protobuf:"varint,6,opt,name=root_id,json=rootId,proto3" json:"root_id,omitempty"`               // 所属楼层的顶层评论 ID，顶层评论为自身 ID
+	ReplyToUid    int64                  `protobuf:"varint,7,opt,name=reply_to_uid,json=replyToUid,proto3" json:"reply_to_uid,omitempty"` // 被回复评论的作者 ID，顶层评论为 0
+	Content       string                 `protobuf:"bytes,8,opt,name=content,proto3" json:"content,omitempty"`                            // 已删除时为空
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Deleted       bool                   `protobuf:"varint,10,opt,name=deleted,proto3" json:"deleted,omitempty"`                         // 已删除但仍有回复，保留占位
+	ReplyCount    int32                  `protobuf:"varint,11,opt,name=reply_count,json=replyCount,proto3" json:"reply_count,omitempty"` // 楼层内的回复总数，仅顶层评论有值
+	Replies       []*Comment             `protobuf:"bytes,12,rep,name=replies,proto3" json:"replies,omitempty"`                          // 楼层内最早的若干条回复，仅 ListComments 查询楼层时返回
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Comment) Reset() {
+	*x = Comment{}
+	mi := &file_forum_pb_forum_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Comment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Comment) ProtoMessage() {}
+
+func (x *Comment) ProtoReflect() protoreflect.Message {
+	mi := &file_forum_pb_forum_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Comment.ProtoReflect.Descriptor instead.
+func (*Comment) Descriptor() ([]byte, []int) {
+	return file_forum_pb_forum_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Comment) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Comment) GetPostId() int64 {
+	if x != nil {
+		return x.PostId
+	}
+	return 0
+}
+
+func (x *Comment) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *Comment) GetAuthor() *v1.UserRef {
+	if x != nil {
+		return x.Author
+	}
+	return nil
+}
+
+func (x *Comment) GetParentId() int64 {
+	if x != nil {
+		return x.ParentId
+	}
+	return 0
+}
+
+func (x *Comment) GetRootId() int64 {
+	if x != nil {
+		return x.RootId
+	}
+	return 0
+}
+
+func (x *Comment) GetReplyToUid() int64 {
+	if x != nil {
+		return x.ReplyToUid
+	}
+	return 0
+}
+
+func (x *Comment) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *Comment) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Comment) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+func (x *Comment) GetReplyCount() int32 {
+	if x != nil {
+		return x.ReplyCount
+	}
+	return 0
+}
+
+func (x *Comment) GetReplies() []*Comment {
+	if x != nil {
+		return x.Replies
+	}
+	return nil
+}
+
+// 评论请求，作者由服务端从已认证用户获取
+type CreateCommentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostId        int64                  `protobuf:"varint,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	ParentId      int64                  `protobuf:"varint,2,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"` // 被回复的评论 ID，0 表示发表顶层评论
+	Content       string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCommentRequest) Reset() {
+	*x = CreateCommentRequest{}
+	mi := &file_forum_pb_forum_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCommentRequest) ProtoMessage() {}
+
+func (x *CreateCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_forum_pb_forum_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCommentRequest.ProtoReflect.Descriptor instead.
+func (*CreateCommentRequest) Descriptor() ([]byte, []int) {
+	return file_forum_pb_forum_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CreateCommentRequest) GetPostId() int64 {
+	if x != nil {
+		return x.PostId
+	}
+	return 0
+}
+
+func (x *CreateCommentRequest) GetParentId() int64 {
+	if x != nil {
+		return x.ParentId
+	}
+	return 0
+}
+
+func (x *CreateCommentRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+type ListCommentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostId        int64                  `protobuf:"varint,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	RootId        int64                  `protobuf:"varint,2,opt,name=root_id,json=rootId,proto3" json:"root_id,omitempty"`                   // 0 表示查询楼层，非 0 表示查询该楼层内的回复（按时间正序）
+	ReplyPreview  int32                  `protobuf:"varint,3,opt,name=reply_preview,json=replyPreview,proto3" json:"reply_preview,omitempty"` // 查询楼层时每层附带的回复数，默认 3，最大 10，负数表示不附带
+	Pagination    *v1.PageRequest        `protobuf:"bytes,4,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCommentsRequest) Reset() {
+	*x = ListCommentsRequest{}
+	mi := &file_forum_pb_forum_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCommentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCommentsRequest) ProtoMessage() {}
+
+func (x *ListCommentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_forum_pb_forum_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCommentsRequest.ProtoReflect.Descriptor instead.
+func (*ListCommentsRequest) Descriptor() ([]byte, []int) {
+	return file_forum_pb_forum_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListCommentsRequest) GetPostId() int64 {
+	if x != nil {
+		return x.PostId
+	}
+	return 0
+}
+
+func (x *ListCommentsRequest) GetRootId() int64 {
+	if x != nil {
+		return x.RootId
+	}
+	return 0
+}
+
+func (x *ListCommentsRequest) GetReplyPreview() int32 {
+	if x != nil {
+		return x.ReplyPreview
+	}
+	return 0
+}
+
+func (x *ListCommentsRequest) GetPagination() *v1.PageRequest {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type ListCommentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Comments      []*Comment             `protobuf:"bytes,1,rep,name=comments,proto3" json:"comments,omitempty"`
+	Pagination    *v1.PageResponse       `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCommentsResponse) Reset() {
+	*x = ListCommentsResponse{}
+	mi := &file_forum_pb_forum_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCommentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCommentsResponse) ProtoMessage() {}
+
+func (x *ListCommentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_forum_pb_forum_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCommentsResponse.ProtoReflect.Descriptor instead.
+func (*ListCommentsResponse) Descriptor() ([]byte, []int) {
+	return file_forum_pb_forum_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListCommentsResponse) GetComments() []*Comment {
+	if x != nil {
+		return x.Comments
+	}
+	return nil
+}
+
+func (x *ListCommentsResponse) GetPagination() *v1.PageResponse {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+// 删除评论，仅作者本人或版主可操作
+type DeleteCommentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCommentRequest) Reset() {
+	*x = DeleteCommentRequest{}
+	mi := &file_forum_pb_forum_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCommentRequest) ProtoMessage() {}
+
+func (x *DeleteCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_forum_pb_forum_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCommentRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCommentRequest) Descriptor() ([]byte, []int) {
+	return file_forum_pb_forum_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DeleteCommentRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// Empty 与 google.protobuf.Empty 等价，仅为兼容保留，新接口请直接使用 google.protobuf.Empty
 type Empty struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -394,7 +1232,7 @@ type Empty struct {
 
 func (x *Empty) Reset() {
 	*x = Empty{}
-	mi := &file_forum_pb_forum_proto_msgTypes[7]
+	mi := &file_forum_pb_forum_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -406,7 +1244,7 @@ func (x *Empty) String() string {
 func (*Empty) ProtoMessage() {}
 
 func (x *Empty) ProtoReflect() protoreflect.Message {
-	mi := &file_forum_pb_forum_proto_msgTypes[7]
+	mi := &file_forum_pb_forum_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -419,44 +1257,151 @@ func (x *Empty) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Empty.ProtoReflect.Descriptor instead.
 func (*Empty) Descriptor() ([]byte, []int) {
-	return file_forum_pb_forum_proto_rawDescGZIP(), []int{7}
+	return file_forum_pb_forum_proto_rawDescGZIP(), []int{13}
 }
 
 var File_forum_pb_forum_proto protoreflect.FileDescriptor
 
 const file_forum_pb_forum_proto_rawDesc = "" +
 	"\n" +
-	"\x14forum_pb/forum.proto\x12\x05forum\x1a\x1cgoogle/api/annotations.proto\"H\n" +
+	"\x14forum_pb/forum.proto\x12\x05forum\x1a\x1cgoogle/api/annotations.proto\x1a\x16common/v1/common.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a google/protobuf/field_mask.proto\x1a\x1bgoogle/protobuf/empty.proto\"\xa3\x01\n" +
 	"\x16CreateForumPostRequest\x12\x14\n" +
 	"\x05title\x18\x01 \x01(\tR\x05title\x12\x18\n" +
-	"\acontent\x18\x02 \x01(\tR\acontent\")\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\x12\x12\n" +
+	"\x04tags\x18\x03 \x03(\tR\x04tags\x12\x1a\n" +
+	"\bcategory\x18\x04 \x01(\tR\bcategory\x12)\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x11.forum.PostStatusR\x06status\")\n" +
 	"\x17GetForumPostByIDRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\"E\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\xa3\x02\n" +
 	"\x15ListForumPostsRequest\x12\x14\n" +
 	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06offset\x18\x02 \x01(\x05R\x06offset\"H\n" +
+	"\x06offset\x18\x02 \x01(\x05R\x06offset\x126\n" +
+	"\n" +
+	"pagination\x18\x03 \x01(\v2\x16.common.v1.PageRequestR\n" +
+	"pagination\x12\x10\n" +
+	"\x03tag\x18\x04 \x01(\tR\x03tag\x12\x1a\n" +
+	"\bcategory\x18\x05 \x01(\tR\bcategory\x12\x1d\n" +
+	"\n" +
+	"author_uid\x18\x06 \x01(\x03R\tauthorUid\x12-\n" +
+	"\bstatuses\x18\a \x03(\x0e2\x11.forum.PostStatusR\bstatuses\x12(\n" +
+	"\x04sort\x18\b \x01(\x0e2\x14.forum.PostSortOrderR\x04sort\"\x81\x01\n" +
 	"\x16ListForumPostsResponse\x12.\n" +
-	"\x05posts\x18\x01 \x03(\v2\x18.forum.ForumPostResponseR\x05posts\"X\n" +
+	"\x05posts\x18\x01 \x03(\v2\x18.forum.ForumPostResponseR\x05posts\x127\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2\x17.common.v1.PageResponseR\n" +
+	"pagination\"\x8a\x02\n" +
 	"\x16UpdateForumPostRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12\x18\n" +
-	"\acontent\x18\x03 \x01(\tR\acontent\"(\n" +
+	"\acontent\x18\x03 \x01(\tR\acontent\x12\x12\n" +
+	"\x04tags\x18\x04 \x03(\tR\x04tags\x12\x1a\n" +
+	"\bcategory\x18\x05 \x01(\tR\bcategory\x12)\n" +
+	"\x06status\x18\x06 \x01(\x0e2\x11.forum.PostStatusR\x06status\x12\x18\n" +
+	"\aversion\x18\a \x01(\x03R\aversion\x12;\n" +
+	"\vupdate_mask\x18\b \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"(\n" +
 	"\x16DeleteForumPostRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\"e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\xa7\x04\n" +
 	"\x11ForumPostResponse\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x10\n" +
 	"\x03uid\x18\x02 \x01(\x03R\x03uid\x12\x14\n" +
 	"\x05title\x18\x03 \x01(\tR\x05title\x12\x18\n" +
-	"\acontent\x18\x04 \x01(\tR\acontent\"\a\n" +
-	"\x05Empty2\xe9\x03\n" +
+	"\acontent\x18\x04 \x01(\tR\acontent\x12*\n" +
+	"\x06author\x18\x05 \x01(\v2\x12.common.v1.UserRefR\x06author\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x12\n" +
+	"\x04tags\x18\b \x03(\tR\x04tags\x12\x1a\n" +
+	"\bcategory\x18\t \x01(\tR\bcategory\x12)\n" +
+	"\x06status\x18\n" +
+	" \x01(\x0e2\x11.forum.PostStatusR\x06status\x12\x1d\n" +
+	"\n" +
+	"view_count\x18\v \x01(\x03R\tviewCount\x12\x1d\n" +
+	"\n" +
+	"like_count\x18\f \x01(\x03R\tlikeCount\x12#\n" +
+	"\rcomment_count\x18\r \x01(\x03R\fcommentCount\x122\n" +
+	"\vauthor_info\x18\x0e \x01(\v2\x11.forum.AuthorInfoR\n" +
+	"authorInfo\x12\x18\n" +
+	"\aversion\x18\x0f \x01(\x03R\aversion\x12\x12\n" +
+	"\x04etag\x18\x10 \x01(\tR\x04etag\"o\n" +
+	"\n" +
+	"AuthorInfo\x12\x10\n" +
+	"\x03uid\x18\x01 \x01(\x03R\x03uid\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1d\n" +
+	"\n" +
+	"avatar_url\x18\x03 \x01(\tR\tavatarUrl\x12\x14\n" +
+	"\x05level\x18\x04 \x01(\x05R\x05level\"\x82\x03\n" +
+	"\aComment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\apost_id\x18\x02 \x01(\x03R\x06postId\x12\x10\n" +
+	"\x03uid\x18\x03 \x01(\x03R\x03uid\x12*\n" +
+	"\x06author\x18\x04 \x01(\v2\x12.common.v1.UserRefR\x06author\x12\x1b\n" +
+	"\tparent_id\x18\x05 \x01(\x03R\bparentId\x12\x17\n" +
+	"\aroot_id\x18\x06 \x01(\x03R\x06rootId\x12 \n" +
+	"\freply_to_uid\x18\a \x01(\x03R\n" +
+	"replyToUid\x12\x18\n" +
+	"\acontent\x18\b \x01(\tR\acontent\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x18\n" +
+	"\adeleted\x18\n" +
+	" \x01(\bR\adeleted\x12\x1f\n" +
+	"\vreply_count\x18\v \x01(\x05R\n" +
+	"replyCount\x12(\n" +
+	"\areplies\x18\f \x03(\v2\x0e.forum.CommentR\areplies\"f\n" +
+	"\x14CreateCommentRequest\x12\x17\n" +
+	"\apost_id\x18\x01 \x01(\x03R\x06postId\x12\x1b\n" +
+	"\tparent_id\x18\x02 \x01(\x03R\bparentId\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\tR\acontent\"\xa4\x01\n" +
+	"\x13ListCommentsRequest\x12\x17\n" +
+	"\apost_id\x18\x01 \x01(\x03R\x06postId\x12\x17\n" +
+	"\aroot_id\x18\x02 \x01(\x03R\x06rootId\x12#\n" +
+	"\rreply_preview\x18\x03 \x01(\x05R\freplyPreview\x126\n" +
+	"\n" +
+	"pagination\x18\x04 \x01(\v2\x16.common.v1.PageRequestR\n" +
+	"pagination\"{\n" +
+	"\x14ListCommentsResponse\x12*\n" +
+	"\bcomments\x18\x01 \x03(\v2\x0e.forum.CommentR\bcomments\x127\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2\x17.common.v1.PageResponseR\n" +
+	"pagination\"&\n" +
+	"\x14DeleteCommentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\a\n" +
+	"\x05Empty*\x8c\x01\n" +
+	"\n" +
+	"PostStatus\x12\x1b\n" +
+	"\x17POST_STATUS_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11POST_STATUS_DRAFT\x10\x01\x12\x19\n" +
+	"\x15POST_STATUS_PUBLISHED\x10\x02\x12\x16\n" +
+	"\x12POST_STATUS_HIDDEN\x10\x03\x12\x17\n" +
+	"\x13POST_STATUS_DELETED\x10\x04*\xf3\x01\n" +
+	"\rPostSortOrder\x12\x1f\n" +
+	"\x1bPOST_SORT_ORDER_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16POST_SORT_ORDER_NEWEST\x10\x01\x12\x1a\n" +
+	"\x16POST_SORT_ORDER_OLDEST\x10\x02\x12$\n" +
+	" POST_SORT_ORDER_RECENTLY_UPDATED\x10\x03\x12\x1f\n" +
+	"\x1bPOST_SORT_ORDER_MOST_VIEWED\x10\x04\x12\x1e\n" +
+	"\x1aPOST_SORT_ORDER_MOST_LIKED\x10\x05\x12\"\n" +
+	"\x1ePOST_SORT_ORDER_MOST_COMMENTED\x10\x06*\x96\x02\n" +
+	"\x0eForumErrorCode\x12 \n" +
+	"\x1cFORUM_ERROR_CODE_UNSPECIFIED\x10\x00\x12$\n" +
+	" FORUM_ERROR_CODE_UNAUTHENTICATED\x10\x01\x12\x1e\n" +
+	"\x1aFORUM_ERROR_CODE_NOT_OWNER\x10\x02\x12#\n" +
+	"\x1fFORUM_ERROR_CODE_POST_NOT_FOUND\x10\x03\x12&\n" +
+	"\"FORUM_ERROR_CODE_COMMENT_NOT_FOUND\x10\x04\x12%\n" +
+	"!FORUM_ERROR_CODE_VERSION_CONFLICT\x10\x05\x12(\n" +
+	"$FORUM_ERROR_CODE_INVALID_UPDATE_MASK\x10\x062\xaa\x06\n" +
 	"\fForumService\x12]\n" +
 	"\x0fCreateForumPost\x12\x1d.forum.CreateForumPostRequest\x1a\x18.forum.ForumPostResponse\"\x11\x82\xd3\xe4\x93\x02\v:\x01*\"\x06/forum\x12d\n" +
 	"\x10GetForumPostByID\x12\x1e.forum.GetForumPostByIDRequest\x1a\x18.forum.ForumPostResponse\"\x16\x82\xd3\xe4\x93\x02\x10b\x01*\x12\v/forum/{id}\x12`\n" +
 	"\x0eListForumPosts\x12\x1c.forum.ListForumPostsRequest\x1a\x1d.forum.ListForumPostsResponse\"\x11\x82\xd3\xe4\x93\x02\vb\x01*\x12\x06/forum\x12]\n" +
-	"\x0fUpdateForumPost\x12\x1d.forum.UpdateForumPostRequest\x1a\x18.forum.ForumPostResponse\"\x11\x82\xd3\xe4\x93\x02\v:\x01*\x1a\x06/forum\x12S\n" +
-	"\x0fDeleteForumPost\x12\x1d.forum.DeleteForumPostRequest\x1a\f.forum.Empty\"\x13\x82\xd3\xe4\x93\x02\r*\v/forum/{id}B~\n" +
+	"\x0fUpdateForumPost\x12\x1d.forum.UpdateForumPostRequest\x1a\x18.forum.ForumPostResponse\"\x11\x82\xd3\xe4\x93\x02\v:\x01*\x1a\x06/forum\x12]\n" +
+	"\x0fDeleteForumPost\x12\x1d.forum.DeleteForumPostRequest\x1a\x16.google.protobuf.Empty\"\x13\x82\xd3\xe4\x93\x02\r*\v/forum/{id}\x12b\n" +
+	"\rCreateComment\x12\x1b.forum.CreateCommentRequest\x1a\x0e.forum.Comment\"$\x82\xd3\xe4\x93\x02\x1e:\x01*\"\x19/forum/{post_id}/comments\x12m\n" +
+	"\fListComments\x12\x1a.forum.ListCommentsRequest\x1a\x1b.forum.ListCommentsResponse\"$\x82\xd3\xe4\x93\x02\x1eb\x01*\x12\x19/forum/{post_id}/comments\x12b\n" +
+	"\rDeleteComment\x12\x1b.forum.DeleteCommentRequest\x1a\x16.google.protobuf.Empty\"\x1c\x82\xd3\xe4\x93\x02\x16*\x14/forum/comments/{id}Bz\n" +
 	"\tcom.forumB\n" +
-	"ForumProtoP\x01Z1github.com/betterde/focusly/internal/gen/forum_pb\xa2\x02\x03FXX\xaa\x02\x05Forum\xca\x02\x05Forum\xe2\x02\x11Forum\\GPBMetadata\xea\x02\x05Forumb\x06proto3"
+	"ForumProtoP\x01Z-github.com/trancecho/mundo-proto-sdk/forum_pb\xa2\x02\x03FXX\xaa\x02\x05Forum\xca\x02\x05Forum\xe2\x02\x11Forum\\GPBMetadata\xea\x02\x05Forumb\x06proto3"
 
 var (
 	file_forum_pb_forum_proto_rawDescOnce sync.Once
@@ -470,34 +1415,74 @@ func file_forum_pb_forum_proto_rawDescGZIP() []byte {
 	return file_forum_pb_forum_proto_rawDescData
 }
 
-var file_forum_pb_forum_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_forum_pb_forum_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_forum_pb_forum_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
 var file_forum_pb_forum_proto_goTypes = []any{
-	(*CreateForumPostRequest)(nil),  // 0: forum.CreateForumPostRequest
-	(*GetForumPostByIDRequest)(nil), // 1: forum.GetForumPostByIDRequest
-	(*ListForumPostsRequest)(nil),   // 2: forum.ListForumPostsRequest
-	(*ListForumPostsResponse)(nil),  // 3: forum.ListForumPostsResponse
-	(*UpdateForumPostRequest)(nil),  // 4: forum.UpdateForumPostRequest
-	(*DeleteForumPostRequest)(nil),  // 5: forum.DeleteForumPostRequest
-	(*ForumPostResponse)(nil),       // 6: forum.ForumPostResponse
-	(*Empty)(nil),                   // 7: forum.Empty
+	(PostStatus)(0),                 // 0: forum.PostStatus
+	(PostSortOrder)(0),              // 1: forum.PostSortOrder
+	(ForumErrorCode)(0),             // 2: forum.ForumErrorCode
+	(*CreateForumPostRequest)(nil),  // 3: forum.CreateForumPostRequest
+	(*GetForumPostByIDRequest)(nil), // 4: forum.GetForumPostByIDRequest
+	(*ListForumPostsRequest)(nil),   // 5: forum.ListForumPostsRequest
+	(*ListForumPostsResponse)(nil),  // 6: forum.ListForumPostsResponse
+	(*UpdateForumPostRequest)(nil),  // 7: forum.UpdateForumPostRequest
+	(*DeleteForumPostRequest)(nil),  // 8: forum.DeleteForumPostRequest
+	(*ForumPostResponse)(nil),       // 9: forum.ForumPostResponse
+	(*AuthorInfo)(nil),              // 10: forum.AuthorInfo
+	(*Comment)(nil),                 // 11: forum.Comment
+	(*CreateCommentRequest)(nil),    // 12: forum.CreateCommentRequest
+	(*ListCommentsRequest)(nil),     // 13: forum.ListCommentsRequest
+	(*ListCommentsResponse)(nil),    // 14: forum.ListCommentsResponse
+	(*DeleteCommentRequest)(nil),    // 15: forum.DeleteCommentRequest
+	(*Empty)(nil),                   // 16: forum.Empty
+	(*v1.PageRequest)(nil),          // 17: common.v1.PageRequest
+	(*v1.PageResponse)(nil),         // 18: common.v1.PageResponse
+	(*fieldmaskpb.FieldMask)(nil),   // 19: google.protobuf.FieldMask
+	(*v1.UserRef)(nil),              // 20: common.v1.UserRef
+	(*timestamppb.Timestamp)(nil),   // 21: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),           // 22: google.protobuf.Empty
 }
 var file_forum_pb_forum_proto_depIdxs = []int32{
-	6, // 0: forum.ListForumPostsResponse.posts:type_name -> forum.ForumPostResponse
-	0, // 1: forum.ForumService.CreateForumPost:input_type -> forum.CreateForumPostRequest
-	1, // 2: forum.ForumService.GetForumPostByID:input_type -> forum.GetForumPostByIDRequest
-	2, // 3: forum.ForumService.ListForumPosts:input_type -> forum.ListForumPostsRequest
-	4, // 4: forum.ForumService.UpdateForumPost:input_type -> forum.UpdateForumPostRequest
-	5, // 5: forum.ForumService.DeleteForumPost:input_type -> forum.DeleteForumPostRequest
-	6, // 6: forum.ForumService.CreateForumPost:output_type -> forum.ForumPostResponse
-	6, // 7: forum.ForumService.GetForumPostByID:output_type -> forum.ForumPostResponse
-	3, // 8: forum.ForumService.ListForumPosts:output_type -> forum.ListForumPostsResponse
-	6, // 9: forum.ForumService.UpdateForumPost:output_type -> forum.ForumPostResponse
-	7, // 10: forum.ForumService.DeleteForumPost:output_type -> forum.Empty
-	6, // [6:11] is the sub-list for method output_type
-	1, // [1:6] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	0,  // 0: forum.CreateForumPostRequest.status:type_name -> forum.PostStatus
+	17, // 1: forum.ListForumPostsRequest.pagination:type_name -> common.v1.PageRequest
+	0,  // 2: forum.ListForumPostsRequest.statuses:type_name -> forum.PostStatus
+	1,  // 3: forum.ListForumPostsRequest.sort:type_name -> forum.PostSortOrder
+	9,  // 4: forum.ListForumPostsResponse.posts:type_name -> forum.ForumPostResponse
+	18, // 5: forum.ListForumPostsResponse.pagination:type_name -> common.v1.PageResponse
+	0,  // 6: forum.UpdateForumPostRequest.status:type_name -> forum.PostStatus
+	19, // 7: forum.UpdateForumPostRequest.update_mask:type_name -> google.protobuf.FieldMask
+	20, // 8: forum.ForumPostResponse.author:type_name -> common.v1.UserRef
+	21, // 9: forum.ForumPostResponse.created_at:type_name -> google.protobuf.Timestamp
+	21, // 10: forum.ForumPostResponse.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 11: forum.ForumPostResponse.status:type_name -> forum.PostStatus
+	10, // 12: forum.ForumPostResponse.author_info:type_name -> forum.AuthorInfo
+	20, // 13: forum.Comment.author:type_name -> common.v1.UserRef
+	21, // 14: forum.Comment.created_at:type_name -> google.protobuf.Timestamp
+	11, // 15: forum.Comment.replies:type_name -> forum.Comment
+	17, // 16: forum.ListCommentsRequest.pagination:type_name -> common.v1.PageRequest
+	11, // 17: forum.ListCommentsResponse.comments:type_name -> forum.Comment
+	18, // 18: forum.ListCommentsResponse.pagination:type_name -> common.v1.PageResponse
+	3,  // 19: forum.ForumService.CreateForumPost:input_type -> forum.CreateForumPostRequest
+	4,  // 20: forum.ForumService.GetForumPostByID:input_type -> forum.GetForumPostByIDRequest
+	5,  // 21: forum.ForumService.ListForumPosts:input_type -> forum.ListForumPostsRequest
+	7,  // 22: forum.ForumService.UpdateForumPost:input_type -> forum.UpdateForumPostRequest
+	8,  // 23: forum.ForumService.DeleteForumPost:input_type -> forum.DeleteForumPostRequest
+	12, // 24: forum.ForumService.CreateComment:input_type -> forum.CreateCommentRequest
+	13, // 25: forum.ForumService.ListComments:input_type -> forum.ListCommentsRequest
+	15, // 26: forum.ForumService.DeleteComment:input_type -> forum.DeleteCommentRequest
+	9,  // 27: forum.ForumService.CreateForumPost:output_type -> forum.ForumPostResponse
+	9,  // 28: forum.ForumService.GetForumPostByID:output_type -> forum.ForumPostResponse
+	6,  // 29: forum.ForumService.ListForumPosts:output_type -> forum.ListForumPostsResponse
+	9,  // 30: forum.ForumService.UpdateForumPost:output_type -> forum.ForumPostResponse
+	22, // 31: forum.ForumService.DeleteForumPost:output_type -> google.protobuf.Empty
+	11, // 32: forum.ForumService.CreateComment:output_type -> forum.Comment
+	14, // 33: forum.ForumService.ListComments:output_type -> forum.ListCommentsResponse
+	22, // 34: forum.ForumService.DeleteComment:output_type -> google.protobuf.Empty
+	27, // [27:35] is the sub-list for method output_type
+	19, // [19:27] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
 }
 
 func init() { file_forum_pb_forum_proto_init() }
@@ -510,13 +1495,14 @@ func file_forum_pb_forum_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_forum_pb_forum_proto_rawDesc), len(file_forum_pb_forum_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   8,
+			NumEnums:      3,
+			NumMessages:   14,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_forum_pb_forum_proto_goTypes,
 		DependencyIndexes: file_forum_pb_forum_proto_depIdxs,
+		EnumInfos:         file_forum_pb_forum_proto_enumTypes,
 		MessageInfos:      file_forum_pb_forum_proto_msgTypes,
 	}.Build()
 	File_forum_pb_forum_proto = out.File
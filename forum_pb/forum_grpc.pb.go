@@ -11,6 +11,7 @@ import (
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
 // This is a compile-time assertion to ensure that this generated file
@@ -24,6 +25,9 @@ const (
 	ForumService_ListForumPosts_FullMethodName   = "/forum.ForumService/ListForumPosts"
 	ForumService_UpdateForumPost_FullMethodName  = "/forum.ForumService/UpdateForumPost"
 	ForumService_DeleteForumPost_FullMethodName  = "/forum.ForumService/DeleteForumPost"
+	ForumService_CreateComment_FullMethodName    = "/forum.ForumService/CreateComment"
+	ForumService_ListComments_FullMethodName     = "/forum.ForumService/ListComments"
+	ForumService_DeleteComment_FullMethodName    = "/forum.ForumService/DeleteComment"
 )
 
 // ForumServiceClient is the client API for ForumService service.
@@ -34,7 +38,13 @@ type ForumServiceClient interface {
 	GetForumPostByID(ctx context.Context, in *GetForumPostByIDRequest, opts ...grpc.CallOption) (*ForumPostResponse, error)
 	ListForumPosts(ctx context.Context, in *ListForumPostsRequest, opts ...grpc.CallOption) (*ListForumPostsResponse, error)
 	UpdateForumPost(ctx context.Context, in *UpdateForumPostRequest, opts ...grpc.CallOption) (*ForumPostResponse, error)
-	DeleteForumPost(ctx context.Context, in *DeleteForumPostRequest, opts ...grpc.CallOption) (*Empty, error)
+	DeleteForumPost(ctx context.Context, in *DeleteForumPostRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// 发表评论或回复，成功后向 rconst.StreamComment 发布 CommentEvent
+	CreateComment(ctx context.Context, in *CreateCommentRequest, opts ...grpc.CallOption) (*Comment, error)
+	// 分页查询楼层（root_id 为 0）或某楼层内的回复（root_id 非 0）
+	ListComments(ctx context.Context, in *ListCommentsRequest, opts ...grpc.CallOption) (*ListCommentsResponse, error)
+	// 删除评论，仍有回复的评论保留占位（deleted = true）
+	DeleteComment(ctx context.Context, in *DeleteCommentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
 type forumServiceClient struct {
@@ -85,9 +95,9 @@ func (c *forumServiceClient) UpdateForumPost(ctx context.Context, in *UpdateForu
 	return out, nil
 }
 
-func (c *forumServiceClient) DeleteForumPost(ctx context.Context, in *DeleteForumPostRequest, opts ...grpc.CallOption) (*Empty, error) {
+func (c *forumServiceClient) DeleteForumPost(ctx context.Context, in *DeleteForumPostRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(Empty)
+	out := new(emptypb.Empty)
 	err := c.cc.Invoke(ctx, ForumService_DeleteForumPost_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -95,6 +105,36 @@ func (c *forumServiceClient) DeleteForumPost(ctx context.Context, in *DeleteForu
 	return out, nil
 }
 
+func (c *forumServiceClient) CreateComment(ctx context.Context, in *CreateCommentRequest, opts ...grpc.CallOption) (*Comment, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Comment)
+	err := c.cc.Invoke(ctx, ForumService_CreateComment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *forumServiceClient) ListComments(ctx context.Context, in *ListCommentsRequest, opts ...grpc.CallOption) (*ListCommentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCommentsResponse)
+	err := c.cc.Invoke(ctx, ForumService_ListComments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *forumServiceClient) DeleteComment(ctx context.Context, in *DeleteCommentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ForumService_DeleteComment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ForumServiceServer is the server API for ForumService service.
 // All implementations must embed UnimplementedForumServiceServer
 // for forward compatibility.
@@ -103,7 +143,13 @@ type ForumServiceServer interface {
 	GetForumPostByID(context.Context, *GetForumPostByIDRequest) (*ForumPostResponse, error)
 	ListForumPosts(context.Context, *ListForumPostsRequest) (*ListForumPostsResponse, error)
 	UpdateForumPost(context.Context, *UpdateForumPostRequest) (*ForumPostResponse, error)
-	DeleteForumPost(context.Context, *DeleteForumPostRequest) (*Empty, error)
+	DeleteForumPost(context.Context, *DeleteForumPostRequest) (*emptypb.Empty, error)
+	// 发表评论或回复，成功后向 rconst.StreamComment 发布 CommentEvent
+	CreateComment(context.Context, *CreateCommentRequest) (*Comment, error)
+	// 分页查询楼层（root_id 为 0）或某楼层内的回复（root_id 非 0）
+	ListComments(context.Context, *ListCommentsRequest) (*ListCommentsResponse, error)
+	// 删除评论，仍有回复的评论保留占位（deleted = true）
+	DeleteComment(context.Context, *DeleteCommentRequest) (*emptypb.Empty, error)
 	mustEmbedUnimplementedForumServiceServer()
 }
 
@@ -126,9 +172,18 @@ func (UnimplementedForumServiceServer) ListForumPosts(context.Context, *ListForu
 func (UnimplementedForumServiceServer) UpdateForumPost(context.Context, *UpdateForumPostRequest) (*ForumPostResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method UpdateForumPost not implemented")
 }
-func (UnimplementedForumServiceServer) DeleteForumPost(context.Context, *DeleteForumPostRequest) (*Empty, error) {
+func (UnimplementedForumServiceServer) DeleteForumPost(context.Context, *DeleteForumPostRequest) (*emptypb.Empty, error) {
 	return nil, status.Error(codes.Unimplemented, "method DeleteForumPost not implemented")
 }
+func (UnimplementedForumServiceServer) CreateComment(context.Context, *CreateCommentRequest) (*Comment, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateComment not implemented")
+}
+func (UnimplementedForumServiceServer) ListComments(context.Context, *ListCommentsRequest) (*ListCommentsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListComments not implemented")
+}
+func (UnimplementedForumServiceServer) DeleteComment(context.Context, *DeleteCommentRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteComment not implemented")
+}
 func (UnimplementedForumServiceServer) mustEmbedUnimplementedForumServiceServer() {}
 func (UnimplementedForumServiceServer) testEmbeddedByValue()                      {}
 
@@ -240,6 +295,60 @@ func _ForumService_DeleteForumPost_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ForumService_CreateComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ForumServiceServer).CreateComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ForumService_CreateComment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ForumServiceServer).CreateComment(ctx, req.(*CreateCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ForumService_ListComments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCommentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ForumServiceServer).ListComments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ForumService_ListComments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ForumServiceServer).ListComments(ctx, req.(*ListCommentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ForumService_DeleteComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ForumServiceServer).DeleteComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ForumService_DeleteComment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ForumServiceServer).DeleteComment(ctx, req.(*DeleteCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ForumService_ServiceDesc is the grpc.ServiceDesc for ForumService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -267,6 +376,18 @@ var ForumService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteForumPost",
 			Handler:    _ForumService_DeleteForumPost_Handler,
 		},
+		{
+			MethodName: "CreateComment",
+			Handler:    _ForumService_CreateComment_Handler,
+		},
+		{
+			MethodName: "ListComments",
+			Handler:    _ForumService_ListComments_Handler,
+		},
+		{
+			MethodName: "DeleteComment",
+			Handler:    _ForumService_DeleteComment_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "forum_pb/forum.proto",
@@ -0,0 +1,88 @@
+package messageclient
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/trancecho/mundo-proto-sdk/common/userref"
+	commonv1 "github.com/trancecho/mundo-proto-sdk/common/v1"
+	messagev1 "github.com/trancecho/mundo-proto-sdk/message/v1"
+	"github.com/trancecho/mundo-proto-sdk/rconst"
+	"github.com/trancecho/mundo-proto-sdk/stat/tracker"
+	statv1 "github.com/trancecho/mundo-proto-sdk/stat/v1"
+	"google.golang.org/grpc"
+)
+
+// Client MessageService 客户端封装：发送成功后自动上报 chat_sent 统计事件
+type Client struct {
+	rpc  messagev1.MessageServiceClient
+	sink tracker.Sink
+}
+
+// New sink 为空时不上报统计事件，通常传入 *tracker.Tracker
+func New(rpc messagev1.MessageServiceClient, sink tracker.Sink) *Client {
+	return &Client{rpc: rpc, sink: sink}
+}
+
+// RPC 返回底层的 MessageServiceClient
+func (c *Client) RPC() messagev1.MessageServiceClient {
+	return c.rpc
+}
+
+// SendMessage 发送消息，成功后异步上报 chat_sent 事件，上报失败不影响发送结果
+func (c *Client) SendMessage(ctx context.Context, req *messagev1.SendMessageRequest, opts ...grpc.CallOption) (*messagev1.SendMessageResponse, error) {
+	resp, err := c.rpc.SendMessage(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if c.sink != nil {
+		c.sink.Track(chatSentEvent(req, resp))
+	}
+	return resp, nil
+}
+
+// MarkRead 将指定消息标记为已读
+func (c *Client) MarkRead(ctx context.Context, req *messagev1.MarkReadRequest, opts ...grpc.CallOption) (*messagev1.MarkReadResponse, error) {
+	return c.rpc.MarkRead(ctx, req, opts...)
+}
+
+// MarkAllRead 将收到的消息全部标记为已读
+func (c *Client) MarkAllRead(ctx context.Context, req *messagev1.MarkAllReadRequest, opts ...grpc.CallOption) (*messagev1.MarkReadResponse, error) {
+	return c.rpc.MarkAllRead(ctx, req, opts...)
+}
+
+// RecallMessage 撤回消息
+func (c *Client) RecallMessage(ctx context.Context, req *messagev1.RecallMessageRequest, opts ...grpc.CallOption) (*messagev1.RecallMessageResponse, error) {
+	return c.rpc.RecallMessage(ctx, req, opts...)
+}
+
+// UnreadCount 返回 user 的未读消息总数，peer 为空时统计全部会话
+func (c *Client) UnreadCount(ctx context.Context, user, peer *commonv1.UserRef, opts ...grpc.CallOption) (int64, error) {
+	resp, err := c.rpc.GetUnreadCount(ctx, &messagev1.GetUnreadCountRequest{User: user, Peer: peer}, opts...)
+	if err != nil {
+		return 0, err
+	}
+	return resp.GetTotal(), nil
+}
+
+func chatSentEvent(req *messagev1.SendMessageRequest, resp *messagev1.SendMessageResponse) *statv1.TrackEventRequest {
+	sender := req.GetSenderRef()
+	if userref.IsZero(sender) {
+		// 旧字段 sender 无法解析时仍上报事件，只是不带用户
+		sender, _ = userref.FromString(req.GetSender())
+	}
+	uid, _ := userref.Uint32(sender)
+	receiver, _ := userref.StringOf(req.GetReceiverRef(), req.GetReceiver())
+	props, _ := json.Marshal(map[string]any{
+		"message_id": resp.GetMessageId(),
+		"receiver":   receiver,
+	})
+	return &statv1.TrackEventRequest{
+		EventType:  rconst.EventChatSend,
+		Timestamp:  time.Now().UnixMilli(),
+		UserId:     uid,
+		User:       sender,
+		Properties: string(props),
+	}
+}
@@ -0,0 +1,79 @@
+package messageclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	commonv1 "github.com/trancecho/mundo-proto-sdk/common/v1"
+	messagev1 "github.com/trancecho/mundo-proto-sdk/message/v1"
+	"github.com/trancecho/mundo-proto-sdk/rconst"
+	statv1 "github.com/trancecho/mundo-proto-sdk/stat/v1"
+	"google.golang.org/grpc"
+)
+
+type fakeRPC struct {
+	messagev1.MessageServiceClient
+	err error
+}
+
+func (f *fakeRPC) SendMessage(context.Context, *messagev1.SendMessageRequest, ...grpc.CallOption) (*messagev1.SendMessageResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &messagev1.SendMessageResponse{MessageId: "m1"}, nil
+}
+
+type recorder []*statv1.TrackEventRequest
+
+func (r *recorder) Track(ev *statv1.TrackEventRequest) bool {
+	*r = append(*r, ev)
+	return true
+}
+
+func TestSendMessageTracksChatSent(t *testing.T) {
+	tests := []struct {
+		name         string
+		req          *messagev1.SendMessageRequest
+		rpcErr       error
+		wantTracked  bool
+		wantUid      uint32
+		wantReceiver string
+	}{
+		{"legacy fields", &messagev1.SendMessageRequest{Sender: "7", Receiver: "8"}, nil, true, 7, "8"},
+		{"refs win", &messagev1.SendMessageRequest{Sender: "1", Receiver: "2",
+			SenderRef: &commonv1.UserRef{Uid: 7}, ReceiverRef: &commonv1.UserRef{Uid: 8}}, nil, true, 7, "8"},
+		{"unparsable sender still tracked", &messagev1.SendMessageRequest{Sender: "alice", Receiver: "8"}, nil, true, 0, "8"},
+		{"sender out of uint32 range", &messagev1.SendMessageRequest{SenderRef: &commonv1.UserRef{Uid: 1 << 40}, Receiver: "8"}, nil, true, 0, "8"},
+		{"send failed", &messagev1.SendMessageRequest{Sender: "7", Receiver: "8"}, errors.New("down"), false, 0, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sink recorder
+			_, err := New(&fakeRPC{err: tt.rpcErr}, &sink).SendMessage(context.Background(), tt.req)
+			if !errors.Is(err, tt.rpcErr) {
+				t.Fatalf("err = %v, want %v", err, tt.rpcErr)
+			}
+			if (len(sink) == 1) != tt.wantTracked {
+				t.Fatalf("tracked %d events, want tracked %v", len(sink), tt.wantTracked)
+			}
+			if !tt.wantTracked {
+				return
+			}
+			ev := sink[0]
+			var props map[string]string
+			if err := json.Unmarshal([]byte(ev.GetProperties()), &props); err != nil {
+				t.Fatal(err)
+			}
+			if ev.GetEventType() != rconst.EventChatSend || ev.GetUserId() != tt.wantUid ||
+				props["receiver"] != tt.wantReceiver || props["message_id"] != "m1" {
+				t.Fatalf("event = %v", ev)
+			}
+		})
+	}
+	// sink 为空时不上报
+	if _, err := New(&fakeRPC{}, nil).SendMessage(context.Background(), &messagev1.SendMessageRequest{}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,74 @@
+// Package msgstatus 消息状态流转：SENT -> DELIVERED -> READ 单向推进，未读的消息可撤回。
+package msgstatus
+
+import (
+	"errors"
+	"time"
+
+	messagev1 "github.com/trancecho/mundo-proto-sdk/message/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	ErrInvalidTransition = errors.New("msgstatus: invalid status transition")
+	ErrRecallRead        = errors.New("msgstatus: message has been read")
+)
+
+// Of 读取消息状态：优先 state，其次旧字段 status；均未设置时视为 SENT
+func Of(m *messagev1.Message) messagev1.MessageStatus {
+	if s := m.GetState(); s != messagev1.MessageStatus_MESSAGE_STATUS_UNSPECIFIED {
+		return s
+	}
+	if s := messagev1.MessageStatus(m.GetStatus()); s > 0 && s <= messagev1.MessageStatus_MESSAGE_STATUS_RECALLED {
+		return s
+	}
+	return messagev1.MessageStatus_MESSAGE_STATUS_SENT
+}
+
+// IsUnread 接收方尚未读取且未撤回
+func IsUnread(s messagev1.MessageStatus) bool {
+	return s == messagev1.MessageStatus_MESSAGE_STATUS_SENT || s == messagev1.MessageStatus_MESSAGE_STATUS_DELIVERED
+}
+
+// CheckTransition 校验状态变更。相同状态视为幂等返回 nil；
+// 向后回退（如 READ -> DELIVERED）及已撤回消息的任何变更返回 ErrInvalidTransition，撤回已读消息返回 ErrRecallRead
+func CheckTransition(from, to messagev1.MessageStatus) error {
+	if from == messagev1.MessageStatus_MESSAGE_STATUS_UNSPECIFIED {
+		from = messagev1.MessageStatus_MESSAGE_STATUS_SENT
+	}
+	switch {
+	case from == to:
+		return nil
+	case from == messagev1.MessageStatus_MESSAGE_STATUS_RECALLED:
+		return ErrInvalidTransition
+	case to == messagev1.MessageStatus_MESSAGE_STATUS_RECALLED:
+		if !IsUnread(from) {
+			return ErrRecallRead
+		}
+		return nil
+	case to > from && to <= messagev1.MessageStatus_MESSAGE_STATUS_READ:
+		return nil
+	}
+	return ErrInvalidTransition
+}
+
+// Apply 校验并变更消息状态，同时写入旧字段 status 及 read_at/recalled_at；撤回时清空内容
+func Apply(m *messagev1.Message, to messagev1.MessageStatus, at time.Time) error {
+	from := Of(m)
+	if err := CheckTransition(from, to); err != nil {
+		return err
+	}
+	m.State = to
+	m.Status = int32(to)
+	if from == to {
+		return nil
+	}
+	switch to {
+	case messagev1.MessageStatus_MESSAGE_STATUS_READ:
+		m.ReadAt = timestamppb.New(at)
+	case messagev1.MessageStatus_MESSAGE_STATUS_RECALLED:
+		m.RecalledAt = timestamppb.New(at)
+		m.Content = ""
+	}
+	return nil
+}
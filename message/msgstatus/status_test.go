@@ -0,0 +1,110 @@
+package msgstatus
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	messagev1 "github.com/trancecho/mundo-proto-sdk/message/v1"
+)
+
+const (
+	unspecified = messagev1.MessageStatus_MESSAGE_STATUS_UNSPECIFIED
+	sent        = messagev1.MessageStatus_MESSAGE_STATUS_SENT
+	delivered   = messagev1.MessageStatus_MESSAGE_STATUS_DELIVERED
+	read        = messagev1.MessageStatus_MESSAGE_STATUS_READ
+	recalled    = messagev1.MessageStatus_MESSAGE_STATUS_RECALLED
+)
+
+func TestOf(t *testing.T) {
+	tests := []struct {
+		name string
+		m    *messagev1.Message
+		want messagev1.MessageStatus
+	}{
+		{"nil", nil, sent},
+		{"unset", &messagev1.Message{}, sent},
+		{"legacy status", &messagev1.Message{Status: int32(read)}, read},
+		{"legacy out of range", &messagev1.Message{Status: 9}, sent},
+		{"state wins", &messagev1.Message{State: delivered, Status: int32(read)}, delivered},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Of(tt.m); got != tt.want {
+				t.Fatalf("Of = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckTransition(t *testing.T) {
+	tests := []struct {
+		from, to messagev1.MessageStatus
+		want     error
+	}{
+		{unspecified, delivered, nil},
+		{sent, sent, nil},
+		{sent, delivered, nil},
+		{sent, read, nil},
+		{delivered, read, nil},
+		{read, read, nil},
+		{read, delivered, ErrInvalidTransition},
+		{delivered, sent, ErrInvalidTransition},
+		{sent, recalled, nil},
+		{delivered, recalled, nil},
+		{read, recalled, ErrRecallRead},
+		{recalled, recalled, nil},
+		{recalled, read, ErrInvalidTransition},
+		{sent, unspecified, ErrInvalidTransition},
+	}
+	for _, tt := range tests {
+		t.Run(tt.from.String()+"->"+tt.to.String(), func(t *testing.T) {
+			if err := CheckTransition(tt.from, tt.to); !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	at := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name         string
+		m            *messagev1.Message
+		to           messagev1.MessageStatus
+		wantErr      error
+		wantContent  string
+		wantRead     bool
+		wantRecalled bool
+	}{
+		{"read", &messagev1.Message{Content: "hi"}, read, nil, "hi", true, false},
+		{"read again keeps read_at", &messagev1.Message{Content: "hi", State: read}, read, nil, "hi", false, false},
+		{"recall unread", &messagev1.Message{Content: "hi", State: delivered}, recalled, nil, "", false, true},
+		{"recall read", &messagev1.Message{Content: "hi", Status: int32(read)}, recalled, ErrRecallRead, "hi", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := Of(tt.m)
+			err := Apply(tt.m, tt.to, at)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			wantState := tt.to
+			if err != nil {
+				wantState = before
+			}
+			if Of(tt.m) != wantState || (err == nil && tt.m.GetStatus() != int32(wantState)) {
+				t.Fatalf("state = %v, status = %d; want %v", tt.m.GetState(), tt.m.GetStatus(), wantState)
+			}
+			if tt.m.GetContent() != tt.wantContent {
+				t.Fatalf("content = %q, want %q", tt.m.GetContent(), tt.wantContent)
+			}
+			if (tt.m.ReadAt != nil) != tt.wantRead || (tt.m.RecalledAt != nil) != tt.wantRecalled {
+				t.Fatalf("read_at = %v, recalled_at = %v", tt.m.ReadAt, tt.m.RecalledAt)
+			}
+			if tt.wantRead && !tt.m.GetReadAt().AsTime().Equal(at) {
+				t.Fatalf("read_at = %v, want %v", tt.m.GetReadAt().AsTime(), at)
+			}
+		})
+	}
+}
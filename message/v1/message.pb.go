@@ -9,8 +9,10 @@
 package messagev1
 
 import (
+	v1 "github.com/trancecho/mundo-proto-sdk/common/v1"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -23,11 +25,69 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// 消息状态，只能按 SENT -> DELIVERED -> READ 单向推进；未读的消息（SENT/DELIVERED）可撤回
+type MessageStatus int32
+
+const (
+	MessageStatus_MESSAGE_STATUS_UNSPECIFIED MessageStatus = 0
+	MessageStatus_MESSAGE_STATUS_SENT        MessageStatus = 1 // 已发送，服务端已保存
+	MessageStatus_MESSAGE_STATUS_DELIVERED   MessageStatus = 2 // 已送达接收方客户端
+	MessageStatus_MESSAGE_STATUS_READ        MessageStatus = 3 // 接收方已读
+	MessageStatus_MESSAGE_STATUS_RECALLED    MessageStatus = 4 // 发送方已撤回，内容不再返回
+)
+
+// Enum value maps for MessageStatus.
+var (
+	MessageStatus_name = map[int32]string{
+		0: "MESSAGE_STATUS_UNSPECIFIED",
+		1: "MESSAGE_STATUS_SENT",
+		2: "MESSAGE_STATUS_DELIVERED",
+		3: "MESSAGE_STATUS_READ",
+		4: "MESSAGE_STATUS_RECALLED",
+	}
+	MessageStatus_value = map[string]int32{
+		"MESSAGE_STATUS_UNSPECIFIED": 0,
+		"MESSAGE_STATUS_SENT":        1,
+		"MESSAGE_STATUS_DELIVERED":   2,
+		"MESSAGE_STATUS_READ":        3,
+		"MESSAGE_STATUS_RECALLED":    4,
+	}
+)
+
+func (x MessageStatus) Enum() *MessageStatus {
+	p := new(MessageStatus)
+	*p = x
+	return p
+}
+
+func (x MessageStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MessageStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_message_v1_message_proto_enumTypes[0].Descriptor()
+}
+
+func (MessageStatus) Type() protoreflect.EnumType {
+	return &file_message_v1_message_proto_enumTypes[0]
+}
+
+func (x MessageStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MessageStatus.Descriptor instead.
+func (MessageStatus) EnumDescriptor() ([]byte, []int) {
+	return file_message_v1_message_proto_rawDescGZIP(), []int{0}
+}
+
 type GetMessagesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
-	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`                         // 已废弃，请使用 pagination
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"` // 已废弃，请使用 pagination
+	User          *v1.UserRef            `protobuf:"bytes,4,opt,name=user,proto3" json:"user,omitempty"`                          // 统一用户标识，设置时优先于 user_id
+	Pagination    *v1.PageRequest        `protobuf:"bytes,5,opt,name=pagination,proto3" json:"pagination,omitempty"`              // 设置时忽略 page/page_size
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -83,10 +143,25 @@ func (x *GetMessagesRequest) GetPageSize() int32 {
 	return 0
 }
 
+func (x *GetMessagesRequest) GetUser() *v1.UserRef {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *GetMessagesRequest) GetPagination() *v1.PageRequest {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
 type GetMessagesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Messages      []*Message             `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
 	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Pagination    *v1.PageResponse       `protobuf:"bytes,3,opt,name=pagination,proto3" json:"pagination,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -135,14 +210,27 @@ func (x *GetMessagesResponse) GetTotal() int32 {
 	return 0
 }
 
+func (x *GetMessagesResponse) GetPagination() *v1.PageResponse {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
 type Message struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Sender        string                 `protobuf:"bytes,2,opt,name=sender,proto3" json:"sender,omitempty"`
 	Receiver      string                 `protobuf:"bytes,3,opt,name=receiver,proto3" json:"receiver,omitempty"`
 	Content       string                 `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
-	Status        int32                  `protobuf:"varint,5,opt,name=status,proto3" json:"status,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Status        int32                  `protobuf:"varint,5,opt,name=status,proto3" json:"status,omitempty"`                             // 已废弃，请使用 state，数值与 MessageStatus 一致
+	CreatedAt     string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`       // 已废弃，请使用 created_at_time
+	SenderRef     *v1.UserRef            `protobuf:"bytes,7,opt,name=sender_ref,json=senderRef,proto3" json:"sender_ref,omitempty"`       // 发送者统一标识
+	ReceiverRef   *v1.UserRef            `protobuf:"bytes,8,opt,name=receiver_ref,json=receiverRef,proto3" json:"receiver_ref,omitempty"` // 接收者统一标识
+	CreatedAtTime *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at_time,json=createdAtTime,proto3" json:"created_at_time,omitempty"`
+	State         MessageStatus          `protobuf:"varint,10,opt,name=state,proto3,enum=proto.message.v1.MessageStatus" json:"state,omitempty"`
+	ReadAt        *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=read_at,json=readAt,proto3" json:"read_at,omitempty"`             // 已读时间
+	RecalledAt    *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=recalled_at,json=recalledAt,proto3" json:"recalled_at,omitempty"` // 撤回时间
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -219,11 +307,55 @@ func (x *Message) GetCreatedAt() string {
 	return ""
 }
 
+func (x *Message) GetSenderRef() *v1.UserRef {
+	if x != nil {
+		return x.SenderRef
+	}
+	return nil
+}
+
+func (x *Message) GetReceiverRef() *v1.UserRef {
+	if x != nil {
+		return x.ReceiverRef
+	}
+	return nil
+}
+
+func (x *Message) GetCreatedAtTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAtTime
+	}
+	return nil
+}
+
+func (x *Message) GetState() MessageStatus {
+	if x != nil {
+		return x.State
+	}
+	return MessageStatus_MESSAGE_STATUS_UNSPECIFIED
+}
+
+func (x *Message) GetReadAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReadAt
+	}
+	return nil
+}
+
+func (x *Message) GetRecalledAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RecalledAt
+	}
+	return nil
+}
+
 type SendMessageRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Sender        string                 `protobuf:"bytes,1,opt,name=sender,proto3" json:"sender,omitempty"`
 	Receiver      string                 `protobuf:"bytes,2,opt,name=receiver,proto3" json:"receiver,omitempty"`
 	Content       string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	SenderRef     *v1.UserRef            `protobuf:"bytes,4,opt,name=sender_ref,json=senderRef,proto3" json:"sender_ref,omitempty"`       // 设置时优先于 sender
+	ReceiverRef   *v1.UserRef            `protobuf:"bytes,5,opt,name=receiver_ref,json=receiverRef,proto3" json:"receiver_ref,omitempty"` // 设置时优先于 receiver
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -279,6 +411,20 @@ func (x *SendMessageRequest) GetContent() string {
 	return ""
 }
 
+func (x *SendMessageRequest) GetSenderRef() *v1.UserRef {
+	if x != nil {
+		return x.SenderRef
+	}
+	return nil
+}
+
+func (x *SendMessageRequest) GetReceiverRef() *v1.UserRef {
+	if x != nil {
+		return x.ReceiverRef
+	}
+	return nil
+}
+
 type SendMessageResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	MessageId     string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
@@ -323,18 +469,433 @@ func (x *SendMessageResponse) GetMessageId() string {
 	return ""
 }
 
+type MarkReadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *v1.UserRef            `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"` // 接收者
+	MessageIds    []string               `protobuf:"bytes,2,rep,name=message_ids,json=messageIds,proto3" json:"message_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkReadRequest) Reset() {
+	*x = MarkReadRequest{}
+	mi := &file_message_v1_message_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkReadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkReadRequest) ProtoMessage() {}
+
+func (x *MarkReadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkReadRequest.ProtoReflect.Descriptor instead.
+func (*MarkReadRequest) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *MarkReadRequest) GetUser() *v1.UserRef {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *MarkReadRequest) GetMessageIds() []string {
+	if x != nil {
+		return x.MessageIds
+	}
+	return nil
+}
+
+type MarkAllReadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *v1.UserRef            `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`     // 接收者
+	Peer          *v1.UserRef            `protobuf:"bytes,2,opt,name=peer,proto3" json:"peer,omitempty"`     // 只标记来自该用户的消息，为空时标记全部
+	Before        *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=before,proto3" json:"before,omitempty"` // 只标记此时间之前的消息，避免误标并发到达的新消息；为空时不限
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkAllReadRequest) Reset() {
+	*x = MarkAllReadRequest{}
+	mi := &file_message_v1_message_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkAllReadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkAllReadRequest) ProtoMessage() {}
+
+func (x *MarkAllReadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkAllReadRequest.ProtoReflect.Descriptor instead.
+func (*MarkAllReadRequest) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *MarkAllReadRequest) GetUser() *v1.UserRef {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *MarkAllReadRequest) GetPeer() *v1.UserRef {
+	if x != nil {
+		return x.Peer
+	}
+	return nil
+}
+
+func (x *MarkAllReadRequest) GetBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Before
+	}
+	return nil
+}
+
+type MarkReadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Updated       int32                  `protobuf:"varint,1,opt,name=updated,proto3" json:"updated,omitempty"` // 实际由未读变为已读的消息数
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkReadResponse) Reset() {
+	*x = MarkReadResponse{}
+	mi := &file_message_v1_message_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkReadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkReadResponse) ProtoMessage() {}
+
+func (x *MarkReadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkReadResponse.ProtoReflect.Descriptor instead.
+func (*MarkReadResponse) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *MarkReadResponse) GetUpdated() int32 {
+	if x != nil {
+		return x.Updated
+	}
+	return 0
+}
+
+type RecallMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *v1.UserRef            `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"` // 发送者
+	MessageId     string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecallMessageRequest) Reset() {
+	*x = RecallMessageRequest{}
+	mi := &file_message_v1_message_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecallMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecallMessageRequest) ProtoMessage() {}
+
+func (x *RecallMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecallMessageRequest.ProtoReflect.Descriptor instead.
+func (*RecallMessageRequest) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RecallMessageRequest) GetUser() *v1.UserRef {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *RecallMessageRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+type RecallMessageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       *Message               `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"` // 撤回后的消息
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecallMessageResponse) Reset() {
+	*x = RecallMessageResponse{}
+	mi := &file_message_v1_message_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecallMessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecallMessageResponse) ProtoMessage() {}
+
+func (x *RecallMessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecallMessageResponse.ProtoReflect.Descriptor instead.
+func (*RecallMessageResponse) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RecallMessageResponse) GetMessage() *Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+type GetUnreadCountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *v1.UserRef            `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"` // 接收者
+	Peer          *v1.UserRef            `protobuf:"bytes,2,opt,name=peer,proto3" json:"peer,omitempty"` // 只统计来自该用户的消息，为空时统计全部
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUnreadCountRequest) Reset() {
+	*x = GetUnreadCountRequest{}
+	mi := &file_message_v1_message_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUnreadCountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUnreadCountRequest) ProtoMessage() {}
+
+func (x *GetUnreadCountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUnreadCountRequest.ProtoReflect.Descriptor instead.
+func (*GetUnreadCountRequest) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetUnreadCountRequest) GetUser() *v1.UserRef {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *GetUnreadCountRequest) GetPeer() *v1.UserRef {
+	if x != nil {
+		return x.Peer
+	}
+	return nil
+}
+
+type UnreadCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Peer          *v1.UserRef            `protobuf:"bytes,1,opt,name=peer,proto3" json:"peer,omitempty"`
+	Count         int64                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnreadCount) Reset() {
+	*x = UnreadCount{}
+	mi := &file_message_v1_message_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnreadCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnreadCount) ProtoMessage() {}
+
+func (x *UnreadCount) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnreadCount.ProtoReflect.Descriptor instead.
+func (*UnreadCount) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *UnreadCount) GetPeer() *v1.UserRef {
+	if x != nil {
+		return x.Peer
+	}
+	return nil
+}
+
+func (x *UnreadCount) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type GetUnreadCountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Total         int64                  `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	ByPeer        []*UnreadCount         `protobuf:"bytes,2,rep,name=by_peer,json=byPeer,proto3" json:"by_peer,omitempty"` // 按发送者分组，请求指定 peer 时为空
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUnreadCountResponse) Reset() {
+	*x = GetUnreadCountResponse{}
+	mi := &file_message_v1_message_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUnreadCountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUnreadCountResponse) ProtoMessage() {}
+
+func (x *GetUnreadCountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUnreadCountResponse.ProtoReflect.Descriptor instead.
+func (*GetUnreadCountResponse) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetUnreadCountResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *GetUnreadCountResponse) GetByPeer() []*UnreadCount {
+	if x != nil {
+		return x.ByPeer
+	}
+	return nil
+}
+
 var File_message_v1_message_proto protoreflect.FileDescriptor
 
 const file_message_v1_message_proto_rawDesc = "" +
 	"\n" +
-	"\x18message/v1/message.proto\x12\x10proto.message.v1\"^\n" +
+	"\x18message/v1/message.proto\x12\x10proto.message.v1\x1a\x16common/v1/common.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xbe\x01\n" +
 	"\x12GetMessagesRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
 	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12&\n" +
+	"\x04user\x18\x04 \x01(\v2\x12.common.v1.UserRefR\x04user\x126\n" +
+	"\n" +
+	"pagination\x18\x05 \x01(\v2\x16.common.v1.PageRequestR\n" +
+	"pagination\"\x9b\x01\n" +
 	"\x13GetMessagesResponse\x125\n" +
 	"\bmessages\x18\x01 \x03(\v2\x19.proto.message.v1.MessageR\bmessages\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x05R\x05total\"\x9e\x01\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x127\n" +
+	"\n" +
+	"pagination\x18\x03 \x01(\v2\x17.common.v1.PageResponseR\n" +
+	"pagination\"\xf5\x03\n" +
 	"\aMessage\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
 	"\x06sender\x18\x02 \x01(\tR\x06sender\x12\x1a\n" +
@@ -342,18 +903,65 @@ const file_message_v1_message_proto_rawDesc = "" +
 	"\acontent\x18\x04 \x01(\tR\acontent\x12\x16\n" +
 	"\x06status\x18\x05 \x01(\x05R\x06status\x12\x1d\n" +
 	"\n" +
-	"created_at\x18\x06 \x01(\tR\tcreatedAt\"b\n" +
+	"created_at\x18\x06 \x01(\tR\tcreatedAt\x121\n" +
+	"\n" +
+	"sender_ref\x18\a \x01(\v2\x12.common.v1.UserRefR\tsenderRef\x125\n" +
+	"\freceiver_ref\x18\b \x01(\v2\x12.common.v1.UserRefR\vreceiverRef\x12B\n" +
+	"\x0fcreated_at_time\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedAtTime\x125\n" +
+	"\x05state\x18\n" +
+	" \x01(\x0e2\x1f.proto.message.v1.MessageStatusR\x05state\x123\n" +
+	"\aread_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\x06readAt\x12;\n" +
+	"\vrecalled_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"recalledAt\"\xcc\x01\n" +
 	"\x12SendMessageRequest\x12\x16\n" +
 	"\x06sender\x18\x01 \x01(\tR\x06sender\x12\x1a\n" +
 	"\breceiver\x18\x02 \x01(\tR\breceiver\x12\x18\n" +
-	"\acontent\x18\x03 \x01(\tR\acontent\"4\n" +
+	"\acontent\x18\x03 \x01(\tR\acontent\x121\n" +
+	"\n" +
+	"sender_ref\x18\x04 \x01(\v2\x12.common.v1.UserRefR\tsenderRef\x125\n" +
+	"\freceiver_ref\x18\x05 \x01(\v2\x12.common.v1.UserRefR\vreceiverRef\"4\n" +
 	"\x13SendMessageResponse\x12\x1d\n" +
 	"\n" +
-	"message_id\x18\x01 \x01(\tR\tmessageId2\xc8\x01\n" +
+	"message_id\x18\x01 \x01(\tR\tmessageId\"Z\n" +
+	"\x0fMarkReadRequest\x12&\n" +
+	"\x04user\x18\x01 \x01(\v2\x12.common.v1.UserRefR\x04user\x12\x1f\n" +
+	"\vmessage_ids\x18\x02 \x03(\tR\n" +
+	"messageIds\"\x98\x01\n" +
+	"\x12MarkAllReadRequest\x12&\n" +
+	"\x04user\x18\x01 \x01(\v2\x12.common.v1.UserRefR\x04user\x12&\n" +
+	"\x04peer\x18\x02 \x01(\v2\x12.common.v1.UserRefR\x04peer\x122\n" +
+	"\x06before\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x06before\",\n" +
+	"\x10MarkReadResponse\x12\x18\n" +
+	"\aupdated\x18\x01 \x01(\x05R\aupdated\"]\n" +
+	"\x14RecallMessageRequest\x12&\n" +
+	"\x04user\x18\x01 \x01(\v2\x12.common.v1.UserRefR\x04user\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\"L\n" +
+	"\x15RecallMessageResponse\x123\n" +
+	"\amessage\x18\x01 \x01(\v2\x19.proto.message.v1.MessageR\amessage\"g\n" +
+	"\x15GetUnreadCountRequest\x12&\n" +
+	"\x04user\x18\x01 \x01(\v2\x12.common.v1.UserRefR\x04user\x12&\n" +
+	"\x04peer\x18\x02 \x01(\v2\x12.common.v1.UserRefR\x04peer\"K\n" +
+	"\vUnreadCount\x12&\n" +
+	"\x04peer\x18\x01 \x01(\v2\x12.common.v1.UserRefR\x04peer\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\"f\n" +
+	"\x16GetUnreadCountResponse\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x03R\x05total\x126\n" +
+	"\aby_peer\x18\x02 \x03(\v2\x1d.proto.message.v1.UnreadCountR\x06byPeer*\x9c\x01\n" +
+	"\rMessageStatus\x12\x1e\n" +
+	"\x1aMESSAGE_STATUS_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13MESSAGE_STATUS_SENT\x10\x01\x12\x1c\n" +
+	"\x18MESSAGE_STATUS_DELIVERED\x10\x02\x12\x17\n" +
+	"\x13MESSAGE_STATUS_READ\x10\x03\x12\x1b\n" +
+	"\x17MESSAGE_STATUS_RECALLED\x10\x042\xbb\x04\n" +
 	"\x0eMessageService\x12Z\n" +
 	"\vSendMessage\x12$.proto.message.v1.SendMessageRequest\x1a%.proto.message.v1.SendMessageResponse\x12Z\n" +
-	"\vGetMessages\x12$.proto.message.v1.GetMessagesRequest\x1a%.proto.message.v1.GetMessagesResponseB\xc5\x01\n" +
-	"\x14com.proto.message.v1B\fMessageProtoP\x01Z=github.com/betterde/focusly/internal/gen/message/v1;messagev1\xa2\x02\x03PMX\xaa\x02\x10Proto.Message.V1\xca\x02\x10Proto\\Message\\V1\xe2\x02\x1cProto\\Message\\V1\\GPBMetadata\xea\x02\x12Proto::Message::V1b\x06proto3"
+	"\vGetMessages\x12$.proto.message.v1.GetMessagesRequest\x1a%.proto.message.v1.GetMessagesResponse\x12Q\n" +
+	"\bMarkRead\x12!.proto.message.v1.MarkReadRequest\x1a\".proto.message.v1.MarkReadResponse\x12W\n" +
+	"\vMarkAllRead\x12$.proto.message.v1.MarkAllReadRequest\x1a\".proto.message.v1.MarkReadResponse\x12`\n" +
+	"\rRecallMessage\x12&.proto.message.v1.RecallMessageRequest\x1a'.proto.message.v1.RecallMessageResponse\x12c\n" +
+	"\x0eGetUnreadCount\x12'.proto.message.v1.GetUnreadCountRequest\x1a(.proto.message.v1.GetUnreadCountResponseB\xc1\x01\n" +
+	"\x14com.proto.message.v1B\fMessageProtoP\x01Z9github.com/trancecho/mundo-proto-sdk/message/v1;messagev1\xa2\x02\x03PMX\xaa\x02\x10Proto.Message.V1\xca\x02\x10Proto\\Message\\V1\xe2\x02\x1cProto\\Message\\V1\\GPBMetadata\xea\x02\x12Proto::Message::V1b\x06proto3"
 
 var (
 	file_message_v1_message_proto_rawDescOnce sync.Once
@@ -367,25 +975,68 @@ func file_message_v1_message_proto_rawDescGZIP() []byte {
 	return file_message_v1_message_proto_rawDescData
 }
 
-var file_message_v1_message_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_message_v1_message_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_message_v1_message_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
 var file_message_v1_message_proto_goTypes = []any{
-	(*GetMessagesRequest)(nil),  // 0: proto.message.v1.GetMessagesRequest
-	(*GetMessagesResponse)(nil), // 1: proto.message.v1.GetMessagesResponse
-	(*Message)(nil),             // 2: proto.message.v1.Message
-	(*SendMessageRequest)(nil),  // 3: proto.message.v1.SendMessageRequest
-	(*SendMessageResponse)(nil), // 4: proto.message.v1.SendMessageResponse
+	(MessageStatus)(0),             // 0: proto.message.v1.MessageStatus
+	(*GetMessagesRequest)(nil),     // 1: proto.message.v1.GetMessagesRequest
+	(*GetMessagesResponse)(nil),    // 2: proto.message.v1.GetMessagesResponse
+	(*Message)(nil),                // 3: proto.message.v1.Message
+	(*SendMessageRequest)(nil),     // 4: proto.message.v1.SendMessageRequest
+	(*SendMessageResponse)(nil),    // 5: proto.message.v1.SendMessageResponse
+	(*MarkReadRequest)(nil),        // 6: proto.message.v1.MarkReadRequest
+	(*MarkAllReadRequest)(nil),     // 7: proto.message.v1.MarkAllReadRequest
+	(*MarkReadResponse)(nil),       // 8: proto.message.v1.MarkReadResponse
+	(*RecallMessageRequest)(nil),   // 9: proto.message.v1.RecallMessageRequest
+	(*RecallMessageResponse)(nil),  // 10: proto.message.v1.RecallMessageResponse
+	(*GetUnreadCountRequest)(nil),  // 11: proto.message.v1.GetUnreadCountRequest
+	(*UnreadCount)(nil),            // 12: proto.message.v1.UnreadCount
+	(*GetUnreadCountResponse)(nil), // 13: proto.message.v1.GetUnreadCountResponse
+	(*v1.UserRef)(nil),             // 14: common.v1.UserRef
+	(*v1.PageRequest)(nil),         // 15: common.v1.PageRequest
+	(*v1.PageResponse)(nil),        // 16: common.v1.PageResponse
+	(*timestamppb.Timestamp)(nil),  // 17: google.protobuf.Timestamp
 }
 var file_message_v1_message_proto_depIdxs = []int32{
-	2, // 0: proto.message.v1.GetMessagesResponse.messages:type_name -> proto.message.v1.Message
-	3, // 1: proto.message.v1.MessageService.SendMessage:input_type -> proto.message.v1.SendMessageRequest
-	0, // 2: proto.message.v1.MessageService.GetMessages:input_type -> proto.message.v1.GetMessagesRequest
-	4, // 3: proto.message.v1.MessageService.SendMessage:output_type -> proto.message.v1.SendMessageResponse
-	1, // 4: proto.message.v1.MessageService.GetMessages:output_type -> proto.message.v1.GetMessagesResponse
-	3, // [3:5] is the sub-list for method output_type
-	1, // [1:3] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	14, // 0: proto.message.v1.GetMessagesRequest.user:type_name -> common.v1.UserRef
+	15, // 1: proto.message.v1.GetMessagesRequest.pagination:type_name -> common.v1.PageRequest
+	3,  // 2: proto.message.v1.GetMessagesResponse.messages:type_name -> proto.message.v1.Message
+	16, // 3: proto.message.v1.GetMessagesResponse.pagination:type_name -> common.v1.PageResponse
+	14, // 4: proto.message.v1.Message.sender_ref:type_name -> common.v1.UserRef
+	14, // 5: proto.message.v1.Message.receiver_ref:type_name -> common.v1.UserRef
+	17, // 6: proto.message.v1.Message.created_at_time:type_name -> google.protobuf.Timestamp
+	0,  // 7: proto.message.v1.Message.state:type_name -> proto.message.v1.MessageStatus
+	17, // 8: proto.message.v1.Message.read_at:type_name -> google.protobuf.Timestamp
+	17, // 9: proto.message.v1.Message.recalled_at:type_name -> google.protobuf.Timestamp
+	14, // 10: proto.message.v1.SendMessageRequest.sender_ref:type_name -> common.v1.UserRef
+	14, // 11: proto.message.v1.SendMessageRequest.receiver_ref:type_name -> common.v1.UserRef
+	14, // 12: proto.message.v1.MarkReadRequest.user:type_name -> common.v1.UserRef
+	14, // 13: proto.message.v1.MarkAllReadRequest.user:type_name -> common.v1.UserRef
+	14, // 14: proto.message.v1.MarkAllReadRequest.peer:type_name -> common.v1.UserRef
+	17, // 15: proto.message.v1.MarkAllReadRequest.before:type_name -> google.protobuf.Timestamp
+	14, // 16: proto.message.v1.RecallMessageRequest.user:type_name -> common.v1.UserRef
+	3,  // 17: proto.message.v1.RecallMessageResponse.message:type_name -> proto.message.v1.Message
+	14, // 18: proto.message.v1.GetUnreadCountRequest.user:type_name -> common.v1.UserRef
+	14, // 19: proto.message.v1.GetUnreadCountRequest.peer:type_name -> common.v1.UserRef
+	14, // 20: proto.message.v1.UnreadCount.peer:type_name -> common.v1.UserRef
+	12, // 21: proto.message.v1.GetUnreadCountResponse.by_peer:type_name -> proto.message.v1.UnreadCount
+	4,  // 22: proto.message.v1.MessageService.SendMessage:input_type -> proto.message.v1.SendMessageRequest
+	1,  // 23: proto.message.v1.MessageService.GetMessages:input_type -> proto.message.v1.GetMessagesRequest
+	6,  // 24: proto.message.v1.MessageService.MarkRead:input_type -> proto.message.v1.MarkReadRequest
+	7,  // 25: proto.message.v1.MessageService.MarkAllRead:input_type -> proto.message.v1.MarkAllReadRequest
+	9,  // 26: proto.message.v1.MessageService.RecallMessage:input_type -> proto.message.v1.RecallMessageRequest
+	11, // 27: proto.message.v1.MessageService.GetUnreadCount:input_type -> proto.message.v1.GetUnreadCountRequest
+	5,  // 28: proto.message.v1.MessageService.SendMessage:output_type -> proto.message.v1.SendMessageResponse
+	2,  // 29: proto.message.v1.MessageService.GetMessages:output_type -> proto.message.v1.GetMessagesResponse
+	8,  // 30: proto.message.v1.MessageService.MarkRead:output_type -> proto.message.v1.MarkReadResponse
+	8,  // 31: proto.message.v1.MessageService.MarkAllRead:output_type -> proto.message.v1.MarkReadResponse
+	10, // 32: proto.message.v1.MessageService.RecallMessage:output_type -> proto.message.v1.RecallMessageResponse
+	13, // 33: proto.message.v1.MessageService.GetUnreadCount:output_type -> proto.message.v1.GetUnreadCountResponse
+	28, // [28:34] is the sub-list for method output_type
+	22, // [22:28] is the sub-list for method input_type
+	22, // [22:22] is the sub-list for extension type_name
+	22, // [22:22] is the sub-list for extension extendee
+	0,  // [0:22] is the sub-list for field type_name
 }
 
 func init() { file_message_v1_message_proto_init() }
@@ -398,13 +1049,14 @@ func file_message_v1_message_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_message_v1_message_proto_rawDesc), len(file_message_v1_message_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   5,
+			NumEnums:      1,
+			NumMessages:   13,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_message_v1_message_proto_goTypes,
 		DependencyIndexes: file_message_v1_message_proto_depIdxs,
+		EnumInfos:         file_message_v1_message_proto_enumTypes,
 		MessageInfos:      file_message_v1_message_proto_msgTypes,
 	}.Build()
 	File_message_v1_message_proto = out.File
@@ -0,0 +1,70 @@
+package admin
+
+import (
+	"context"
+	"slices"
+
+	pointv1 "github.com/trancecho/mundo-proto-sdk/points-system/v1"
+	"github.com/trancecho/mundo-proto-sdk/rauth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// RoleMetadataKey 网关鉴权后透传的用户角色
+	RoleMetadataKey = rauth.MetadataRole
+	RoleAdmin       = rauth.RoleAdmin
+)
+
+// Methods 仅管理员可调用的方法
+var Methods = []string{
+	pointv1.UserService_GetAdminStats_FullMethodName,
+	pointv1.UserService_GetAdminStatsV2_FullMethodName,
+}
+
+// RolesFromMetadata 从 incoming metadata 读取全部角色，与 rauth 相同，多个值及逗号分隔的角色均会解析
+func RolesFromMetadata(ctx context.Context) []string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return rauth.Roles(md)
+}
+
+// IsAdmin 判断调用方是否为管理员，只认 rauth 放入 context 的 Principal（由 rauth.UnaryServerInterceptor
+// 等服务端显式配置的认证方式生成），没有 Principal 时一律返回 false，不读取客户端可伪造的 metadata
+func IsAdmin(ctx context.Context) bool {
+	p, ok := rauth.FromContext(ctx)
+	return ok && p.IsAdmin()
+}
+
+// IsAdminFromMetadata 在没有 Principal 时按 incoming metadata 中的 x-user-role 判断，不要求 x-user-id。
+// 只能在服务仅由网关访问、且网关会丢弃客户端自带的 x-user-role 时使用
+func IsAdminFromMetadata(ctx context.Context) bool {
+	if p, ok := rauth.FromContext(ctx); ok {
+		return p.IsAdmin()
+	}
+	return slices.Contains(RolesFromMetadata(ctx), RoleAdmin)
+}
+
+// UnaryServerInterceptor 拦截 Methods 中的方法，非管理员返回 PermissionDenied。
+// isAdmin 为空时使用 IsAdmin；信任网关透传的角色需显式传入 IsAdminFromMetadata。
+func UnaryServerInterceptor(isAdmin func(ctx context.Context) bool) grpc.UnaryServerInterceptor {
+	if isAdmin == nil {
+		isAdmin = IsAdmin
+	}
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if slices.Contains(Methods, info.FullMethod) && !isAdmin(ctx) {
+			return nil, status.Error(codes.PermissionDenied, "admin role required")
+		}
+		return handler(ctx, req)
+	}
+}
+
+// Denied 在 handler 内部校验失败时使用的业务响应
+func Denied() *pointv1.AdminStatsResponse {
+	return &pointv1.AdminStatsResponse{
+		Success:   false,
+		Message:   "admin role required",
+		ErrorCode: pointv1.ErrorCode_PERMISSION_DENIED,
+	}
+}
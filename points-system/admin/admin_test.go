@@ -0,0 +1,71 @@
+package admin
+
+import (
+	"context"
+	"testing"
+
+	pointv1 "github.com/trancecho/mundo-proto-sdk/points-system/v1"
+	"github.com/trancecho/mundo-proto-sdk/rauth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestIsAdmin(t *testing.T) {
+	tests := []struct {
+		name         string
+		ctx          context.Context
+		want         bool
+		wantMetadata bool // IsAdminFromMetadata 的结果
+	}{
+		{"no metadata", context.Background(), false, false},
+		{"role without user id", metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-user-role", "admin")), false, true},
+		{"comma separated", metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-user-role", "moderator, admin")), false, true},
+		{"second value", metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-user-role", "moderator", "x-user-role", "admin")), false, true},
+		{"other role", metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-user-id", "1", "x-user-role", "moderator")), false, false},
+		{"principal wins over metadata", rauth.NewContext(
+			metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-user-role", "admin")),
+			&rauth.Principal{Uid: 1},
+		), false, false},
+		{"admin principal", rauth.NewContext(context.Background(), &rauth.Principal{Uid: 1, Roles: []string{"admin"}}), true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsAdmin(tt.ctx); got != tt.want {
+				t.Fatalf("IsAdmin = %v, want %v", got, tt.want)
+			}
+			if got := IsAdminFromMetadata(tt.ctx); got != tt.wantMetadata {
+				t.Fatalf("IsAdminFromMetadata = %v, want %v", got, tt.wantMetadata)
+			}
+		})
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	roleCtx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-user-role", "admin"))
+	adminCtx := rauth.NewContext(context.Background(), &rauth.Principal{Uid: 1, Roles: []string{"admin"}})
+	tests := []struct {
+		name    string
+		isAdmin func(context.Context) bool
+		ctx     context.Context
+		method  string
+		want    codes.Code
+	}{
+		{"admin method as admin", nil, adminCtx, pointv1.UserService_GetAdminStatsV2_FullMethodName, codes.OK},
+		{"admin method as user", nil, context.Background(), pointv1.UserService_GetAdminStats_FullMethodName, codes.PermissionDenied},
+		{"client supplied role rejected by default", nil, roleCtx, pointv1.UserService_GetAdminStats_FullMethodName, codes.PermissionDenied},
+		{"trusted gateway role", IsAdminFromMetadata, roleCtx, pointv1.UserService_GetAdminStats_FullMethodName, codes.OK},
+		{"other method as user", nil, context.Background(), pointv1.UserService_GetUserInfo_FullMethodName, codes.OK},
+	}
+	handler := func(context.Context, any) (any, error) { return "ok", nil }
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			interceptor := UnaryServerInterceptor(tt.isAdmin)
+			_, err := interceptor(tt.ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			if code := status.Code(err); code != tt.want {
+				t.Fatalf("code = %v, want %v", code, tt.want)
+			}
+		})
+	}
+}
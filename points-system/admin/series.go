@@ -0,0 +1,184 @@
+package admin
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	pointv1 "github.com/trancecho/mundo-proto-sdk/points-system/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// DefaultRange 未指定起始时间时的统计范围
+	DefaultRange = 30 * 24 * time.Hour
+	// MaxBuckets 单次请求最多返回的时间段数
+	MaxBuckets = 400
+)
+
+var ErrInvalidRange = errors.New("admin: invalid time range")
+
+// Range 解析后的统计请求
+type Range struct {
+	Start       time.Time
+	End         time.Time
+	Granularity pointv1.StatsGranularity
+	Location    *time.Location
+}
+
+// ParseRequest 解析 AdminStatsRequest 并补全默认值，now 为当前时间，
+// timezone 为空时使用 defaultLoc（为空时使用 time.Local）
+func ParseRequest(req *pointv1.AdminStatsRequest, now time.Time, defaultLoc *time.Location) (Range, error) {
+	r := Range{Granularity: req.GetGranularity(), Location: defaultLoc}
+	if r.Location == nil {
+		r.Location = time.Local
+	}
+	if tz := req.GetTimezone(); tz != "" {
+		loc, err := time.LoadLocation(tz)
+		if err != nil {
+			return Range{}, err
+		}
+		r.Location = loc
+	}
+	if r.Granularity == pointv1.StatsGranularity_STATS_GRANULARITY_UNSPECIFIED {
+		r.Granularity = pointv1.StatsGranularity_STATS_GRANULARITY_DAY
+	}
+	r.End = now
+	if req.GetEndTime() != nil {
+		r.End = req.GetEndTime().AsTime()
+	}
+	r.Start = r.End.Add(-DefaultRange)
+	if req.GetStartTime() != nil {
+		r.Start = req.GetStartTime().AsTime()
+	}
+	if !r.Start.Before(r.End) {
+		return Range{}, ErrInvalidRange
+	}
+	if len(r.Starts()) > MaxBuckets {
+		return Range{}, ErrInvalidRange
+	}
+	return r, nil
+}
+
+// Truncate 返回 t 所在时间段的起点
+func (r Range) Truncate(t time.Time) time.Time {
+	t = t.In(r.Location)
+	y, m, d := t.Date()
+	switch r.Granularity {
+	case pointv1.StatsGranularity_STATS_GRANULARITY_WEEK:
+		offset := (int(t.Weekday()) + 6) % 7 // 周一为 0
+		return time.Date(y, m, d-offset, 0, 0, 0, 0, r.Location)
+	case pointv1.StatsGranularity_STATS_GRANULARITY_MONTH:
+		return time.Date(y, m, 1, 0, 0, 0, 0, r.Location)
+	}
+	return time.Date(y, m, d, 0, 0, 0, 0, r.Location)
+}
+
+// next 返回下一个时间段的起点，按日历计算以正确处理夏令时
+func (r Range) next(t time.Time) time.Time {
+	switch r.Granularity {
+	case pointv1.StatsGranularity_STATS_GRANULARITY_WEEK:
+		return t.AddDate(0, 0, 7)
+	case pointv1.StatsGranularity_STATS_GRANULARITY_MONTH:
+		return t.AddDate(0, 1, 0)
+	}
+	return t.AddDate(0, 0, 1)
+}
+
+// Starts 范围内所有时间段的起点，第一个时间段从 Start 所在时间段开始
+func (r Range) Starts() []time.Time {
+	var starts []time.Time
+	for t := r.Truncate(r.Start); t.Before(r.End); t = r.next(t) {
+		starts = append(starts, t)
+		if len(starts) > MaxBuckets {
+			break
+		}
+	}
+	return starts
+}
+
+// Series 累加时间序列，服务端遍历流水、签到和等级变更记录后调用 Build 生成响应
+type Series struct {
+	r       Range
+	buckets map[time.Time]*seriesBucket
+}
+
+type seriesBucket struct {
+	issued, spent int64
+	signers       map[string]struct{}
+	migrations    map[[2]int32]int64
+}
+
+func NewSeries(r Range) *Series {
+	s := &Series{r: r, buckets: make(map[time.Time]*seriesBucket)}
+	for _, t := range r.Starts() {
+		s.buckets[t] = &seriesBucket{
+			signers:    make(map[string]struct{}),
+			migrations: make(map[[2]int32]int64),
+		}
+	}
+	return s
+}
+
+func (s *Series) bucket(at time.Time) *seriesBucket {
+	if at.Before(s.r.Start) || !at.Before(s.r.End) {
+		return nil
+	}
+	return s.buckets[s.r.Truncate(at)]
+}
+
+// AddTransaction 累加一条积分流水，正向计入发放，负向计入消耗
+func (s *Series) AddTransaction(at time.Time, deltaPoints int64) {
+	b := s.bucket(at)
+	if b == nil {
+		return
+	}
+	if deltaPoints > 0 {
+		b.issued += deltaPoints
+	} else {
+		b.spent -= deltaPoints
+	}
+}
+
+// AddSign 记录一次签到，同一时间段内同一用户只计一次
+func (s *Series) AddSign(at time.Time, userID string) {
+	if b := s.bucket(at); b != nil {
+		b.signers[userID] = struct{}{}
+	}
+}
+
+// AddLevelChange 记录一次等级变化，from == to 时忽略
+func (s *Series) AddLevelChange(at time.Time, from, to int32) {
+	if from == to {
+		return
+	}
+	if b := s.bucket(at); b != nil {
+		b.migrations[[2]int32{from, to}]++
+	}
+}
+
+// Build 按时间升序生成时间序列
+func (s *Series) Build() []*pointv1.AdminStatsBucket {
+	out := make([]*pointv1.AdminStatsBucket, 0, len(s.buckets))
+	for _, t := range s.r.Starts() {
+		b := s.buckets[t]
+		pb := &pointv1.AdminStatsBucket{
+			StartTime:     timestamppb.New(t),
+			PointsIssued:  b.issued,
+			PointsSpent:   b.spent,
+			ActiveSigners: int64(len(b.signers)),
+		}
+		for k, n := range b.migrations {
+			pb.LevelMigrations = append(pb.LevelMigrations, &pointv1.LevelMigration{FromLevel: k[0], ToLevel: k[1], UserCount: n})
+		}
+		sort.Slice(pb.LevelMigrations, func(i, j int) bool {
+			a, b := pb.LevelMigrations[i], pb.LevelMigrations[j]
+			if a.FromLevel != b.FromLevel {
+				return a.FromLevel < b.FromLevel
+			}
+			return a.ToLevel < b.ToLevel
+		})
+		out = append(out, pb)
+	}
+	return out
+}
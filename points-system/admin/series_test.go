@@ -0,0 +1,171 @@
+package admin
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	pointv1 "github.com/trancecho/mundo-proto-sdk/points-system/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	day   = pointv1.StatsGranularity_STATS_GRANULARITY_DAY
+	week  = pointv1.StatsGranularity_STATS_GRANULARITY_WEEK
+	month = pointv1.StatsGranularity_STATS_GRANULARITY_MONTH
+)
+
+func mustLoad(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("time zone %s unavailable: %v", name, err)
+	}
+	return loc
+}
+
+func TestParseRequest(t *testing.T) {
+	now := time.Date(2025, 10, 19, 12, 0, 0, 0, time.UTC)
+	ts := func(y int, m time.Month, d int) *timestamppb.Timestamp {
+		return timestamppb.New(time.Date(y, m, d, 0, 0, 0, 0, time.UTC))
+	}
+	tests := []struct {
+		name      string
+		req       *pointv1.AdminStatsRequest
+		wantStart time.Time
+		wantEnd   time.Time
+		wantGran  pointv1.StatsGranularity
+		wantLoc   string
+		wantErr   error
+	}{
+		{"defaults", &pointv1.AdminStatsRequest{}, now.Add(-DefaultRange), now, day, "UTC", nil},
+		{"explicit range", &pointv1.AdminStatsRequest{StartTime: ts(2025, 1, 1), EndTime: ts(2025, 2, 1), Granularity: week},
+			time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), week, "UTC", nil},
+		{"timezone", &pointv1.AdminStatsRequest{Timezone: "Asia/Shanghai"}, now.Add(-DefaultRange), now, day, "Asia/Shanghai", nil},
+		{"start after end", &pointv1.AdminStatsRequest{StartTime: ts(2025, 3, 1), EndTime: ts(2025, 2, 1)}, time.Time{}, time.Time{}, 0, "", ErrInvalidRange},
+		{"empty range", &pointv1.AdminStatsRequest{StartTime: ts(2025, 2, 1), EndTime: ts(2025, 2, 1)}, time.Time{}, time.Time{}, 0, "", ErrInvalidRange},
+		{"too many day buckets", &pointv1.AdminStatsRequest{StartTime: ts(2020, 1, 1), EndTime: ts(2025, 1, 1)}, time.Time{}, time.Time{}, 0, "", ErrInvalidRange},
+		{"same span by month", &pointv1.AdminStatsRequest{StartTime: ts(2020, 1, 1), EndTime: ts(2025, 1, 1), Granularity: month},
+			time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), month, "UTC", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := ParseRequest(tt.req, now, time.UTC)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !r.Start.Equal(tt.wantStart) || !r.End.Equal(tt.wantEnd) || r.Granularity != tt.wantGran || r.Location.String() != tt.wantLoc {
+				t.Fatalf("Range = %+v", r)
+			}
+		})
+	}
+	if _, err := ParseRequest(&pointv1.AdminStatsRequest{Timezone: "Mars/Olympus"}, now, time.UTC); err == nil {
+		t.Fatal("unknown timezone accepted")
+	}
+}
+
+func TestMaxBuckets(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, tt := range []struct {
+		days    int
+		wantErr bool
+	}{
+		{MaxBuckets, false},
+		{MaxBuckets + 1, true},
+	} {
+		req := &pointv1.AdminStatsRequest{
+			StartTime: timestamppb.New(start),
+			EndTime:   timestamppb.New(start.AddDate(0, 0, tt.days)),
+		}
+		r, err := ParseRequest(req, start, time.UTC)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("%d days: err = %v", tt.days, err)
+		}
+		if err == nil && len(r.Starts()) != tt.days {
+			t.Fatalf("%d days: %d buckets", tt.days, len(r.Starts()))
+		}
+	}
+}
+
+func TestStarts(t *testing.T) {
+	ny := mustLoad(t, "America/New_York")
+	local := func(y int, m time.Month, d, h int) time.Time { return time.Date(y, m, d, h, 0, 0, 0, ny) }
+	tests := []struct {
+		name  string
+		r     Range
+		want  []time.Time
+		hours []float64 // 各时间段的时长（小时），为空时不检查
+	}{
+		{"spring forward", Range{Start: local(2025, 3, 8, 15), End: local(2025, 3, 11, 0), Granularity: day, Location: ny},
+			[]time.Time{local(2025, 3, 8, 0), local(2025, 3, 9, 0), local(2025, 3, 10, 0)}, []float64{24, 23, 24}},
+		{"fall back", Range{Start: local(2025, 11, 1, 0), End: local(2025, 11, 3, 0), Granularity: day, Location: ny},
+			[]time.Time{local(2025, 11, 1, 0), local(2025, 11, 2, 0)}, []float64{24, 25}},
+		{"week starts on monday", Range{Start: local(2025, 10, 19, 12), End: local(2025, 10, 28, 0), Granularity: week, Location: ny},
+			[]time.Time{local(2025, 10, 13, 0), local(2025, 10, 20, 0), local(2025, 10, 27, 0)}, nil},
+		{"month", Range{Start: local(2025, 1, 31, 0), End: local(2025, 3, 2, 0), Granularity: month, Location: ny},
+			[]time.Time{local(2025, 1, 1, 0), local(2025, 2, 1, 0), local(2025, 3, 1, 0)}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.r.Starts()
+			if !slices.EqualFunc(got, tt.want, time.Time.Equal) {
+				t.Fatalf("Starts = %v, want %v", got, tt.want)
+			}
+			for i, h := range tt.hours {
+				if d := tt.r.next(got[i]).Sub(got[i]).Hours(); d != h {
+					t.Fatalf("bucket %d lasts %vh, want %vh", i, d, h)
+				}
+			}
+		})
+	}
+}
+
+func TestSeriesBuild(t *testing.T) {
+	ny := mustLoad(t, "America/New_York")
+	local := func(d, h int) time.Time { return time.Date(2025, 3, d, h, 0, 0, 0, ny) }
+	s := NewSeries(Range{Start: local(8, 0), End: local(10, 0), Granularity: day, Location: ny})
+
+	s.AddTransaction(local(8, 1), 10)
+	s.AddTransaction(local(8, 23), -4)
+	s.AddTransaction(local(9, 1), 5)  // 夏令时切换前
+	s.AddTransaction(local(9, 23), 7) // 夏令时切换后，仍属于 3 月 9 日
+	s.AddTransaction(local(7, 23), 100)
+	s.AddTransaction(local(10, 0), 100) // End 不包含在内
+	s.AddSign(local(8, 1), "u1")
+	s.AddSign(local(8, 20), "u1")
+	s.AddSign(local(8, 21), "u2")
+	s.AddSign(local(9, 5), "u1")
+	s.AddLevelChange(local(9, 5), 2, 3)
+	s.AddLevelChange(local(9, 6), 1, 2)
+	s.AddLevelChange(local(9, 7), 2, 3)
+	s.AddLevelChange(local(9, 8), 3, 3)
+
+	type bucket struct {
+		start                 time.Time
+		issued, spent, signer int64
+		migrations            [][3]int64
+	}
+	want := []bucket{
+		{local(8, 0), 10, 4, 2, nil},
+		{local(9, 0), 12, 0, 1, [][3]int64{{1, 2, 1}, {2, 3, 2}}},
+	}
+	got := s.Build()
+	if len(got) != len(want) {
+		t.Fatalf("Build returned %d buckets, want %d", len(got), len(want))
+	}
+	for i, w := range want {
+		g := got[i]
+		var migrations [][3]int64
+		for _, m := range g.GetLevelMigrations() {
+			migrations = append(migrations, [3]int64{int64(m.GetFromLevel()), int64(m.GetToLevel()), m.GetUserCount()})
+		}
+		if !g.GetStartTime().AsTime().Equal(w.start) || g.GetPointsIssued() != w.issued || g.GetPointsSpent() != w.spent ||
+			g.GetActiveSigners() != w.signer || !slices.Equal(migrations, w.migrations) {
+			t.Fatalf("bucket %d = %v, want %+v", i, g, w)
+		}
+	}
+}
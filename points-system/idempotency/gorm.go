@@ -0,0 +1,130 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/trancecho/mundo-proto-sdk/rmodel"
+	"gorm.io/gorm"
+)
+
+// IdempotencyKey 幂等键表，Key 唯一
+type IdempotencyKey struct {
+	rmodel.BaseModel
+	Key         string    `gorm:"column:idem_key;uniqueIndex;size:191" json:"key"`
+	Owner       string    `gorm:"size:32" json:"-"` // 当前占用者的凭证
+	Fingerprint string    `gorm:"size:64" json:"-"` // 请求内容摘要
+	Done        bool      `json:"done"`
+	Result      []byte    `json:"-"`
+	ExpiresAt   time.Time `gorm:"index" json:"expires_at"`
+}
+
+func (IdempotencyKey) TableName() string {
+	return "idempotency_keys"
+}
+
+// GormStore 基于数据库唯一键的幂等键存储
+type GormStore struct {
+	db *gorm.DB
+	// PendingTTL 处理中状态的过期时间，防止进程崩溃后 key 永久处于处理中，默认 30s
+	PendingTTL time.Duration
+}
+
+var _ Store = &GormStore{}
+
+func NewGormStore(db *gorm.DB) *GormStore {
+	return &GormStore{db: db, PendingTTL: 30 * time.Second}
+}
+
+// AutoMigrate 创建或更新幂等键表
+func (s *GormStore) AutoMigrate() error {
+	return s.db.AutoMigrate(&IdempotencyKey{})
+}
+
+func (s *GormStore) Acquire(ctx context.Context, key, fingerprint string, ttl time.Duration) ([]byte, string, error) {
+	db := s.db.WithContext(ctx)
+	pendingTTL := s.PendingTTL
+	if pendingTTL <= 0 || pendingTTL > ttl {
+		pendingTTL = ttl
+	}
+
+	var lastErr error
+	for range maxAcquireAttempts {
+		now := time.Now()
+		token := newToken()
+		createErr := db.Create(&IdempotencyKey{Key: key, Owner: token, Fingerprint: fingerprint, ExpiresAt: now.Add(pendingTTL)}).Error
+		if createErr == nil {
+			return nil, token, nil
+		}
+
+		var rec IdempotencyKey
+		err := db.Unscoped().Where("idem_key = ?", key).Take(&rec).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// 不是唯一键冲突，或记录刚被释放，重试
+			lastErr = createErr
+			continue
+		}
+		if err != nil {
+			return nil, "", err
+		}
+		if rec.ExpiresAt.Before(now) {
+			// 已过期，通过条件更新抢占，只有一个请求能成功
+			res := db.Unscoped().Model(&IdempotencyKey{}).
+				Where("id = ? AND owner = ? AND expires_at = ?", rec.ID, rec.Owner, rec.ExpiresAt).
+				Updates(map[string]any{
+					"owner":       token,
+					"fingerprint": fingerprint,
+					"done":        false,
+					"result":      nil,
+					"expires_at":  now.Add(pendingTTL),
+					"deleted_at":  nil,
+				})
+			if res.Error != nil {
+				return nil, "", res.Error
+			}
+			if res.RowsAffected == 1 {
+				return nil, token, nil
+			}
+			return nil, "", ErrInProgress
+		}
+		if rec.Fingerprint != fingerprint {
+			return nil, "", ErrMismatch
+		}
+		if !rec.Done {
+			return nil, "", ErrInProgress
+		}
+		return rec.Result, "", nil
+	}
+	return nil, "", lastErr
+}
+
+func (s *GormStore) Complete(ctx context.Context, key, token string, result []byte, ttl time.Duration) error {
+	res := s.db.WithContext(ctx).Model(&IdempotencyKey{}).
+		Where("idem_key = ? AND owner = ?", key, token).
+		Updates(map[string]any{"done": true, "result": result, "expires_at": time.Now().Add(ttl)})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrNotOwner
+	}
+	return nil
+}
+
+func (s *GormStore) Release(ctx context.Context, key, token string) error {
+	res := s.db.WithContext(ctx).Unscoped().Where("idem_key = ? AND owner = ?", key, token).Delete(&IdempotencyKey{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrNotOwner
+	}
+	return nil
+}
+
+// PurgeExpired 物理删除已过期的记录，建议定时调用
+func (s *GormStore) PurgeExpired(ctx context.Context) (int64, error) {
+	res := s.db.WithContext(ctx).Unscoped().Where("expires_at < ?", time.Now()).Delete(&IdempotencyKey{})
+	return res.RowsAffected, res.Error
+}
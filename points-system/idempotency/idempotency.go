@@ -0,0 +1,152 @@
+package idempotency
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+var (
+	ErrInProgress = errors.New("idempotency: request in progress")
+	// ErrMismatch 同一个 key 被用于内容不同的请求
+	ErrMismatch = errors.New("idempotency: key reused with a different request")
+	// ErrNotOwner 持有的占用已过期并被其他请求接管，或已被释放
+	ErrNotOwner = errors.New("idempotency: key not owned by caller")
+)
+
+// maxAcquireAttempts 占用时记录恰好过期或被释放的重试次数
+const maxAcquireAttempts = 3
+
+// Store 幂等键存储
+type Store interface {
+	// Acquire 占用 key，fingerprint 为请求内容的摘要。首次占用返回非空的 token，作为 Complete/Release 的凭证；
+	// 已完成的请求返回空 token 和缓存的结果；fingerprint 与首次请求不同返回 ErrMismatch，仍在处理中返回 ErrInProgress
+	Acquire(ctx context.Context, key, fingerprint string, ttl time.Duration) (result []byte, token string, err error)
+	// Complete 保存处理结果，ttl 内相同 key 的请求直接返回该结果；token 不匹配时返回 ErrNotOwner
+	Complete(ctx context.Context, key, token string, result []byte, ttl time.Duration) error
+	// Release 处理失败时释放 key，允许客户端使用同一个 key 重试；token 不匹配时返回 ErrNotOwner
+	Release(ctx context.Context, key, token string) error
+}
+
+// Fingerprint 请求内容的摘要，计算前清空 idempotency_key 字段，使同一请求的重试得到相同的结果
+func Fingerprint(req proto.Message) (string, error) {
+	m := proto.Clone(req)
+	if fd := m.ProtoReflect().Descriptor().Fields().ByName("idempotency_key"); fd != nil {
+		m.ProtoReflect().Clear(fd)
+	}
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(m)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// newToken 生成占用者的随机凭证
+func newToken() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic("idempotency: crypto/rand failed: " + err.Error())
+	}
+	return hex.EncodeToString(b[:])
+}
+
+// Do 以 key 保证 fn 在 ttl 内只成功执行一次，重复请求返回首次的结果。
+// fingerprint 为请求内容的摘要（见 Fingerprint），同一个 key 携带不同内容时返回 ErrMismatch。
+// fn 返回错误时释放 key；返回的业务失败响应（如积分不足）同样会被缓存。
+func Do(ctx context.Context, store Store, key, fingerprint string, ttl time.Duration, fn func() (proto.Message, error)) (proto.Message, error) {
+	cached, token, err := store.Acquire(ctx, key, fingerprint, ttl)
+	if err != nil {
+		return nil, err
+	}
+	if token == "" {
+		var a anypb.Any
+		if err := proto.Unmarshal(cached, &a); err != nil {
+			return nil, err
+		}
+		return a.UnmarshalNew()
+	}
+
+	resp, err := fn()
+	if err != nil {
+		if rerr := store.Release(context.WithoutCancel(ctx), key, token); rerr != nil {
+			log.Println("idempotency: release key error:", key, rerr)
+		}
+		return nil, err
+	}
+	a, err := anypb.New(resp)
+	if err != nil {
+		return resp, nil
+	}
+	data, err := proto.Marshal(a)
+	if err == nil {
+		err = store.Complete(context.WithoutCancel(ctx), key, token, data, ttl)
+	}
+	if err != nil {
+		// 结果已生效，只是无法缓存，不影响本次返回；ErrNotOwner 说明处理时间超过了 PendingTTL
+		log.Println("idempotency: save result error:", key, err)
+	}
+	return resp, nil
+}
+
+type keyedRequest interface {
+	GetIdempotencyKey() string
+}
+
+// UnaryServerInterceptor 对携带 idempotency_key 的请求去重。
+// key 按 gRPC 方法区分；store 不可用时返回 Unavailable，处理中返回 Aborted，客户端可用同一个 key 重试；
+// 同一个 key 携带不同的请求内容返回 InvalidArgument。
+func UnaryServerInterceptor(store Store, ttl time.Duration) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		kr, ok := req.(keyedRequest)
+		if !ok || kr.GetIdempotencyKey() == "" {
+			return handler(ctx, req)
+		}
+		key := info.FullMethod + ":" + kr.GetIdempotencyKey()
+		var fingerprint string
+		if msg, ok := req.(proto.Message); ok {
+			fp, err := Fingerprint(msg)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "idempotency: %v", err)
+			}
+			fingerprint = fp
+		}
+
+		var handlerErr bool
+		resp, err := Do(ctx, store, key, fingerprint, ttl, func() (proto.Message, error) {
+			resp, err := handler(ctx, req)
+			if err != nil {
+				handlerErr = true
+				return nil, err
+			}
+			msg, ok := resp.(proto.Message)
+			if !ok {
+				handlerErr = true
+				return nil, status.Error(codes.Internal, "idempotency: response is not a proto message")
+			}
+			return msg, nil
+		})
+		switch {
+		case err == nil:
+			return resp, nil
+		case handlerErr:
+			return nil, err
+		case errors.Is(err, ErrInProgress):
+			return nil, status.Error(codes.Aborted, err.Error())
+		case errors.Is(err, ErrMismatch):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		default:
+			return nil, status.Errorf(codes.Unavailable, "idempotency store: %v", err)
+		}
+	}
+}
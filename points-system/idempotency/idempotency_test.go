@@ -0,0 +1,210 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/glebarez/sqlite"
+	"github.com/redis/go-redis/v9"
+	pointv1 "github.com/trancecho/mundo-proto-sdk/points-system/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const pendingTTL = 50 * time.Millisecond
+
+type storeCase struct {
+	name string
+	new  func(t *testing.T) (Store, func())
+}
+
+// storeCases 返回各个 Store 实现，以及使处理中状态过期的函数
+func storeCases() []storeCase {
+	return []storeCase{
+		{"redis", func(t *testing.T) (Store, func()) {
+			mr := miniredis.RunT(t)
+			s := NewRedisStore(redis.NewClient(&redis.Options{Addr: mr.Addr()}), "")
+			s.PendingTTL = pendingTTL
+			return s, func() { mr.FastForward(2 * pendingTTL) }
+		}},
+		{"gorm", func(t *testing.T) (Store, func()) {
+			db, err := gorm.Open(sqlite.Open("file:"+t.Name()+"?mode=memory&cache=shared"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+			if err != nil {
+				t.Fatal(err)
+			}
+			s := NewGormStore(db)
+			s.PendingTTL = pendingTTL
+			if err := s.AutoMigrate(); err != nil {
+				t.Fatal(err)
+			}
+			return s, func() { time.Sleep(2 * pendingTTL) }
+		}},
+	}
+}
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name string
+		run  func(t *testing.T, s Store, expire func())
+	}{
+		{"duplicate while pending", func(t *testing.T, s Store, _ func()) {
+			if _, token, err := s.Acquire(ctx, "k", "fp", time.Minute); err != nil || token == "" {
+				t.Fatalf("first Acquire = %q, %v", token, err)
+			}
+			if _, _, err := s.Acquire(ctx, "k", "fp", time.Minute); !errors.Is(err, ErrInProgress) {
+				t.Fatalf("second Acquire err = %v, want ErrInProgress", err)
+			}
+		}},
+		{"completed result is cached", func(t *testing.T, s Store, _ func()) {
+			_, token, _ := s.Acquire(ctx, "k", "fp", time.Minute)
+			if err := s.Complete(ctx, "k", token, []byte("ok"), time.Minute); err != nil {
+				t.Fatal(err)
+			}
+			result, token, err := s.Acquire(ctx, "k", "fp", time.Minute)
+			if err != nil || token != "" || string(result) != "ok" {
+				t.Fatalf("Acquire = %q, %q, %v; want cached result", result, token, err)
+			}
+		}},
+		{"fingerprint mismatch", func(t *testing.T, s Store, _ func()) {
+			_, token, _ := s.Acquire(ctx, "k", "fp", time.Minute)
+			if _, _, err := s.Acquire(ctx, "k", "other", time.Minute); !errors.Is(err, ErrMismatch) {
+				t.Fatalf("pending: err = %v, want ErrMismatch", err)
+			}
+			if err := s.Complete(ctx, "k", token, []byte("ok"), time.Minute); err != nil {
+				t.Fatal(err)
+			}
+			if _, _, err := s.Acquire(ctx, "k", "other", time.Minute); !errors.Is(err, ErrMismatch) {
+				t.Fatalf("done: err = %v, want ErrMismatch", err)
+			}
+		}},
+		{"wrong token", func(t *testing.T, s Store, _ func()) {
+			_, token, _ := s.Acquire(ctx, "k", "fp", time.Minute)
+			if err := s.Complete(ctx, "k", "bogus", []byte("x"), time.Minute); !errors.Is(err, ErrNotOwner) {
+				t.Fatalf("Complete err = %v, want ErrNotOwner", err)
+			}
+			if err := s.Release(ctx, "k", "bogus"); !errors.Is(err, ErrNotOwner) {
+				t.Fatalf("Release err = %v, want ErrNotOwner", err)
+			}
+			if err := s.Release(ctx, "k", token); err != nil {
+				t.Fatal(err)
+			}
+			if _, token, err := s.Acquire(ctx, "k", "fp", time.Minute); err != nil || token == "" {
+				t.Fatalf("Acquire after release = %q, %v", token, err)
+			}
+		}},
+		{"expired lease is taken over", func(t *testing.T, s Store, expire func()) {
+			_, stale, _ := s.Acquire(ctx, "k", "fp", time.Minute)
+			expire()
+			_, token, err := s.Acquire(ctx, "k", "fp", time.Minute)
+			if err != nil || token == "" || token == stale {
+				t.Fatalf("takeover Acquire = %q, %v", token, err)
+			}
+			if err := s.Complete(ctx, "k", stale, []byte("stale"), time.Minute); !errors.Is(err, ErrNotOwner) {
+				t.Fatalf("stale Complete err = %v, want ErrNotOwner", err)
+			}
+			if err := s.Release(ctx, "k", stale); !errors.Is(err, ErrNotOwner) {
+				t.Fatalf("stale Release err = %v, want ErrNotOwner", err)
+			}
+			if err := s.Complete(ctx, "k", token, []byte("fresh"), time.Minute); err != nil {
+				t.Fatal(err)
+			}
+			if result, _, _ := s.Acquire(ctx, "k", "fp", time.Minute); string(result) != "fresh" {
+				t.Fatalf("cached result = %q, want fresh", result)
+			}
+		}},
+	}
+	for _, sc := range storeCases() {
+		for _, tt := range tests {
+			t.Run(sc.name+"/"+tt.name, func(t *testing.T) {
+				s, expire := sc.new(t)
+				tt.run(t, s, expire)
+			})
+		}
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	base := &pointv1.UpdatePointsRequest{UserId: "1", DeltaPoints: 10, IdempotencyKey: "a"}
+	tests := []struct {
+		name string
+		req  *pointv1.UpdatePointsRequest
+		same bool
+	}{
+		{"identical", &pointv1.UpdatePointsRequest{UserId: "1", DeltaPoints: 10, IdempotencyKey: "a"}, true},
+		{"key ignored", &pointv1.UpdatePointsRequest{UserId: "1", DeltaPoints: 10, IdempotencyKey: "b"}, true},
+		{"different payload", &pointv1.UpdatePointsRequest{UserId: "1", DeltaPoints: 20, IdempotencyKey: "a"}, false},
+	}
+	want, err := Fingerprint(base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Fingerprint(tt.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (got == want) != tt.same {
+				t.Fatalf("Fingerprint equal = %v, want %v", got == want, tt.same)
+			}
+		})
+	}
+	if base.GetIdempotencyKey() != "a" {
+		t.Fatal("Fingerprint modified the request")
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/points.v1.PointsService/UpdatePoints"}
+	req := func(delta int64, key string) *pointv1.UpdatePointsRequest {
+		return &pointv1.UpdatePointsRequest{UserId: "1", DeltaPoints: delta, IdempotencyKey: key}
+	}
+	tests := []struct {
+		name      string
+		reqs      []*pointv1.UpdatePointsRequest
+		failFirst bool
+		wantCalls int
+		wantCode  codes.Code // 最后一个请求的状态码
+	}{
+		{"retry returns cached response", []*pointv1.UpdatePointsRequest{req(10, "k"), req(10, "k")}, false, 1, codes.OK},
+		{"reused key with different payload", []*pointv1.UpdatePointsRequest{req(10, "k"), req(20, "k")}, false, 1, codes.InvalidArgument},
+		{"handler error releases key", []*pointv1.UpdatePointsRequest{req(10, "k"), req(10, "k")}, true, 2, codes.OK},
+		{"no key bypasses store", []*pointv1.UpdatePointsRequest{req(10, ""), req(10, "")}, false, 2, codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := storeCases()[0].new(t)
+			interceptor := UnaryServerInterceptor(s, time.Minute)
+			calls := 0
+			handler := func(ctx context.Context, req any) (any, error) {
+				calls++
+				if tt.failFirst && calls == 1 {
+					return nil, status.Error(codes.Internal, "boom")
+				}
+				return &pointv1.CommonResponse{Success: true, Message: "done"}, nil
+			}
+			var err error
+			var resp any
+			for _, r := range tt.reqs {
+				resp, err = interceptor(context.Background(), r, info, handler)
+			}
+			if calls != tt.wantCalls {
+				t.Fatalf("handler calls = %d, want %d", calls, tt.wantCalls)
+			}
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("code = %v (%v), want %v", code, err, tt.wantCode)
+			}
+			if err == nil && !proto.Equal(resp.(proto.Message), &pointv1.CommonResponse{Success: true, Message: "done"}) {
+				t.Fatalf("resp = %v", resp)
+			}
+		})
+	}
+}
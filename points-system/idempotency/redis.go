@@ -0,0 +1,105 @@
+package idempotency
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 每个 key 为一个 hash：owner 占用者凭证，fp 请求摘要，done/result 处理结果。
+// 占用、完成和释放均通过脚本原子执行，只有 owner 匹配时才能完成或释放
+var (
+	acquireScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	redis.call('HSET', KEYS[1], 'owner', ARGV[1], 'fp', ARGV[2])
+	redis.call('PEXPIRE', KEYS[1], ARGV[3])
+	return {1}
+end
+local v = redis.call('HMGET', KEYS[1], 'fp', 'done', 'result')
+return {0, v[1], v[2], v[3]}
+`)
+	completeScript = redis.NewScript(`
+if redis.call('HGET', KEYS[1], 'owner') ~= ARGV[1] then
+	return 0
+end
+redis.call('HSET', KEYS[1], 'done', '1', 'result', ARGV[2])
+redis.call('PEXPIRE', KEYS[1], ARGV[3])
+return 1
+`)
+	releaseScript = redis.NewScript(`
+if redis.call('HGET', KEYS[1], 'owner') ~= ARGV[1] then
+	return 0
+end
+return redis.call('DEL', KEYS[1])
+`)
+)
+
+// RedisStore 基于 Redis 的幂等键存储
+type RedisStore struct {
+	rdb    redis.Cmdable
+	prefix string
+	// PendingTTL 处理中状态的过期时间，防止进程崩溃后 key 永久处于处理中，默认 30s
+	PendingTTL time.Duration
+}
+
+var _ Store = &RedisStore{}
+
+func NewRedisStore(rdb redis.Cmdable, prefix string) *RedisStore {
+	if prefix == "" {
+		prefix = "idempotency:"
+	}
+	return &RedisStore{rdb: rdb, prefix: prefix, PendingTTL: 30 * time.Second}
+}
+
+func (s *RedisStore) Acquire(ctx context.Context, key, fingerprint string, ttl time.Duration) ([]byte, string, error) {
+	pendingTTL := s.PendingTTL
+	if pendingTTL <= 0 || pendingTTL > ttl {
+		pendingTTL = ttl
+	}
+	token := newToken()
+	res, err := acquireScript.Run(ctx, s.rdb, []string{s.prefix + key}, token, fingerprint, pendingTTL.Milliseconds()).Slice()
+	if err != nil {
+		return nil, "", err
+	}
+	if len(res) == 0 {
+		return nil, "", fmt.Errorf("idempotency: unexpected script reply %v", res)
+	}
+	if acquired, _ := res[0].(int64); acquired == 1 {
+		return nil, token, nil
+	}
+	if len(res) < 4 {
+		return nil, "", fmt.Errorf("idempotency: unexpected script reply %v", res)
+	}
+	if fp, _ := res[1].(string); fp != fingerprint {
+		return nil, "", ErrMismatch
+	}
+	if done, _ := res[2].(string); done != "1" {
+		return nil, "", ErrInProgress
+	}
+	result, _ := res[3].(string)
+	return []byte(result), "", nil
+}
+
+func (s *RedisStore) Complete(ctx context.Context, key, token string, result []byte, ttl time.Duration) error {
+	ok, err := completeScript.Run(ctx, s.rdb, []string{s.prefix + key}, token, result, ttl.Milliseconds()).Int()
+	if err != nil {
+		return err
+	}
+	if ok == 0 {
+		return ErrNotOwner
+	}
+	return nil
+}
+
+func (s *RedisStore) Release(ctx context.Context, key, token string) error {
+	ok, err := releaseScript.Run(ctx, s.rdb, []string{s.prefix + key}, token).Int()
+	if err != nil {
+		return err
+	}
+	if ok == 0 {
+		return ErrNotOwner
+	}
+	return nil
+}
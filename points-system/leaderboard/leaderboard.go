@@ -0,0 +1,249 @@
+package leaderboard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/trancecho/mundo-proto-sdk/common/userref"
+	pointv1 "github.com/trancecho/mundo-proto-sdk/points-system/v1"
+)
+
+var ErrInvalidMetric = errors.New("leaderboard: invalid metric")
+
+// periods Incr 同时累加的周期榜
+var periods = []pointv1.LeaderboardPeriod{
+	pointv1.LeaderboardPeriod_LEADERBOARD_PERIOD_DAILY,
+	pointv1.LeaderboardPeriod_LEADERBOARD_PERIOD_WEEKLY,
+	pointv1.LeaderboardPeriod_LEADERBOARD_PERIOD_MONTHLY,
+	pointv1.LeaderboardPeriod_LEADERBOARD_PERIOD_ALL_TIME,
+}
+
+// Board 基于 Redis 有序集合的排行榜，每个 指标+周期+时间段 对应一个 key，
+// 如 leaderboard:points:daily:20251019、leaderboard:points:weekly:2025W42、leaderboard:points:all
+type Board struct {
+	rdb    redis.Cmdable
+	prefix string
+	loc    *time.Location
+}
+
+// New 创建排行榜，prefix 为空时使用 "leaderboard:"，周期按 loc 划分（为空时使用 time.Local）
+func New(rdb redis.Cmdable, prefix string, loc *time.Location) *Board {
+	if prefix == "" {
+		prefix = "leaderboard:"
+	}
+	if loc == nil {
+		loc = time.Local
+	}
+	return &Board{rdb: rdb, prefix: prefix, loc: loc}
+}
+
+// Entry 排行榜条目，Rank 从 1 开始
+type Entry struct {
+	Rank   int64
+	UserID string
+	Score  int64
+}
+
+// ToProto 转换为 LeaderboardEntry，用户名和等级由调用方补充
+func (e Entry) ToProto() *pointv1.LeaderboardEntry {
+	return &pointv1.LeaderboardEntry{Rank: e.Rank, UserId: e.UserID, Score: e.Score}
+}
+
+// Key 返回 at 所在周期的 key。metric 未指定或未知时返回 ErrInvalidMetric；
+// period 未指定时与 proto 约定一致，等同于总榜
+func (b *Board) Key(metric pointv1.LeaderboardMetric, period pointv1.LeaderboardPeriod, at time.Time) (string, error) {
+	if _, ok := pointv1.LeaderboardMetric_name[int32(metric)]; !ok || metric == pointv1.LeaderboardMetric_LEADERBOARD_METRIC_UNSPECIFIED {
+		return "", fmt.Errorf("%w: %v", ErrInvalidMetric, metric)
+	}
+	t := at.In(b.loc)
+	m := strings.ToLower(strings.TrimPrefix(metric.String(), "LEADERBOARD_METRIC_"))
+	switch period {
+	case pointv1.LeaderboardPeriod_LEADERBOARD_PERIOD_DAILY:
+		return fmt.Sprintf("%s%s:daily:%s", b.prefix, m, t.Format("20060102")), nil
+	case pointv1.LeaderboardPeriod_LEADERBOARD_PERIOD_WEEKLY:
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%s%s:weekly:%dW%02d", b.prefix, m, year, week), nil
+	case pointv1.LeaderboardPeriod_LEADERBOARD_PERIOD_MONTHLY:
+		return fmt.Sprintf("%s%s:monthly:%s", b.prefix, m, t.Format("200601")), nil
+	}
+	return fmt.Sprintf("%s%s:all", b.prefix, m), nil
+}
+
+// ttl 周期榜的保留时间，保证上一周期的榜单在切换后仍可查询
+func ttl(period pointv1.LeaderboardPeriod) time.Duration {
+	switch period {
+	case pointv1.LeaderboardPeriod_LEADERBOARD_PERIOD_DAILY:
+		return 3 * 24 * time.Hour
+	case pointv1.LeaderboardPeriod_LEADERBOARD_PERIOD_WEEKLY:
+		return 3 * 7 * 24 * time.Hour
+	case pointv1.LeaderboardPeriod_LEADERBOARD_PERIOD_MONTHLY:
+		return 93 * 24 * time.Hour
+	}
+	return 0
+}
+
+// Incr 累加用户在日、周、月、总榜上的分数，用于积分、经验等增量指标。
+// 周期榜统计的是周期内获得的量，负的 delta（如消费积分）只计入总榜
+func (b *Board) Incr(ctx context.Context, metric pointv1.LeaderboardMetric, userID string, delta int64, at time.Time) error {
+	if delta == 0 {
+		return nil
+	}
+	_, err := b.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, p := range periods {
+			if delta < 0 && p != pointv1.LeaderboardPeriod_LEADERBOARD_PERIOD_ALL_TIME {
+				continue
+			}
+			key, err := b.Key(metric, p, at)
+			if err != nil {
+				return err
+			}
+			pipe.ZIncrBy(ctx, key, float64(delta), userID)
+			if d := ttl(p); d > 0 {
+				pipe.Expire(ctx, key, d)
+			}
+		}
+		return nil
+	})
+	return err
+}
+
+// Set 直接设置用户在某个榜单上的分数，用于连续签到天数等绝对值指标
+func (b *Board) Set(ctx context.Context, metric pointv1.LeaderboardMetric, period pointv1.LeaderboardPeriod, userID string, score int64, at time.Time) error {
+	key, err := b.Key(metric, period, at)
+	if err != nil {
+		return err
+	}
+	_, err = b.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZAdd(ctx, key, redis.Z{Score: float64(score), Member: userID})
+		if d := ttl(period); d > 0 {
+			pipe.Expire(ctx, key, d)
+		}
+		return nil
+	})
+	return err
+}
+
+// Top 按分数降序返回 [offset, offset+limit) 的条目
+func (b *Board) Top(ctx context.Context, metric pointv1.LeaderboardMetric, period pointv1.LeaderboardPeriod, at time.Time, offset, limit int64) ([]Entry, error) {
+	if limit <= 0 {
+		return nil, nil
+	}
+	key, err := b.Key(metric, period, at)
+	if err != nil {
+		return nil, err
+	}
+	zs, err := b.rdb.ZRevRangeWithScores(ctx, key, offset, offset+limit-1).Result()
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]Entry, len(zs))
+	for i, z := range zs {
+		entries[i] = Entry{
+			Rank:   offset + int64(i) + 1,
+			UserID: fmt.Sprint(z.Member),
+			Score:  int64(z.Score),
+		}
+	}
+	return entries, nil
+}
+
+// Rank 返回用户的名次，未上榜时 ok 为 false
+func (b *Board) Rank(ctx context.Context, metric pointv1.LeaderboardMetric, period pointv1.LeaderboardPeriod, at time.Time, userID string) (Entry, bool, error) {
+	key, err := b.Key(metric, period, at)
+	if err != nil {
+		return Entry{}, false, err
+	}
+	var rankCmd *redis.IntCmd
+	var scoreCmd *redis.FloatCmd
+	_, err = b.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		rankCmd = pipe.ZRevRank(ctx, key, userID)
+		scoreCmd = pipe.ZScore(ctx, key, userID)
+		return nil
+	})
+	if errors.Is(err, redis.Nil) {
+		return Entry{UserID: userID}, false, nil
+	}
+	if err != nil {
+		return Entry{}, false, err
+	}
+	return Entry{Rank: rankCmd.Val() + 1, UserID: userID, Score: int64(scoreCmd.Val())}, true, nil
+}
+
+// Count 榜单上的人数
+func (b *Board) Count(ctx context.Context, metric pointv1.LeaderboardMetric, period pointv1.LeaderboardPeriod, at time.Time) (int64, error) {
+	key, err := b.Key(metric, period, at)
+	if err != nil {
+		return 0, err
+	}
+	return b.rdb.ZCard(ctx, key).Result()
+}
+
+// Remove 从某指标当前周期的所有榜单中移除用户（如封禁账号）
+func (b *Board) Remove(ctx context.Context, metric pointv1.LeaderboardMetric, userID string, at time.Time) error {
+	_, err := b.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, p := range periods {
+			key, err := b.Key(metric, p, at)
+			if err != nil {
+				return err
+			}
+			pipe.ZRem(ctx, key, userID)
+		}
+		return nil
+	})
+	return err
+}
+
+// Leaderboard 组装 GetLeaderboard 响应（不含用户名和等级），limit 默认 10、最大 100
+func (b *Board) Leaderboard(ctx context.Context, req *pointv1.GetLeaderboardRequest, at time.Time) (*pointv1.GetLeaderboardResponse, error) {
+	limit := int64(req.GetLimit())
+	if limit <= 0 {
+		limit = 10
+	}
+	limit = min(limit, 100)
+	entries, err := b.Top(ctx, req.GetMetric(), req.GetPeriod(), at, int64(max(req.GetOffset(), 0)), limit)
+	if err != nil {
+		return nil, err
+	}
+	total, err := b.Count(ctx, req.GetMetric(), req.GetPeriod(), at)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pointv1.GetLeaderboardResponse{Total: total}
+	for _, e := range entries {
+		resp.Entries = append(resp.Entries, e.ToProto())
+	}
+	userID, err := userref.StringOf(req.GetUser(), req.GetUserId())
+	if errors.Is(err, userref.ErrEmpty) {
+		return resp, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	self, _, err := b.Rank(ctx, req.GetMetric(), req.GetPeriod(), at, userID)
+	if err != nil {
+		return nil, err
+	}
+	resp.Self = self.ToProto()
+	return resp, nil
+}
+
+// UserRank 组装 GetUserRank 响应（不含用户名和等级）
+func (b *Board) UserRank(ctx context.Context, req *pointv1.GetUserRankRequest, at time.Time) (*pointv1.GetUserRankResponse, error) {
+	userID, err := userref.StringOf(req.GetUser(), req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	entry, _, err := b.Rank(ctx, req.GetMetric(), req.GetPeriod(), at, userID)
+	if err != nil {
+		return nil, err
+	}
+	total, err := b.Count(ctx, req.GetMetric(), req.GetPeriod(), at)
+	if err != nil {
+		return nil, err
+	}
+	return &pointv1.GetUserRankResponse{Entry: entry.ToProto(), Total: total}, nil
+}
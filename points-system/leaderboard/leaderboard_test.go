@@ -0,0 +1,177 @@
+package leaderboard
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/trancecho/mundo-proto-sdk/common/userref"
+	commonv1 "github.com/trancecho/mundo-proto-sdk/common/v1"
+	pointv1 "github.com/trancecho/mundo-proto-sdk/points-system/v1"
+)
+
+const (
+	points = pointv1.LeaderboardMetric_LEADERBOARD_METRIC_POINTS
+	daily  = pointv1.LeaderboardPeriod_LEADERBOARD_PERIOD_DAILY
+)
+
+var at = time.Date(2025, 10, 19, 12, 0, 0, 0, time.UTC)
+
+// newBoard 返回积分日榜为 1:30、2:20、3:10 的排行榜
+func newBoard(t *testing.T) *Board {
+	t.Helper()
+	b := New(redis.NewClient(&redis.Options{Addr: miniredis.RunT(t).Addr()}), "", time.UTC)
+	for uid, score := range map[string]int64{"1": 30, "2": 20, "3": 10} {
+		if err := b.Incr(context.Background(), points, uid, score, at); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return b
+}
+
+func TestKey(t *testing.T) {
+	b := New(nil, "", time.UTC)
+	tests := []struct {
+		name    string
+		metric  pointv1.LeaderboardMetric
+		period  pointv1.LeaderboardPeriod
+		want    string
+		wantErr error
+	}{
+		{"daily", points, daily, "leaderboard:points:daily:20251019", nil},
+		{"weekly", points, pointv1.LeaderboardPeriod_LEADERBOARD_PERIOD_WEEKLY, "leaderboard:points:weekly:2025W42", nil},
+		{"monthly", points, pointv1.LeaderboardPeriod_LEADERBOARD_PERIOD_MONTHLY, "leaderboard:points:monthly:202510", nil},
+		{"all time", points, pointv1.LeaderboardPeriod_LEADERBOARD_PERIOD_ALL_TIME, "leaderboard:points:all", nil},
+		{"unspecified period", points, pointv1.LeaderboardPeriod_LEADERBOARD_PERIOD_UNSPECIFIED, "leaderboard:points:all", nil},
+		{"sign streak", pointv1.LeaderboardMetric_LEADERBOARD_METRIC_SIGN_STREAK, daily, "leaderboard:sign_streak:daily:20251019", nil},
+		{"unspecified metric", pointv1.LeaderboardMetric_LEADERBOARD_METRIC_UNSPECIFIED, daily, "", ErrInvalidMetric},
+		{"unknown metric", pointv1.LeaderboardMetric(99), daily, "", ErrInvalidMetric},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := b.Key(tt.metric, tt.period, at)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("Key = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIncr(t *testing.T) {
+	allTime := pointv1.LeaderboardPeriod_LEADERBOARD_PERIOD_ALL_TIME
+	tests := []struct {
+		name      string
+		metric    pointv1.LeaderboardMetric
+		delta     int64
+		wantDaily int64
+		wantAll   int64
+		wantErr   error
+	}{
+		{"positive delta", points, 5, 35, 35, nil},
+		{"negative delta only on all time", points, -25, 30, 5, nil},
+		{"zero delta", points, 0, 30, 30, nil},
+		{"unspecified metric", pointv1.LeaderboardMetric_LEADERBOARD_METRIC_UNSPECIFIED, 5, 30, 30, ErrInvalidMetric},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newBoard(t)
+			if err := b.Incr(context.Background(), tt.metric, "1", tt.delta, at); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Incr err = %v, want %v", err, tt.wantErr)
+			}
+			for period, want := range map[pointv1.LeaderboardPeriod]int64{daily: tt.wantDaily, allTime: tt.wantAll} {
+				e, _, err := b.Rank(context.Background(), points, period, at, "1")
+				if err != nil {
+					t.Fatal(err)
+				}
+				if e.Score != want {
+					t.Fatalf("%v score = %d, want %d", period, e.Score, want)
+				}
+			}
+		})
+	}
+}
+
+func TestInvalidMetric(t *testing.T) {
+	b := newBoard(t)
+	unspecified := pointv1.LeaderboardMetric_LEADERBOARD_METRIC_UNSPECIFIED
+	if _, err := b.Leaderboard(context.Background(), &pointv1.GetLeaderboardRequest{Period: daily}, at); !errors.Is(err, ErrInvalidMetric) {
+		t.Fatalf("Leaderboard err = %v, want ErrInvalidMetric", err)
+	}
+	if _, err := b.UserRank(context.Background(), &pointv1.GetUserRankRequest{Period: daily, UserId: "1"}, at); !errors.Is(err, ErrInvalidMetric) {
+		t.Fatalf("UserRank err = %v, want ErrInvalidMetric", err)
+	}
+	if err := b.Set(context.Background(), unspecified, daily, "1", 3, at); !errors.Is(err, ErrInvalidMetric) {
+		t.Fatalf("Set err = %v, want ErrInvalidMetric", err)
+	}
+	if err := b.Remove(context.Background(), unspecified, "1", at); !errors.Is(err, ErrInvalidMetric) {
+		t.Fatalf("Remove err = %v, want ErrInvalidMetric", err)
+	}
+}
+
+func TestLeaderboardSelf(t *testing.T) {
+	b := newBoard(t)
+	tests := []struct {
+		name     string
+		userID   string
+		user     *commonv1.UserRef
+		wantSelf *pointv1.LeaderboardEntry
+	}{
+		{"no caller", "", nil, nil},
+		{"legacy user_id", "2", nil, &pointv1.LeaderboardEntry{Rank: 2, UserId: "2", Score: 20}},
+		{"user only", "", userref.FromInt64(3), &pointv1.LeaderboardEntry{Rank: 3, UserId: "3", Score: 10}},
+		{"user wins over user_id", "2", userref.FromInt64(1), &pointv1.LeaderboardEntry{Rank: 1, UserId: "1", Score: 30}},
+		{"not ranked", "9", nil, &pointv1.LeaderboardEntry{UserId: "9"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := b.Leaderboard(context.Background(), &pointv1.GetLeaderboardRequest{
+				Metric: points, Period: daily, Limit: 2, UserId: tt.userID, User: tt.user,
+			}, at)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.GetTotal() != 3 || len(resp.GetEntries()) != 2 || resp.GetEntries()[0].GetUserId() != "1" {
+				t.Fatalf("resp = %v", resp)
+			}
+			self := resp.GetSelf()
+			if (self == nil) != (tt.wantSelf == nil) ||
+				self.GetRank() != tt.wantSelf.GetRank() || self.GetUserId() != tt.wantSelf.GetUserId() || self.GetScore() != tt.wantSelf.GetScore() {
+				t.Fatalf("self = %v, want %v", self, tt.wantSelf)
+			}
+		})
+	}
+}
+
+func TestUserRank(t *testing.T) {
+	b := newBoard(t)
+	tests := []struct {
+		name     string
+		userID   string
+		user     *commonv1.UserRef
+		wantRank int64
+		wantErr  error
+	}{
+		{"legacy user_id", "2", nil, 2, nil},
+		{"user wins over user_id", "2", userref.FromInt64(1), 1, nil},
+		{"missing user", "", nil, 0, userref.ErrEmpty},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := b.UserRank(context.Background(), &pointv1.GetUserRankRequest{
+				Metric: points, Period: daily, UserId: tt.userID, User: tt.user,
+			}, at)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if resp.GetEntry().GetRank() != tt.wantRank {
+				t.Fatalf("rank = %d, want %d", resp.GetEntry().GetRank(), tt.wantRank)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package pointsclient
+
+import (
+	"context"
+	crand "crypto/rand"
+	"encoding/hex"
+	"math/rand/v2"
+	"sync/atomic"
+	"time"
+
+	pointv1 "github.com/trancecho/mundo-proto-sdk/points-system/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// RetryConfig 重试配置，零值字段使用默认值
+type RetryConfig struct {
+	MaxAttempts int           // 最大尝试次数（含首次），默认 3
+	Backoff     time.Duration // 首次重试等待时间，之后指数增长，默认 200ms
+	MaxBackoff  time.Duration // 最大等待时间，默认 2s
+}
+
+func (c *RetryConfig) setDefaults() {
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = 3
+	}
+	if c.Backoff <= 0 {
+		c.Backoff = 200 * time.Millisecond
+	}
+	if c.MaxBackoff <= 0 {
+		c.MaxBackoff = 2 * time.Second
+	}
+}
+
+// Client UserService 客户端封装：写操作自动生成幂等键，
+// 超时或服务不可用时使用同一个幂等键安全重试。
+type Client struct {
+	rpc   pointv1.UserServiceClient
+	retry RetryConfig
+
+	legacySignAt atomic.Int64 // 最近一次探测到服务端不支持 SignV2 的时间（UnixNano），0 表示未探测到
+}
+
+func New(rpc pointv1.UserServiceClient) *Client {
+	return NewWithRetry(rpc, RetryConfig{})
+}
+
+func NewWithRetry(rpc pointv1.UserServiceClient, cfg RetryConfig) *Client {
+	cfg.setDefaults()
+	return &Client{rpc: rpc, retry: cfg}
+}
+
+// RPC 返回底层的 UserServiceClient
+func (c *Client) RPC() pointv1.UserServiceClient {
+	return c.rpc
+}
+
+// NewIdempotencyKey 生成随机幂等键
+func NewIdempotencyKey() string {
+	var b [16]byte
+	_, _ = crand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// UpdatePointsAndExperience 更新积分和经验，未设置幂等键时自动生成
+func (c *Client) UpdatePointsAndExperience(ctx context.Context, req *pointv1.UpdatePointsRequest, opts ...grpc.CallOption) (*pointv1.CommonResponse, error) {
+	req = proto.Clone(req).(*pointv1.UpdatePointsRequest)
+	if req.IdempotencyKey == "" {
+		req.IdempotencyKey = NewIdempotencyKey()
+	}
+	return invoke(ctx, c, req, opts, c.rpc.UpdatePointsAndExperience)
+}
+
+// ProcessLike 处理点赞，未设置幂等键时自动生成
+func (c *Client) ProcessLike(ctx context.Context, req *pointv1.LikeRequest, opts ...grpc.CallOption) (*pointv1.CommonResponse, error) {
+	req = proto.Clone(req).(*pointv1.LikeRequest)
+	if req.IdempotencyKey == "" {
+		req.IdempotencyKey = NewIdempotencyKey()
+	}
+	return invoke(ctx, c, req, opts, c.rpc.ProcessLike)
+}
+
+// Sign 用户签到，未设置幂等键时自动生成
+//
+// Deprecated: 旧接口无法返回签到奖励，请使用 SignV2
+func (c *Client) Sign(ctx context.Context, req *pointv1.SignRequest, opts ...grpc.CallOption) (*pointv1.CommonResponse, error) {
+	req = proto.Clone(req).(*pointv1.SignRequest)
+	if req.IdempotencyKey == "" {
+		req.IdempotencyKey = NewIdempotencyKey()
+	}
+	return invoke(ctx, c, req, opts, c.rpc.Sign)
+}
+
+// invoke 调用 RPC，遇到可重试错误时按指数退避重试
+func invoke[Req, Resp any](ctx context.Context, c *Client, req Req, opts []grpc.CallOption,
+	call func(context.Context, Req, ...grpc.CallOption) (Resp, error)) (Resp, error) {
+	backoff := c.retry.Backoff
+	for attempt := 1; ; attempt++ {
+		resp, err := call(ctx, req, opts...)
+		if err == nil || attempt >= c.retry.MaxAttempts || !retryable(err) {
+			return resp, err
+		}
+		// 加入抖动，避免大量客户端同时重试
+		wait := backoff/2 + rand.N(backoff/2+1)
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return resp, err
+		case <-timer.C:
+		}
+		backoff = min(backoff*2, c.retry.MaxBackoff)
+	}
+}
+
+// retryable 只有结果不确定或明确未处理的错误才重试，服务端依靠幂等键去重
+func retryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Aborted:
+		return true
+	}
+	return false
+}
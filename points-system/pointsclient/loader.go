@@ -0,0 +1,200 @@
+package pointsclient
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	pointv1 "github.com/trancecho/mundo-proto-sdk/points-system/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// MaxBatchGetUserInfo BatchGetUserInfo 单次请求的最大 ID 数
+const MaxBatchGetUserInfo = 100
+
+var ErrUserNotFound = errors.New("pointsclient: user not found")
+
+// batchProbeInterval 旧服务端不支持 BatchGetUserInfo 时，改为逐个 GetUserInfo 的持续时间，之后重新探测
+const batchProbeInterval = 5 * time.Minute
+
+// LoaderConfig UserLoader 配置，零值字段使用默认值
+type LoaderConfig struct {
+	Wait       time.Duration // 收集请求的时间窗口，默认 2ms
+	MaxBatch   int           // 单批最大 ID 数，默认且最大为 MaxBatchGetUserInfo
+	RPCTimeout time.Duration // 批量 RPC 超时，默认 3s
+}
+
+func (c *LoaderConfig) setDefaults() {
+	if c.Wait <= 0 {
+		c.Wait = 2 * time.Millisecond
+	}
+	if c.MaxBatch <= 0 || c.MaxBatch > MaxBatchGetUserInfo {
+		c.MaxBatch = MaxBatchGetUserInfo
+	}
+	if c.RPCTimeout <= 0 {
+		c.RPCTimeout = 3 * time.Second
+	}
+}
+
+// UserLoader 合并并发的用户信息查询（dataloader 模式）：
+// 时间窗口内的 Load 调用被合并为一次 BatchGetUserInfo，批次满时立即发送。
+// 批量 RPC 不受单个调用方的取消影响，但携带批次中第一个调用方 ctx 的 outgoing metadata（如鉴权、trace 信息）。
+// 服务端未实现 BatchGetUserInfo 时退化为并发的逐个 GetUserInfo。
+// 不做跨批次缓存，适合在单个请求的渲染过程中共享。
+type UserLoader struct {
+	rpc pointv1.UserServiceClient
+	cfg LoaderConfig
+
+	mu      sync.Mutex
+	pending *loadBatch
+
+	legacyBatchAt atomic.Int64 // 最近一次发现不支持 BatchGetUserInfo 的时间（UnixNano），0 表示支持
+}
+
+type loadBatch struct {
+	md    metadata.MD
+	ids   []string
+	seen  map[string]bool
+	done  chan struct{}
+	users map[string]*pointv1.UserInfo
+	errs  map[string]error // 逐个查询时单个用户的错误
+	err   error
+}
+
+// result 返回批次中某个用户的查询结果
+func (b *loadBatch) result(userID string) (*pointv1.UserInfo, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if err := b.errs[userID]; err != nil {
+		return nil, err
+	}
+	u, ok := b.users[userID]
+	if !ok {
+		return nil, ErrUserNotFound
+	}
+	return u, nil
+}
+
+func NewUserLoader(rpc pointv1.UserServiceClient, cfg LoaderConfig) *UserLoader {
+	cfg.setDefaults()
+	return &UserLoader{rpc: rpc, cfg: cfg}
+}
+
+// Load 获取单个用户信息，用户不存在时返回 ErrUserNotFound
+func (l *UserLoader) Load(ctx context.Context, userID string) (*pointv1.UserInfo, error) {
+	b := l.enqueue(ctx, userID)
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-b.done:
+	}
+	return b.result(userID)
+}
+
+// LoadMany 获取多个用户信息，不存在的用户不出现在结果中
+func (l *UserLoader) LoadMany(ctx context.Context, userIDs []string) (map[string]*pointv1.UserInfo, error) {
+	byID := make(map[string]*loadBatch, len(userIDs))
+	for _, id := range userIDs {
+		byID[id] = l.enqueue(ctx, id)
+	}
+	out := make(map[string]*pointv1.UserInfo, len(byID))
+	for id, b := range byID {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-b.done:
+		}
+		u, err := b.result(id)
+		if errors.Is(err, ErrUserNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		out[id] = u
+	}
+	return out, nil
+}
+
+// enqueue 将 ID 加入当前批次，返回该 ID 所在的批次
+func (l *UserLoader) enqueue(ctx context.Context, userID string) *loadBatch {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.pending != nil && l.pending.seen[userID] {
+		return l.pending
+	}
+	if l.pending == nil {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		b := &loadBatch{md: md.Copy(), seen: make(map[string]bool), done: make(chan struct{})}
+		l.pending = b
+		time.AfterFunc(l.cfg.Wait, func() { l.dispatch(b) })
+	}
+	b := l.pending
+	b.ids = append(b.ids, userID)
+	b.seen[userID] = true
+	if len(b.ids) >= l.cfg.MaxBatch {
+		l.pending = nil
+		go l.fetch(b)
+	}
+	return b
+}
+
+// dispatch 时间窗口到期，发送仍在等待的批次
+func (l *UserLoader) dispatch(b *loadBatch) {
+	l.mu.Lock()
+	if l.pending != b {
+		// 已因批次满提前发送
+		l.mu.Unlock()
+		return
+	}
+	l.pending = nil
+	l.mu.Unlock()
+	l.fetch(b)
+}
+
+func (l *UserLoader) fetch(b *loadBatch) {
+	defer close(b.done)
+	ctx, cancel := context.WithTimeout(metadata.NewOutgoingContext(context.Background(), b.md), l.cfg.RPCTimeout)
+	defer cancel()
+	if at := l.legacyBatchAt.Load(); at == 0 || time.Since(time.Unix(0, at)) >= batchProbeInterval {
+		resp, err := l.rpc.BatchGetUserInfo(ctx, &pointv1.BatchGetUserInfoRequest{UserIds: b.ids})
+		if status.Code(err) != codes.Unimplemented {
+			if err == nil {
+				l.legacyBatchAt.Store(0)
+			}
+			b.users, b.err = resp.GetUsers(), err
+			return
+		}
+		l.legacyBatchAt.Store(time.Now().UnixNano())
+	}
+	l.fetchEach(ctx, b)
+}
+
+// fetchEach 逐个并发调用 GetUserInfo，NotFound 视为用户不存在，其余错误只影响对应用户
+func (l *UserLoader) fetchEach(ctx context.Context, b *loadBatch) {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	b.users = make(map[string]*pointv1.UserInfo, len(b.ids))
+	b.errs = make(map[string]error)
+	for _, id := range b.ids {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			u, err := l.rpc.GetUserInfo(ctx, &pointv1.GetUserInfoRequest{UserId: id})
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				b.users[id] = u
+			case status.Code(err) != codes.NotFound:
+				b.errs[id] = err
+			}
+		}()
+	}
+	wg.Wait()
+}
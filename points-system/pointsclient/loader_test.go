@@ -0,0 +1,223 @@
+package pointsclient
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	pointv1 "github.com/trancecho/mundo-proto-sdk/points-system/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// loaderRPC 模拟服务端：users 中的用户存在，errs 中的用户查询失败；
+// legacy 为 true 时 BatchGetUserInfo 返回 Unimplemented，block 非空时请求阻塞到其关闭
+type loaderRPC struct {
+	pointv1.UserServiceClient
+	users  map[string]int64
+	errs   map[string]error
+	legacy bool
+	block  chan struct{}
+
+	mu      sync.Mutex
+	batches [][]string
+	singles []string
+	tokens  []string // 每次请求 outgoing metadata 中的 authorization
+}
+
+func (r *loaderRPC) record(ctx context.Context) {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	r.tokens = append(r.tokens, md.Get("authorization")...)
+}
+
+func (r *loaderRPC) BatchGetUserInfo(ctx context.Context, req *pointv1.BatchGetUserInfoRequest, _ ...grpc.CallOption) (*pointv1.BatchGetUserInfoResponse, error) {
+	if r.block != nil {
+		<-r.block
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.record(ctx)
+	if r.legacy {
+		return nil, status.Error(codes.Unimplemented, "unknown method BatchGetUserInfo")
+	}
+	ids := slices.Clone(req.GetUserIds())
+	slices.Sort(ids)
+	r.batches = append(r.batches, ids)
+	resp := &pointv1.BatchGetUserInfoResponse{Users: make(map[string]*pointv1.UserInfo)}
+	for _, id := range req.GetUserIds() {
+		if p, ok := r.users[id]; ok {
+			resp.Users[id] = &pointv1.UserInfo{Points: p}
+		}
+	}
+	return resp, nil
+}
+
+func (r *loaderRPC) GetUserInfo(ctx context.Context, req *pointv1.GetUserInfoRequest, _ ...grpc.CallOption) (*pointv1.UserInfo, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.record(ctx)
+	r.singles = append(r.singles, req.GetUserId())
+	if err := r.errs[req.GetUserId()]; err != nil {
+		return nil, err
+	}
+	p, ok := r.users[req.GetUserId()]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	return &pointv1.UserInfo{Points: p}, nil
+}
+
+func pointsOf(users map[string]*pointv1.UserInfo) map[string]int64 {
+	out := make(map[string]int64, len(users))
+	for id, u := range users {
+		out[id] = u.GetPoints()
+	}
+	return out
+}
+
+func TestLoaderCoalesces(t *testing.T) {
+	rpc := &loaderRPC{users: map[string]int64{"1": 10, "2": 20, "3": 30}}
+	l := NewUserLoader(rpc, LoaderConfig{Wait: 20 * time.Millisecond})
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer t1")
+
+	var wg sync.WaitGroup
+	got := make(map[string]int64)
+	var mu sync.Mutex
+	for _, id := range []string{"1", "2", "2", "3", "9"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			u, err := l.Load(ctx, id)
+			if id == "9" {
+				if !errors.Is(err, ErrUserNotFound) {
+					t.Errorf("Load(9) err = %v, want ErrUserNotFound", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Load(%s) err = %v", id, err)
+				return
+			}
+			mu.Lock()
+			got[id] = u.GetPoints()
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	if want := map[string]int64{"1": 10, "2": 20, "3": 30}; !maps.Equal(got, want) {
+		t.Fatalf("loaded %v, want %v", got, want)
+	}
+	if want := [][]string{{"1", "2", "3", "9"}}; !slices.EqualFunc(rpc.batches, want, slices.Equal) {
+		t.Fatalf("batches = %v, want %v", rpc.batches, want)
+	}
+	if !slices.Equal(rpc.tokens, []string{"Bearer t1"}) {
+		t.Fatalf("forwarded metadata = %v", rpc.tokens)
+	}
+}
+
+func TestLoaderMaxBatch(t *testing.T) {
+	rpc := &loaderRPC{users: map[string]int64{"1": 1, "2": 2, "3": 3, "4": 4, "5": 5}}
+	l := NewUserLoader(rpc, LoaderConfig{Wait: 20 * time.Millisecond, MaxBatch: 2})
+	got, err := l.LoadMany(context.Background(), []string{"1", "2", "3", "4", "5", "6"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]int64{"1": 1, "2": 2, "3": 3, "4": 4, "5": 5}; !maps.Equal(pointsOf(got), want) {
+		t.Fatalf("LoadMany = %v, want %v", pointsOf(got), want)
+	}
+	if len(rpc.batches) != 3 {
+		t.Fatalf("batches = %v, want 3 batches of at most 2", rpc.batches)
+	}
+	for _, b := range rpc.batches {
+		if len(b) > 2 {
+			t.Fatalf("batch %v exceeds MaxBatch", b)
+		}
+	}
+}
+
+func TestLoaderUnimplementedFallback(t *testing.T) {
+	errDown := status.Error(codes.Unavailable, "shard down")
+	rpc := &loaderRPC{
+		users:  map[string]int64{"1": 10, "2": 20},
+		errs:   map[string]error{"3": errDown},
+		legacy: true,
+	}
+	l := NewUserLoader(rpc, LoaderConfig{})
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer t1")
+
+	tests := []struct {
+		id      string
+		want    int64
+		wantErr error
+	}{
+		{"1", 10, nil},
+		{"3", 0, errDown},
+		{"9", 0, ErrUserNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			u, err := l.Load(ctx, tt.id)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if u.GetPoints() != tt.want {
+				t.Fatalf("points = %d, want %d", u.GetPoints(), tt.want)
+			}
+		})
+	}
+	// 只有第一批探测 BatchGetUserInfo，之后直接逐个查询
+	if got := len(rpc.tokens) - len(rpc.singles); got != 1 {
+		t.Fatalf("BatchGetUserInfo probed %d times, want 1", got)
+	}
+	for _, tok := range rpc.tokens {
+		if tok != "Bearer t1" {
+			t.Fatalf("forwarded metadata = %v", rpc.tokens)
+		}
+	}
+
+	if _, err := l.LoadMany(ctx, []string{"1", "3"}); !errors.Is(err, errDown) {
+		t.Fatalf("LoadMany err = %v, want %v", err, errDown)
+	}
+	got, err := l.LoadMany(ctx, []string{"1", "2", "9"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]int64{"1": 10, "2": 20}; !maps.Equal(pointsOf(got), want) {
+		t.Fatalf("LoadMany = %v, want %v", pointsOf(got), want)
+	}
+}
+
+func TestLoaderCancel(t *testing.T) {
+	rpc := &loaderRPC{users: map[string]int64{"1": 10}, block: make(chan struct{})}
+	defer close(rpc.block)
+	l := NewUserLoader(rpc, LoaderConfig{})
+
+	tests := []struct {
+		name string
+		load func(ctx context.Context) error
+	}{
+		{"Load", func(ctx context.Context) error {
+			_, err := l.Load(ctx, "1")
+			return err
+		}},
+		{"LoadMany", func(ctx context.Context) error {
+			_, err := l.LoadMany(ctx, []string{"1", "2"})
+			return err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+			if err := tt.load(ctx); !errors.Is(err, context.DeadlineExceeded) {
+				t.Fatalf("err = %v, want context.DeadlineExceeded", err)
+			}
+		})
+	}
+}
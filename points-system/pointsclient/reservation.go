@@ -0,0 +1,125 @@
+package pointsclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	pointv1 "github.com/trancecho/mundo-proto-sdk/points-system/v1"
+)
+
+var (
+	ErrInsufficientPoints = errors.New("pointsclient: points insufficient")
+	ErrReservationExpired = errors.New("pointsclient: reservation expired")
+	// ErrCommitFailed Spend 中 fn 已成功但确认扣除最终失败，预留到期后积分会退回
+	ErrCommitFailed = errors.New("pointsclient: commit reservation failed")
+)
+
+// ResponseError 服务端返回 success=false 时的业务错误
+type ResponseError struct {
+	Code    pointv1.ErrorCode
+	Message string
+}
+
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("pointsclient: %s: %s", e.Code, e.Message)
+}
+
+func (e *ResponseError) Is(target error) bool {
+	switch target {
+	case ErrInsufficientPoints:
+		return e.Code == pointv1.ErrorCode_POINTS_INSUFFICIENT
+	case ErrReservationExpired:
+		return e.Code == pointv1.ErrorCode_RESERVATION_EXPIRED
+	}
+	return false
+}
+
+// Reserve 预留积分，积分不足时返回的错误满足 errors.Is(err, ErrInsufficientPoints)。
+// ttl 按秒向上取整，不足 1 秒按 1 秒计；ttl <= 0 时使用服务端默认值。
+func (c *Client) Reserve(ctx context.Context, userID string, points int64, reason string, ttl time.Duration) (*pointv1.Reservation, error) {
+	resp, err := invoke(ctx, c, &pointv1.ReservePointsRequest{
+		UserId:         userID,
+		Points:         points,
+		Reason:         reason,
+		TtlSeconds:     ttlSeconds(ttl),
+		IdempotencyKey: NewIdempotencyKey(),
+	}, nil, c.rpc.ReservePoints)
+	if err != nil {
+		return nil, err
+	}
+	if !resp.GetSuccess() {
+		return nil, &ResponseError{Code: resp.GetErrorCode(), Message: resp.GetMessage()}
+	}
+	return resp.GetReservation(), nil
+}
+
+// Commit 确认扣除预留的积分，幂等键由预留 ID 派生，可安全重复调用
+func (c *Client) Commit(ctx context.Context, r *pointv1.Reservation) error {
+	resp, err := invoke(ctx, c, &pointv1.CommitReservationRequest{
+		ReservationId:  r.GetId(),
+		UserId:         r.GetUserId(),
+		IdempotencyKey: "commit:" + r.GetId(),
+	}, nil, c.rpc.CommitReservation)
+	return reservationError(resp, err)
+}
+
+// Cancel 取消预留，积分退回可用余额，可安全重复调用
+func (c *Client) Cancel(ctx context.Context, r *pointv1.Reservation, reason string) error {
+	resp, err := invoke(ctx, c, &pointv1.CancelReservationRequest{
+		ReservationId:  r.GetId(),
+		UserId:         r.GetUserId(),
+		Reason:         reason,
+		IdempotencyKey: "cancel:" + r.GetId(),
+	}, nil, c.rpc.CancelReservation)
+	return reservationError(resp, err)
+}
+
+// Spend 两阶段消费积分：先预留，执行 fn（如发放商品），成功则确认扣除，失败则取消预留。
+// ttl 应大于 fn 的最长执行时间，否则预留可能在确认前过期。
+// fn 成功但确认最终失败（如预留已过期）时返回满足 errors.Is(err, ErrCommitFailed) 的错误：
+// 此时 fn 的效果已生效而积分未扣除（预留到期后服务端自动退回），调用方应撤销 fn 的效果或另行补扣。
+func (c *Client) Spend(ctx context.Context, userID string, points int64, reason string, ttl time.Duration,
+	fn func(ctx context.Context, r *pointv1.Reservation) error) error {
+	r, err := c.Reserve(ctx, userID, points, reason, ttl)
+	if err != nil {
+		return err
+	}
+	if err := fn(ctx, r); err != nil {
+		// 调用方 ctx 可能已取消，取消预留使用独立的超时
+		cctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+		defer cancel()
+		if cerr := c.Cancel(cctx, r, err.Error()); cerr != nil {
+			log.Println("pointsclient: cancel reservation error, will expire at", r.GetExpiresAt().AsTime(), r.GetId(), cerr)
+		}
+		return err
+	}
+	// fn 可能耗时较长，调用方 ctx 已取消时仍需尝试确认
+	cctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+	if err := c.Commit(cctx, r); err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrCommitFailed, r.GetId(), err)
+	}
+	return nil
+}
+
+// ttlSeconds 将 ttl 向上取整为秒，避免不足 1 秒的 ttl 被截断为 0 而使用服务端默认值
+func ttlSeconds(ttl time.Duration) int32 {
+	if ttl <= 0 {
+		return 0
+	}
+	return int32(min((ttl+time.Second-1)/time.Second, math.MaxInt32))
+}
+
+func reservationError(resp *pointv1.ReservationResponse, err error) error {
+	if err != nil {
+		return err
+	}
+	if !resp.GetSuccess() {
+		return &ResponseError{Code: resp.GetErrorCode(), Message: resp.GetMessage()}
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package pointsclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	pointv1 "github.com/trancecho/mundo-proto-sdk/points-system/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// reserveRPC 模拟预留接口，commitCode 非零时确认返回对应业务错误
+type reserveRPC struct {
+	pointv1.UserServiceClient
+	insufficient bool
+	commitCode   pointv1.ErrorCode
+	commitErr    error
+	ttl          int32
+	committed    bool
+	cancelled    bool
+}
+
+func (s *reserveRPC) ReservePoints(_ context.Context, in *pointv1.ReservePointsRequest, _ ...grpc.CallOption) (*pointv1.ReservePointsResponse, error) {
+	s.ttl = in.GetTtlSeconds()
+	if s.insufficient {
+		return &pointv1.ReservePointsResponse{ErrorCode: pointv1.ErrorCode_POINTS_INSUFFICIENT, Message: "not enough"}, nil
+	}
+	return &pointv1.ReservePointsResponse{Success: true, Reservation: &pointv1.Reservation{Id: "r1", UserId: in.GetUserId()}}, nil
+}
+
+func (s *reserveRPC) CommitReservation(context.Context, *pointv1.CommitReservationRequest, ...grpc.CallOption) (*pointv1.ReservationResponse, error) {
+	if s.commitErr != nil {
+		return nil, s.commitErr
+	}
+	if s.commitCode != 0 {
+		return &pointv1.ReservationResponse{ErrorCode: s.commitCode, Message: "expired"}, nil
+	}
+	s.committed = true
+	return &pointv1.ReservationResponse{Success: true}, nil
+}
+
+func (s *reserveRPC) CancelReservation(context.Context, *pointv1.CancelReservationRequest, ...grpc.CallOption) (*pointv1.ReservationResponse, error) {
+	s.cancelled = true
+	return &pointv1.ReservationResponse{Success: true}, nil
+}
+
+func TestSpend(t *testing.T) {
+	errFn := errors.New("deliver failed")
+	tests := []struct {
+		name          string
+		rpc           *reserveRPC
+		fnErr         error
+		wantErr       []error
+		wantCommitted bool
+		wantCancelled bool
+	}{
+		{"ok", &reserveRPC{}, nil, nil, true, false},
+		{"insufficient", &reserveRPC{insufficient: true}, nil, []error{ErrInsufficientPoints}, false, false},
+		{"fn fails cancels", &reserveRPC{}, errFn, []error{errFn}, false, true},
+		{"commit expired", &reserveRPC{commitCode: pointv1.ErrorCode_RESERVATION_EXPIRED}, nil,
+			[]error{ErrCommitFailed, ErrReservationExpired}, false, false},
+		{"commit rpc error", &reserveRPC{commitErr: status.Error(codes.Internal, "boom")}, nil,
+			[]error{ErrCommitFailed}, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewWithRetry(tt.rpc, RetryConfig{MaxAttempts: 1})
+			called := false
+			err := c.Spend(context.Background(), "1", 10, "兑换商品", time.Minute,
+				func(context.Context, *pointv1.Reservation) error {
+					called = true
+					return tt.fnErr
+				})
+			for _, want := range tt.wantErr {
+				if !errors.Is(err, want) {
+					t.Fatalf("err = %v, want %v", err, want)
+				}
+			}
+			if len(tt.wantErr) == 0 && err != nil {
+				t.Fatalf("err = %v", err)
+			}
+			if tt.rpc.insufficient && called {
+				t.Fatal("fn called without reservation")
+			}
+			if errors.Is(err, ErrCommitFailed) && errors.Is(err, errFn) {
+				t.Fatal("fn error reported as commit failure")
+			}
+			if tt.rpc.committed != tt.wantCommitted || tt.rpc.cancelled != tt.wantCancelled {
+				t.Fatalf("committed = %v, cancelled = %v", tt.rpc.committed, tt.rpc.cancelled)
+			}
+		})
+	}
+}
+
+func TestReserveTTL(t *testing.T) {
+	tests := []struct {
+		ttl  time.Duration
+		want int32
+	}{
+		{0, 0},
+		{-time.Second, 0},
+		{500 * time.Millisecond, 1},
+		{time.Second, 1},
+		{1500 * time.Millisecond, 2},
+		{time.Minute, 60},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ttl.String(), func(t *testing.T) {
+			rpc := &reserveRPC{}
+			if _, err := New(rpc).Reserve(context.Background(), "1", 10, "x", tt.ttl); err != nil {
+				t.Fatal(err)
+			}
+			if rpc.ttl != tt.want {
+				t.Fatalf("TtlSeconds = %d, want %d", rpc.ttl, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package pointsclient
+
+import (
+	"context"
+	"time"
+
+	pointv1 "github.com/trancecho/mundo-proto-sdk/points-system/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// signProbeInterval 服务端不支持 SignV2 时，直接走旧接口的时长
+const signProbeInterval = 5 * time.Minute
+
+// SignV2 用户签到并返回本次奖励，兼容尚未升级的服务端：
+// 服务端返回 Unimplemented 时改用旧的 Sign，并通过签到前后的 GetUserInfo 推算奖励
+// （并发变更积分时推算值可能不准确）。探测结果在 signProbeInterval 内有效，
+// 过期后重新尝试 SignV2，服务端升级后无需重启客户端即可切换到新接口。
+func (c *Client) SignV2(ctx context.Context, req *pointv1.SignRequest, opts ...grpc.CallOption) (*pointv1.SignResponse, error) {
+	req = proto.Clone(req).(*pointv1.SignRequest)
+	if req.IdempotencyKey == "" {
+		req.IdempotencyKey = NewIdempotencyKey()
+	}
+	if at := c.legacySignAt.Load(); at == 0 || time.Since(time.Unix(0, at)) >= signProbeInterval {
+		resp, err := invoke(ctx, c, req, opts, c.rpc.SignV2)
+		if status.Code(err) != codes.Unimplemented {
+			if at != 0 {
+				c.legacySignAt.CompareAndSwap(at, 0)
+			}
+			return resp, err
+		}
+		c.legacySignAt.Store(time.Now().UnixNano())
+	}
+	return c.legacySignV2(ctx, req, opts)
+}
+
+func (c *Client) legacySignV2(ctx context.Context, req *pointv1.SignRequest, opts []grpc.CallOption) (*pointv1.SignResponse, error) {
+	infoReq := &pointv1.GetUserInfoRequest{UserId: req.GetUserId(), User: req.GetUser()}
+	before, err := c.rpc.GetUserInfo(ctx, infoReq, opts...)
+	if err != nil {
+		return nil, err
+	}
+	common, err := invoke(ctx, c, req, opts, c.rpc.Sign) //nolint:staticcheck // 兼容旧服务端
+	if err != nil {
+		return nil, err
+	}
+	resp := &pointv1.SignResponse{
+		Success:            common.GetSuccess(),
+		Message:            common.GetMessage(),
+		ErrorCode:          common.GetErrorCode(),
+		ContinuousSignDays: before.GetContinuousSignDays(),
+	}
+	if !common.GetSuccess() {
+		return resp, nil
+	}
+	after, err := c.rpc.GetUserInfo(ctx, infoReq, opts...)
+	if err != nil {
+		// 签到已成功，只是无法推算奖励
+		return resp, nil
+	}
+	resp.Points = after.GetPoints() - before.GetPoints()
+	resp.Experience = after.GetExperience() - before.GetExperience()
+	resp.ContinuousSignDays = after.GetContinuousSignDays()
+	return resp, nil
+}
@@ -0,0 +1,111 @@
+package pointsclient
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/trancecho/mundo-proto-sdk/common/userref"
+	commonv1 "github.com/trancecho/mundo-proto-sdk/common/v1"
+	pointv1 "github.com/trancecho/mundo-proto-sdk/points-system/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// signRPC 模拟服务端，supportV2 为 false 时 SignV2 返回 Unimplemented
+type signRPC struct {
+	pointv1.UserServiceClient
+	supportV2 bool
+	v2Calls   int
+	signCalls int
+	points    int64
+}
+
+func (s *signRPC) SignV2(context.Context, *pointv1.SignRequest, ...grpc.CallOption) (*pointv1.SignResponse, error) {
+	s.v2Calls++
+	if !s.supportV2 {
+		return nil, status.Error(codes.Unimplemented, "unknown method SignV2")
+	}
+	s.points += 10
+	return &pointv1.SignResponse{Success: true, Points: 10}, nil
+}
+
+func (s *signRPC) Sign(context.Context, *pointv1.SignRequest, ...grpc.CallOption) (*pointv1.CommonResponse, error) {
+	s.signCalls++
+	s.points += 10
+	return &pointv1.CommonResponse{Success: true}, nil
+}
+
+func (s *signRPC) GetUserInfo(_ context.Context, req *pointv1.GetUserInfoRequest, _ ...grpc.CallOption) (*pointv1.UserInfo, error) {
+	if req.GetUserId() == "" && req.GetUser().GetUid() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "missing user")
+	}
+	return &pointv1.UserInfo{Points: s.points}, nil
+}
+
+func TestSignV2Probe(t *testing.T) {
+	tests := []struct {
+		name          string
+		supportV2     bool
+		probedAgo     time.Duration // 0 表示从未探测到不支持
+		wantV2Calls   int
+		wantSignCalls int
+		wantLegacy    bool // 调用后是否仍记录为不支持
+	}{
+		{"v2 supported", true, 0, 1, 0, false},
+		{"v2 unimplemented falls back", false, 0, 1, 1, true},
+		{"within interval skips probe", true, time.Minute, 0, 1, true},
+		{"after interval reprobes upgraded server", true, 2 * signProbeInterval, 1, 0, false},
+		{"after interval still unimplemented", false, 2 * signProbeInterval, 1, 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rpc := &signRPC{supportV2: tt.supportV2}
+			c := New(rpc)
+			if tt.probedAgo > 0 {
+				c.legacySignAt.Store(time.Now().Add(-tt.probedAgo).UnixNano())
+			}
+			resp, err := c.SignV2(context.Background(), &pointv1.SignRequest{UserId: "1"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !resp.GetSuccess() || resp.GetPoints() != 10 {
+				t.Fatalf("resp = %+v", resp)
+			}
+			if rpc.v2Calls != tt.wantV2Calls || rpc.signCalls != tt.wantSignCalls {
+				t.Fatalf("calls: SignV2 = %d, Sign = %d; want %d, %d", rpc.v2Calls, rpc.signCalls, tt.wantV2Calls, tt.wantSignCalls)
+			}
+			at := c.legacySignAt.Load()
+			if (at != 0) != tt.wantLegacy {
+				t.Fatalf("legacySignAt = %d, want legacy %v", at, tt.wantLegacy)
+			}
+			if tt.wantLegacy && time.Since(time.Unix(0, at)) >= signProbeInterval {
+				t.Fatalf("legacySignAt not refreshed: %v ago", time.Since(time.Unix(0, at)))
+			}
+		})
+	}
+}
+
+func TestLegacySignV2User(t *testing.T) {
+	tests := []struct {
+		name   string
+		userID string
+		user   *commonv1.UserRef
+	}{
+		{"legacy user_id", "1", nil},
+		{"user only", "", userref.FromInt64(1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rpc := &signRPC{}
+			resp, err := New(rpc).SignV2(context.Background(), &pointv1.SignRequest{UserId: tt.userID, User: tt.user})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if rpc.signCalls != 1 || !resp.GetSuccess() || resp.GetPoints() != 10 {
+				t.Fatalf("Sign calls = %d, resp = %+v", rpc.signCalls, resp)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package pointsclient
+
+import (
+	"context"
+	"iter"
+	"time"
+
+	"github.com/trancecho/mundo-proto-sdk/common/paging"
+	pointv1 "github.com/trancecho/mundo-proto-sdk/points-system/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// TransactionFilter 积分流水过滤条件
+type TransactionFilter struct {
+	Reasons  []string  // 为空表示全部
+	Start    time.Time // 零值表示不限
+	End      time.Time // 零值表示不限
+	PageSize int32     // 每页数量，0 使用服务端默认值
+}
+
+func (f TransactionFilter) request(userID string) *pointv1.ListPointTransactionsRequest {
+	req := &pointv1.ListPointTransactionsRequest{
+		UserId:   userID,
+		Reasons:  f.Reasons,
+		PageSize: f.PageSize,
+	}
+	if !f.Start.IsZero() {
+		req.StartTime = timestamppb.New(f.Start)
+	}
+	if !f.End.IsZero() {
+		req.EndTime = timestamppb.New(f.End)
+	}
+	return req
+}
+
+// ListTransactions 查询一页积分流水，pageToken 为空表示首页
+func (c *Client) ListTransactions(ctx context.Context, userID string, f TransactionFilter, pageToken string) ([]*pointv1.PointTransaction, string, error) {
+	req := f.request(userID)
+	req.PageToken = pageToken
+	for resp, err := range paging.Pages(ctx, c.rpc.ListPointTransactions, req) {
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.GetTransactions(), resp.GetNextPageToken(), nil
+	}
+	return nil, "", nil
+}
+
+// Transactions 按时间倒序遍历用户的全部积分流水，自动翻页；出错时产出一次错误后结束
+func (c *Client) Transactions(ctx context.Context, userID string, f TransactionFilter) iter.Seq2[*pointv1.PointTransaction, error] {
+	return paging.All(ctx, c.rpc.ListPointTransactions, f.request(userID), (*pointv1.ListPointTransactionsResponse).GetTransactions)
+}
+
+// SumByReason 汇总时间范围内各变更原因的积分变化量，常用于展示"积分从哪来"
+func (c *Client) SumByReason(ctx context.Context, userID string, f TransactionFilter) (map[string]int64, error) {
+	sums := make(map[string]int64)
+	for tx, err := range c.Transactions(ctx, userID, f) {
+		if err != nil {
+			return nil, err
+		}
+		sums[tx.GetReason()] += tx.GetDeltaPoints()
+	}
+	return sums, nil
+}
@@ -0,0 +1,133 @@
+package pointsclient
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"testing"
+
+	pointv1 "github.com/trancecho/mundo-proto-sdk/points-system/v1"
+	"google.golang.org/grpc"
+)
+
+// txPage 一页流水，err 非空时该页请求失败
+type txPage struct {
+	txs  []*pointv1.PointTransaction
+	next string
+	err  error
+}
+
+// txRPC 按 page_token 返回预置的页，并记录请求过的游标
+type txRPC struct {
+	pointv1.UserServiceClient
+	pages  map[string]txPage
+	tokens []string
+}
+
+func (r *txRPC) ListPointTransactions(_ context.Context, req *pointv1.ListPointTransactionsRequest, _ ...grpc.CallOption) (*pointv1.ListPointTransactionsResponse, error) {
+	r.tokens = append(r.tokens, req.GetPageToken())
+	p := r.pages[req.GetPageToken()]
+	if p.err != nil {
+		return nil, p.err
+	}
+	return &pointv1.ListPointTransactionsResponse{Transactions: p.txs, NextPageToken: p.next}, nil
+}
+
+func tx(id, reason string, delta int64) *pointv1.PointTransaction {
+	return &pointv1.PointTransaction{Id: id, Reason: reason, DeltaPoints: delta}
+}
+
+var errPage = errors.New("page failed")
+
+func threePages() map[string]txPage {
+	return map[string]txPage{
+		"":   {txs: []*pointv1.PointTransaction{tx("1", "sign", 10), tx("2", "post", 5)}, next: "p2"},
+		"p2": {txs: []*pointv1.PointTransaction{tx("3", "sign", 10)}, next: "p3"},
+		"p3": {txs: []*pointv1.PointTransaction{tx("4", "shop", -30)}},
+	}
+}
+
+func TestTransactions(t *testing.T) {
+	failing := threePages()
+	failing["p2"] = txPage{err: errPage}
+	tests := []struct {
+		name       string
+		pages      map[string]txPage
+		limit      int // > 0 时读到 limit 条后 break
+		wantIDs    []string
+		wantTokens []string
+		wantErr    error
+	}{
+		{"all pages", threePages(), 0, []string{"1", "2", "3", "4"}, []string{"", "p2", "p3"}, nil},
+		{"early stop", threePages(), 2, []string{"1", "2"}, []string{""}, nil},
+		{"stop on second page", threePages(), 3, []string{"1", "2", "3"}, []string{"", "p2"}, nil},
+		{"error on second page", failing, 0, []string{"1", "2"}, []string{"", "p2"}, errPage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rpc := &txRPC{pages: tt.pages}
+			var ids []string
+			var err error
+			for tx, e := range New(rpc).Transactions(context.Background(), "u1", TransactionFilter{}) {
+				if e != nil {
+					err = e
+					break
+				}
+				ids = append(ids, tx.GetId())
+				if len(ids) == tt.limit {
+					break
+				}
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if !slices.Equal(ids, tt.wantIDs) {
+				t.Fatalf("ids = %v, want %v", ids, tt.wantIDs)
+			}
+			if !slices.Equal(rpc.tokens, tt.wantTokens) {
+				t.Fatalf("requested tokens = %q, want %q", rpc.tokens, tt.wantTokens)
+			}
+		})
+	}
+}
+
+func TestListTransactions(t *testing.T) {
+	rpc := &txRPC{pages: threePages()}
+	txs, next, err := New(rpc).ListTransactions(context.Background(), "u1", TransactionFilter{}, "p2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(txs) != 1 || txs[0].GetId() != "3" || next != "p3" {
+		t.Fatalf("ListTransactions = %v, %q", txs, next)
+	}
+	if !slices.Equal(rpc.tokens, []string{"p2"}) {
+		t.Fatalf("requested tokens = %q, want only p2", rpc.tokens)
+	}
+}
+
+func TestSumByReason(t *testing.T) {
+	failing := threePages()
+	failing["p3"] = txPage{err: errPage}
+	tests := []struct {
+		name    string
+		pages   map[string]txPage
+		want    map[string]int64
+		wantErr error
+	}{
+		{"sums across pages", threePages(), map[string]int64{"sign": 20, "post": 5, "shop": -30}, nil},
+		{"no transactions", map[string]txPage{}, map[string]int64{}, nil},
+		{"error discards partial sums", failing, nil, errPage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New(&txRPC{pages: tt.pages}).SumByReason(context.Background(), "u1", TransactionFilter{})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if !maps.Equal(got, tt.want) {
+				t.Fatalf("SumByReason = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package rules
+
+import (
+	"math"
+
+	pointv1 "github.com/trancecho/mundo-proto-sdk/points-system/v1"
+)
+
+// Input 计算一次奖励所需的用户状态，由积分服务从存储中读取后传入
+type Input struct {
+	Reason     string
+	Experience int64 // 当前累计经验
+	StreakDays int32 // 本次行为之后的连续签到天数
+
+	TodayCount      int   // 今日该原因已生效次数
+	TodayPoints     int64 // 今日该原因已获得积分
+	TodayExperience int64 // 今日该原因已获得经验
+}
+
+// Award 奖励计算结果
+type Award struct {
+	Points      int64
+	Experience  int64
+	Multiplier  float64
+	Capped      bool // 是否因每日上限被削减
+	LevelBefore int32
+	LevelAfter  int32
+}
+
+// LeveledUp 是否因本次奖励升级
+func (a Award) LeveledUp() bool {
+	return a.LevelAfter > a.LevelBefore
+}
+
+// Evaluate 按规则计算一次奖励，不修改任何状态，可同时用于实际发放和 dry-run 预览
+func (r *Rules) Evaluate(in Input) (Award, error) {
+	rw, ok := r.Rewards[in.Reason]
+	if !ok {
+		return Award{}, ErrUnknownReason
+	}
+	a := Award{
+		Multiplier:  1,
+		LevelBefore: r.Level(in.Experience),
+	}
+	if rw.Streak {
+		a.Multiplier = r.StreakMultiplier(in.StreakDays)
+	}
+
+	if rw.DailyLimit > 0 && in.TodayCount >= rw.DailyLimit {
+		a.Capped = true
+		a.LevelAfter = a.LevelBefore
+		return a, nil
+	}
+	a.Points = int64(math.Round(float64(rw.Points) * a.Multiplier))
+	a.Experience = int64(math.Round(float64(rw.Experience) * a.Multiplier))
+	if p, capped := capDelta(a.Points, in.TodayPoints, rw.DailyPointsCap); capped {
+		a.Points, a.Capped = p, true
+	}
+	if e, capped := capDelta(a.Experience, in.TodayExperience, rw.DailyExperienceCap); capped {
+		a.Experience, a.Capped = e, true
+	}
+	a.LevelAfter = r.Level(in.Experience + a.Experience)
+	return a, nil
+}
+
+// capDelta 将正向奖励限制在每日上限内
+func capDelta(delta, today, limit int64) (int64, bool) {
+	if limit <= 0 || delta <= 0 || today+delta <= limit {
+		return delta, false
+	}
+	return max(limit-today, 0), true
+}
+
+// ToPreview 转换为 PreviewAward 响应
+func (a Award) ToPreview() *pointv1.PreviewAwardResponse {
+	return &pointv1.PreviewAwardResponse{
+		Success:     true,
+		ErrorCode:   pointv1.ErrorCode_NONE_ERROR,
+		Points:      a.Points,
+		Experience:  a.Experience,
+		Multiplier:  a.Multiplier,
+		Capped:      a.Capped,
+		LevelBefore: a.LevelBefore,
+		LevelAfter:  a.LevelAfter,
+	}
+}
@@ -0,0 +1,177 @@
+package rules
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+)
+
+var ErrUnknownReason = errors.New("rules: unknown reason")
+
+// Rules 积分系统的声明式规则：等级曲线、各原因的奖励、每日上限和连续签到倍率。
+//
+// YAML 示例：
+//
+//	curve: {base: 100, exponent: 1.5, max_level: 30}
+//	rewards:
+//	  签到: {points: 10, experience: 5, streak: true}
+//	  点赞: {points: 1, experience: 1, daily_limit: 20}
+//	  发帖: {points: 5, experience: 10, daily_points_cap: 50}
+//	streak:
+//	  - {min_days: 3, multiplier: 1.2}
+//	  - {min_days: 7, multiplier: 1.5}
+type Rules struct {
+	// Levels 显式的等级阈值，与 Curve 二选一，优先使用 Levels
+	Levels []LevelThreshold `json:"levels,omitempty" yaml:"levels,omitempty"`
+	// Curve 按公式生成等级阈值
+	Curve *LevelCurve `json:"curve,omitempty" yaml:"curve,omitempty"`
+	// Rewards 各变更原因对应的奖励
+	Rewards map[string]Reward `json:"rewards" yaml:"rewards"`
+	// Streak 连续签到倍率，对 streak=true 的奖励生效
+	Streak []StreakMultiplier `json:"streak,omitempty" yaml:"streak,omitempty"`
+
+	thresholds []LevelThreshold
+}
+
+// LevelThreshold 达到 Level 所需的累计经验
+type LevelThreshold struct {
+	Level      int32 `json:"level" yaml:"level"`
+	Experience int64 `json:"experience" yaml:"experience"`
+}
+
+// LevelCurve 等级曲线：升到 n 级所需累计经验 = Base * (n-1)^Exponent，1 级为 0
+type LevelCurve struct {
+	Base     float64 `json:"base" yaml:"base"`
+	Exponent float64 `json:"exponent" yaml:"exponent"`
+	MaxLevel int32   `json:"max_level" yaml:"max_level"`
+}
+
+// Reward 单个变更原因的奖励
+type Reward struct {
+	Points     int64 `json:"points" yaml:"points"`
+	Experience int64 `json:"experience" yaml:"experience"`
+	Streak     bool  `json:"streak,omitempty" yaml:"streak,omitempty"` // 是否应用连续签到倍率
+
+	DailyLimit         int   `json:"daily_limit,omitempty" yaml:"daily_limit,omitempty"`                   // 每日最多生效次数，0 不限
+	DailyPointsCap     int64 `json:"daily_points_cap,omitempty" yaml:"daily_points_cap,omitempty"`         // 每日积分上限，0 不限
+	DailyExperienceCap int64 `json:"daily_experience_cap,omitempty" yaml:"daily_experience_cap,omitempty"` // 每日经验上限，0 不限
+}
+
+// StreakMultiplier 连续签到达到 MinDays 天后的奖励倍率
+type StreakMultiplier struct {
+	MinDays    int32   `json:"min_days" yaml:"min_days"`
+	Multiplier float64 `json:"multiplier" yaml:"multiplier"`
+}
+
+// Load 从文件加载规则，.yaml/.yml 按 YAML 解析，其余按 JSON 解析
+func Load(path string) (*Rules, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	ext := strings.ToLower(filepath.Ext(path))
+	return Parse(data, ext == ".yaml" || ext == ".yml")
+}
+
+// Parse 解析规则并校验
+func Parse(data []byte, isYAML bool) (*Rules, error) {
+	var r Rules
+	var err error
+	if isYAML {
+		err = yaml.Unmarshal(data, &r)
+	} else {
+		err = json.Unmarshal(data, &r)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("rules: parse: %w", err)
+	}
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// Validate 校验规则并预计算等级阈值，手动构造 Rules 后必须调用
+func (r *Rules) Validate() error {
+	thresholds := append([]LevelThreshold(nil), r.Levels...)
+	if len(thresholds) == 0 && r.Curve != nil {
+		c := r.Curve
+		if c.Base <= 0 || c.Exponent <= 0 || c.MaxLevel < 1 {
+			return errors.New("rules: curve requires positive base, exponent and max_level")
+		}
+		for lv := int32(1); lv <= c.MaxLevel; lv++ {
+			exp := int64(math.Round(c.Base * math.Pow(float64(lv-1), c.Exponent)))
+			thresholds = append(thresholds, LevelThreshold{Level: lv, Experience: exp})
+		}
+	}
+	if len(thresholds) == 0 {
+		return errors.New("rules: levels or curve is required")
+	}
+	sort.Slice(thresholds, func(i, j int) bool { return thresholds[i].Level < thresholds[j].Level })
+	for i := 1; i < len(thresholds); i++ {
+		if thresholds[i].Level == thresholds[i-1].Level {
+			return fmt.Errorf("rules: duplicate level %d", thresholds[i].Level)
+		}
+		if thresholds[i].Experience <= thresholds[i-1].Experience {
+			return fmt.Errorf("rules: experience of level %d must be greater than level %d",
+				thresholds[i].Level, thresholds[i-1].Level)
+		}
+	}
+	for reason, rw := range r.Rewards {
+		if rw.DailyLimit < 0 || rw.DailyPointsCap < 0 || rw.DailyExperienceCap < 0 {
+			return fmt.Errorf("rules: reward %q has negative cap", reason)
+		}
+	}
+	for _, s := range r.Streak {
+		if s.MinDays < 1 || s.Multiplier <= 0 {
+			return fmt.Errorf("rules: invalid streak multiplier %+v", s)
+		}
+	}
+	sort.Slice(r.Streak, func(i, j int) bool { return r.Streak[i].MinDays < r.Streak[j].MinDays })
+	r.thresholds = thresholds
+	return nil
+}
+
+// Level 根据累计经验计算等级，经验低于最低等级的阈值或未调用 Validate 时返回 0
+func (r *Rules) Level(experience int64) int32 {
+	var level int32
+	for _, t := range r.thresholds {
+		if experience < t.Experience {
+			break
+		}
+		level = t.Level
+	}
+	return level
+}
+
+// NextLevel 返回下一等级及还需的经验，已满级时返回当前等级和 0，未调用 Validate 时返回 0, 0
+func (r *Rules) NextLevel(experience int64) (int32, int64) {
+	if len(r.thresholds) == 0 {
+		return 0, 0
+	}
+	for _, t := range r.thresholds {
+		if experience < t.Experience {
+			return t.Level, t.Experience - experience
+		}
+	}
+	return r.thresholds[len(r.thresholds)-1].Level, 0
+}
+
+// StreakMultiplier 连续签到 days 天对应的倍率，取已达到的 MinDays 最大的档位，未达到任何档位时为 1。
+// 不依赖 Streak 的顺序，未调用 Validate 时同样正确
+func (r *Rules) StreakMultiplier(days int32) float64 {
+	m, best := 1.0, int32(0)
+	for _, s := range r.Streak {
+		if days >= s.MinDays && s.MinDays > best {
+			m, best = s.Multiplier, s.MinDays
+		}
+	}
+	return m
+}
@@ -0,0 +1,174 @@
+package rules
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const testYAML = `
+levels:
+  - {level: 1, experience: 0}
+  - {level: 2, experience: 100}
+  - {level: 3, experience: 300}
+rewards:
+  签到: {points: 10, experience: 5, streak: true}
+  点赞: {points: 1, experience: 1, daily_limit: 20}
+  发帖: {points: 5, experience: 10, daily_points_cap: 12}
+streak:
+  - {min_days: 7, multiplier: 1.5}
+  - {min_days: 3, multiplier: 1.2}
+`
+
+func mustParse(t *testing.T) *Rules {
+	t.Helper()
+	r, err := Parse([]byte(testYAML), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		isYAML  bool
+		wantErr string
+	}{
+		{"yaml levels", testYAML, true, ""},
+		{"json curve", `{"curve": {"base": 100, "exponent": 1.5, "max_level": 10}, "rewards": {}}`, false, ""},
+		{"malformed", `{`, false, "rules: parse"},
+		{"no levels", `{"rewards": {}}`, false, "levels or curve is required"},
+		{"bad curve", `{"curve": {"base": 0, "exponent": 1, "max_level": 3}}`, false, "curve requires"},
+		{"duplicate level", `{"levels": [{"level": 1, "experience": 0}, {"level": 1, "experience": 10}]}`, false, "duplicate level 1"},
+		{"non increasing", `{"levels": [{"level": 1, "experience": 10}, {"level": 2, "experience": 10}]}`, false, "must be greater"},
+		{"negative cap", `{"levels": [{"level": 1}], "rewards": {"x": {"daily_limit": -1}}}`, false, "negative cap"},
+		{"bad streak", `{"levels": [{"level": 1}], "streak": [{"min_days": 0, "multiplier": 2}]}`, false, "invalid streak"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.data), tt.isYAML)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("Parse err = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("Parse err = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLevel(t *testing.T) {
+	r := mustParse(t)
+	tests := []struct {
+		experience int64
+		level      int32
+		next       int32
+		need       int64
+	}{
+		{0, 1, 2, 100},
+		{99, 1, 2, 1},
+		{100, 2, 3, 200},
+		{300, 3, 3, 0},
+		{1000, 3, 3, 0},
+	}
+	for _, tt := range tests {
+		if got := r.Level(tt.experience); got != tt.level {
+			t.Errorf("Level(%d) = %d, want %d", tt.experience, got, tt.level)
+		}
+		if next, need := r.NextLevel(tt.experience); next != tt.next || need != tt.need {
+			t.Errorf("NextLevel(%d) = %d, %d; want %d, %d", tt.experience, next, need, tt.next, tt.need)
+		}
+	}
+}
+
+func TestLevelBelowFirstThreshold(t *testing.T) {
+	r, err := Parse([]byte(`{"levels": [{"level": 1, "experience": 50}, {"level": 2, "experience": 100}]}`), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		experience int64
+		level      int32
+	}{
+		{-10, 0},
+		{0, 0},
+		{49, 0},
+		{50, 1},
+		{100, 2},
+	}
+	for _, tt := range tests {
+		if got := r.Level(tt.experience); got != tt.level {
+			t.Errorf("Level(%d) = %d, want %d", tt.experience, got, tt.level)
+		}
+	}
+	if next, need := r.NextLevel(10); next != 1 || need != 40 {
+		t.Errorf("NextLevel(10) = %d, %d; want 1, 40", next, need)
+	}
+}
+
+func TestStreakMultiplier(t *testing.T) {
+	// 手动构造且未调用 Validate，档位顺序与 testYAML 一样是乱序的
+	unsorted := &Rules{Streak: []StreakMultiplier{{MinDays: 7, Multiplier: 1.5}, {MinDays: 3, Multiplier: 1.2}}}
+	tests := []struct {
+		days int32
+		want float64
+	}{
+		{0, 1},
+		{2, 1},
+		{3, 1.2},
+		{6, 1.2},
+		{7, 1.5},
+		{30, 1.5},
+	}
+	for _, r := range []*Rules{unsorted, mustParse(t)} {
+		for _, tt := range tests {
+			if got := r.StreakMultiplier(tt.days); got != tt.want {
+				t.Errorf("StreakMultiplier(%d) = %v, want %v", tt.days, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestLevelWithoutValidate(t *testing.T) {
+	r := &Rules{Levels: []LevelThreshold{{Level: 1}, {Level: 2, Experience: 100}}}
+	if got := r.Level(500); got != 0 {
+		t.Fatalf("Level = %d, want 0", got)
+	}
+	if next, need := r.NextLevel(500); next != 0 || need != 0 {
+		t.Fatalf("NextLevel = %d, %d; want 0, 0", next, need)
+	}
+	if _, err := (&Rules{Rewards: map[string]Reward{"x": {Points: 1}}}).Evaluate(Input{Reason: "x"}); err != nil {
+		t.Fatalf("Evaluate err = %v", err)
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	r := mustParse(t)
+	tests := []struct {
+		name    string
+		in      Input
+		want    Award
+		wantErr error
+	}{
+		{"unknown reason", Input{Reason: "转账"}, Award{}, ErrUnknownReason},
+		{"plain", Input{Reason: "点赞", Experience: 50}, Award{Points: 1, Experience: 1, Multiplier: 1, LevelBefore: 1, LevelAfter: 1}, nil},
+		{"streak below tier", Input{Reason: "签到", StreakDays: 2}, Award{Points: 10, Experience: 5, Multiplier: 1, LevelBefore: 1, LevelAfter: 1}, nil},
+		{"streak tier", Input{Reason: "签到", StreakDays: 7}, Award{Points: 15, Experience: 8, Multiplier: 1.5, LevelBefore: 1, LevelAfter: 1}, nil},
+		{"daily limit", Input{Reason: "点赞", TodayCount: 20}, Award{Multiplier: 1, Capped: true, LevelBefore: 1, LevelAfter: 1}, nil},
+		{"points cap", Input{Reason: "发帖", TodayPoints: 10}, Award{Points: 2, Experience: 10, Multiplier: 1, Capped: true, LevelBefore: 1, LevelAfter: 1}, nil},
+		{"level up", Input{Reason: "发帖", Experience: 95}, Award{Points: 5, Experience: 10, Multiplier: 1, LevelBefore: 1, LevelAfter: 2}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.Evaluate(tt.in)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("Evaluate = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
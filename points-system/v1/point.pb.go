@@ -7,8 +7,10 @@
 package v1
 
 import (
+	v1 "github.com/trancecho/mundo-proto-sdk/common/v1"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -21,15 +23,181 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// 统计时间粒度
+type StatsGranularity int32
+
+const (
+	StatsGranularity_STATS_GRANULARITY_UNSPECIFIED StatsGranularity = 0 // 默认按天
+	StatsGranularity_STATS_GRANULARITY_DAY         StatsGranularity = 1
+	StatsGranularity_STATS_GRANULARITY_WEEK        StatsGranularity = 2 // 周一为一周的开始
+	StatsGranularity_STATS_GRANULARITY_MONTH       StatsGranularity = 3
+)
+
+// Enum value maps for StatsGranularity.
+var (
+	StatsGranularity_name = map[int32]string{
+		0: "STATS_GRANULARITY_UNSPECIFIED",
+		1: "STATS_GRANULARITY_DAY",
+		2: "STATS_GRANULARITY_WEEK",
+		3: "STATS_GRANULARITY_MONTH",
+	}
+	StatsGranularity_value = map[string]int32{
+		"STATS_GRANULARITY_UNSPECIFIED": 0,
+		"STATS_GRANULARITY_DAY":         1,
+		"STATS_GRANULARITY_WEEK":        2,
+		"STATS_GRANULARITY_MONTH":       3,
+	}
+)
+
+func (x StatsGranularity) Enum() *StatsGranularity {
+	p := new(StatsGranularity)
+	*p = x
+	return p
+}
+
+func (x StatsGranularity) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StatsGranularity) Descriptor() protoreflect.EnumDescriptor {
+	return file_points_system_v1_point_proto_enumTypes[0].Descriptor()
+}
+
+func (StatsGranularity) Type() protoreflect.EnumType {
+	return &file_points_system_v1_point_proto_enumTypes[0]
+}
+
+func (x StatsGranularity) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StatsGranularity.Descriptor instead.
+func (StatsGranularity) EnumDescriptor() ([]byte, []int) {
+	return file_points_system_v1_point_proto_rawDescGZIP(), []int{0}
+}
+
+// 排行榜指标
+type LeaderboardMetric int32
+
+const (
+	LeaderboardMetric_LEADERBOARD_METRIC_UNSPECIFIED LeaderboardMetric = 0
+	LeaderboardMetric_LEADERBOARD_METRIC_POINTS      LeaderboardMetric = 1 // 积分（周期榜为周期内获得的积分）
+	LeaderboardMetric_LEADERBOARD_METRIC_EXPERIENCE  LeaderboardMetric = 2 // 经验（周期榜为周期内获得的经验）
+	LeaderboardMetric_LEADERBOARD_METRIC_SIGN_STREAK LeaderboardMetric = 3 // 连续签到天数
+)
+
+// Enum value maps for LeaderboardMetric.
+var (
+	LeaderboardMetric_name = map[int32]string{
+		0: "LEADERBOARD_METRIC_UNSPECIFIED",
+		1: "LEADERBOARD_METRIC_POINTS",
+		2: "LEADERBOARD_METRIC_EXPERIENCE",
+		3: "LEADERBOARD_METRIC_SIGN_STREAK",
+	}
+	LeaderboardMetric_value = map[string]int32{
+		"LEADERBOARD_METRIC_UNSPECIFIED": 0,
+		"LEADERBOARD_METRIC_POINTS":      1,
+		"LEADERBOARD_METRIC_EXPERIENCE":  2,
+		"LEADERBOARD_METRIC_SIGN_STREAK": 3,
+	}
+)
+
+func (x LeaderboardMetric) Enum() *LeaderboardMetric {
+	p := new(LeaderboardMetric)
+	*p = x
+	return p
+}
+
+func (x LeaderboardMetric) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LeaderboardMetric) Descriptor() protoreflect.EnumDescriptor {
+	return file_points_system_v1_point_proto_enumTypes[1].Descriptor()
+}
+
+func (LeaderboardMetric) Type() protoreflect.EnumType {
+	return &file_points_system_v1_point_proto_enumTypes[1]
+}
+
+func (x LeaderboardMetric) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LeaderboardMetric.Descriptor instead.
+func (LeaderboardMetric) EnumDescriptor() ([]byte, []int) {
+	return file_points_system_v1_point_proto_rawDescGZIP(), []int{1}
+}
+
+// 排行榜周期
+type LeaderboardPeriod int32
+
+const (
+	LeaderboardPeriod_LEADERBOARD_PERIOD_UNSPECIFIED LeaderboardPeriod = 0 // 等同于 ALL_TIME
+	LeaderboardPeriod_LEADERBOARD_PERIOD_DAILY       LeaderboardPeriod = 1 // 日榜
+	LeaderboardPeriod_LEADERBOARD_PERIOD_WEEKLY      LeaderboardPeriod = 2 // 周榜（周一开始）
+	LeaderboardPeriod_LEADERBOARD_PERIOD_MONTHLY     LeaderboardPeriod = 3 // 月榜
+	LeaderboardPeriod_LEADERBOARD_PERIOD_ALL_TIME    LeaderboardPeriod = 4 // 总榜
+)
+
+// Enum value maps for LeaderboardPeriod.
+var (
+	LeaderboardPeriod_name = map[int32]string{
+		0: "LEADERBOARD_PERIOD_UNSPECIFIED",
+		1: "LEADERBOARD_PERIOD_DAILY",
+		2: "LEADERBOARD_PERIOD_WEEKLY",
+		3: "LEADERBOARD_PERIOD_MONTHLY",
+		4: "LEADERBOARD_PERIOD_ALL_TIME",
+	}
+	LeaderboardPeriod_value = map[string]int32{
+		"LEADERBOARD_PERIOD_UNSPECIFIED": 0,
+		"LEADERBOARD_PERIOD_DAILY":       1,
+		"LEADERBOARD_PERIOD_WEEKLY":      2,
+		"LEADERBOARD_PERIOD_MONTHLY":     3,
+		"LEADERBOARD_PERIOD_ALL_TIME":    4,
+	}
+)
+
+func (x LeaderboardPeriod) Enum() *LeaderboardPeriod {
+	p := new(LeaderboardPeriod)
+	*p = x
+	return p
+}
+
+func (x LeaderboardPeriod) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LeaderboardPeriod) Descriptor() protoreflect.EnumDescriptor {
+	return file_points_system_v1_point_proto_enumTypes[2].Descriptor()
+}
+
+func (LeaderboardPeriod) Type() protoreflect.EnumType {
+	return &file_points_system_v1_point_proto_enumTypes[2]
+}
+
+func (x LeaderboardPeriod) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LeaderboardPeriod.Descriptor instead.
+func (LeaderboardPeriod) EnumDescriptor() ([]byte, []int) {
+	return file_points_system_v1_point_proto_rawDescGZIP(), []int{2}
+}
+
 // 错误码枚举
 type ErrorCode int32
 
 const (
-	ErrorCode_UNKNOWN_ERROR       ErrorCode = 0
-	ErrorCode_POINTS_INSUFFICIENT ErrorCode = 1 // 积分不足
-	ErrorCode_OPERATION_FAILED    ErrorCode = 2
-	ErrorCode_INVALID_REQUEST     ErrorCode = 3
-	ErrorCode_NONE_ERROR          ErrorCode = 4 // 无错误
+	ErrorCode_UNKNOWN_ERROR              ErrorCode = 0
+	ErrorCode_POINTS_INSUFFICIENT        ErrorCode = 1 // 积分不足
+	ErrorCode_OPERATION_FAILED           ErrorCode = 2
+	ErrorCode_INVALID_REQUEST            ErrorCode = 3
+	ErrorCode_NONE_ERROR                 ErrorCode = 4 // 无错误
+	ErrorCode_RESERVATION_NOT_FOUND      ErrorCode = 5 // 预留不存在
+	ErrorCode_RESERVATION_EXPIRED        ErrorCode = 6 // 预留已过期
+	ErrorCode_RESERVATION_STATE_CONFLICT ErrorCode = 7 // 预留状态不允许该操作（如已取消后再确认）
+	ErrorCode_PERMISSION_DENIED          ErrorCode = 8 // 无权限（如非管理员调用后台接口）
 )
 
 // Enum value maps for ErrorCode.
@@ -40,13 +208,21 @@ var (
 		2: "OPERATION_FAILED",
 		3: "INVALID_REQUEST",
 		4: "NONE_ERROR",
+		5: "RESERVATION_NOT_FOUND",
+		6: "RESERVATION_EXPIRED",
+		7: "RESERVATION_STATE_CONFLICT",
+		8: "PERMISSION_DENIED",
 	}
 	ErrorCode_value = map[string]int32{
-		"UNKNOWN_ERROR":       0,
-		"POINTS_INSUFFICIENT": 1,
-		"OPERATION_FAILED":    2,
-		"INVALID_REQUEST":     3,
-		"NONE_ERROR":          4,
+		"UNKNOWN_ERROR":              0,
+		"POINTS_INSUFFICIENT":        1,
+		"OPERATION_FAILED":           2,
+		"INVALID_REQUEST":            3,
+		"NONE_ERROR":                 4,
+		"RESERVATION_NOT_FOUND":      5,
+		"RESERVATION_EXPIRED":        6,
+		"RESERVATION_STATE_CONFLICT": 7,
+		"PERMISSION_DENIED":          8,
 	}
 )
 
@@ -61,11 +237,11 @@ func (x ErrorCode) String() string {
 }
 
 func (ErrorCode) Descriptor() protoreflect.EnumDescriptor {
-	return file_points_system_v1_point_proto_enumTypes[0].Descriptor()
+	return file_points_system_v1_point_proto_enumTypes[3].Descriptor()
 }
 
 func (ErrorCode) Type() protoreflect.EnumType {
-	return &file_points_system_v1_point_proto_enumTypes[0]
+	return &file_points_system_v1_point_proto_enumTypes[3]
 }
 
 func (x ErrorCode) Number() protoreflect.EnumNumber {
@@ -74,7 +250,63 @@ func (x ErrorCode) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ErrorCode.Descriptor instead.
 func (ErrorCode) EnumDescriptor() ([]byte, []int) {
-	return file_points_system_v1_point_proto_rawDescGZIP(), []int{0}
+	return file_points_system_v1_point_proto_rawDescGZIP(), []int{3}
+}
+
+// 积分预留状态
+type ReservationStatus int32
+
+const (
+	ReservationStatus_RESERVATION_STATUS_UNSPECIFIED ReservationStatus = 0
+	ReservationStatus_RESERVATION_STATUS_PENDING     ReservationStatus = 1 // 已预留，待确认
+	ReservationStatus_RESERVATION_STATUS_COMMITTED   ReservationStatus = 2 // 已确认扣除
+	ReservationStatus_RESERVATION_STATUS_CANCELLED   ReservationStatus = 3 // 已取消，积分退回
+	ReservationStatus_RESERVATION_STATUS_EXPIRED     ReservationStatus = 4 // 超时未确认，积分退回
+)
+
+// Enum value maps for ReservationStatus.
+var (
+	ReservationStatus_name = map[int32]string{
+		0: "RESERVATION_STATUS_UNSPECIFIED",
+		1: "RESERVATION_STATUS_PENDING",
+		2: "RESERVATION_STATUS_COMMITTED",
+		3: "RESERVATION_STATUS_CANCELLED",
+		4: "RESERVATION_STATUS_EXPIRED",
+	}
+	ReservationStatus_value = map[string]int32{
+		"RESERVATION_STATUS_UNSPECIFIED": 0,
+		"RESERVATION_STATUS_PENDING":     1,
+		"RESERVATION_STATUS_COMMITTED":   2,
+		"RESERVATION_STATUS_CANCELLED":   3,
+		"RESERVATION_STATUS_EXPIRED":     4,
+	}
+)
+
+func (x ReservationStatus) Enum() *ReservationStatus {
+	p := new(ReservationStatus)
+	*p = x
+	return p
+}
+
+func (x ReservationStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReservationStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_points_system_v1_point_proto_enumTypes[4].Descriptor()
+}
+
+func (ReservationStatus) Type() protoreflect.EnumType {
+	return &file_points_system_v1_point_proto_enumTypes[4]
+}
+
+func (x ReservationStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReservationStatus.Descriptor instead.
+func (ReservationStatus) EnumDescriptor() ([]byte, []int) {
+	return file_points_system_v1_point_proto_rawDescGZIP(), []int{4}
 }
 
 // 用户信息
@@ -88,6 +320,8 @@ type UserInfo struct {
 	IsSigned           bool                   `protobuf:"varint,6,opt,name=is_signed,json=isSigned,proto3" json:"is_signed,omitempty"`                                 // 是否已签到
 	ContinuousSignDays int32                  `protobuf:"varint,7,opt,name=continuous_sign_days,json=continuousSignDays,proto3" json:"continuous_sign_days,omitempty"` // 连续签到天数
 	TotalSignDays      int32                  `protobuf:"varint,8,opt,name=total_sign_days,json=totalSignDays,proto3" json:"total_sign_days,omitempty"`                // 总签到天数
+	ReservedPoints     int64                  `protobuf:"varint,9,opt,name=reserved_points,json=reservedPoints,proto3" json:"reserved_points,omitempty"`               // 已预留待确认的积分（不计入可用积分）
+	User               *v1.UserRef            `protobuf:"bytes,10,opt,name=user,proto3" json:"user,omitempty"`                                                         // 统一用户标识，服务端已知时填充
 	unknownFields      protoimpl.UnknownFields
 	sizeCache          protoimpl.SizeCache
 }
@@ -178,6 +412,20 @@ func (x *UserInfo) GetTotalSignDays() int32 {
 	return 0
 }
 
+func (x *UserInfo) GetReservedPoints() int64 {
+	if x != nil {
+		return x.ReservedPoints
+	}
+	return 0
+}
+
+func (x *UserInfo) GetUser() *v1.UserRef {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
 // 积分/经验变更请求
 type UpdatePointsRequest struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
@@ -185,6 +433,8 @@ type UpdatePointsRequest struct {
 	DeltaPoints     int64                  `protobuf:"varint,2,opt,name=delta_points,json=deltaPoints,proto3" json:"delta_points,omitempty"`             // 积分变化量（正加负扣）
 	DeltaExperience int64                  `protobuf:"varint,3,opt,name=delta_experience,json=deltaExperience,proto3" json:"delta_experience,omitempty"` // 经验变化量
 	Reason          string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`                                           // 变更原因（如"签到"、"发帖"）
+	IdempotencyKey  string                 `protobuf:"bytes,5,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`     // 幂等键，相同键的重复请求只生效一次
+	User            *v1.UserRef            `protobuf:"bytes,6,opt,name=user,proto3" json:"user,omitempty"`                                               // 统一用户标识，设置时优先于 user_id
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
@@ -247,6 +497,20 @@ func (x *UpdatePointsRequest) GetReason() string {
 	return ""
 }
 
+func (x *UpdatePointsRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *UpdatePointsRequest) GetUser() *v1.UserRef {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
 // 通用响应
 type CommonResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -310,12 +574,15 @@ func (x *CommonResponse) GetErrorCode() ErrorCode {
 
 // 点赞请求
 type LikeRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	PostId        string                 `protobuf:"bytes,2,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
-	TargetUserId  string                 `protobuf:"bytes,3,opt,name=target_user_id,json=targetUserId,proto3" json:"target_user_id,omitempty"` // 被点赞的用户ID
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UserId         string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PostId         string                 `protobuf:"bytes,2,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	TargetUserId   string                 `protobuf:"bytes,3,opt,name=target_user_id,json=targetUserId,proto3" json:"target_user_id,omitempty"`     // 被点赞的用户ID
+	IdempotencyKey string                 `protobuf:"bytes,4,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"` // 幂等键，相同键的重复请求只生效一次
+	User           *v1.UserRef            `protobuf:"bytes,5,opt,name=user,proto3" json:"user,omitempty"`                                           // 统一用户标识，设置时优先于 user_id
+	TargetUser     *v1.UserRef            `protobuf:"bytes,6,opt,name=target_user,json=targetUser,proto3" json:"target_user,omitempty"`             // 设置时优先于 target_user_id
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *LikeRequest) Reset() {
@@ -369,10 +636,32 @@ func (x *LikeRequest) GetTargetUserId() string {
 	return ""
 }
 
+func (x *LikeRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *LikeRequest) GetUser() *v1.UserRef {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *LikeRequest) GetTargetUser() *v1.UserRef {
+	if x != nil {
+		return x.TargetUser
+	}
+	return nil
+}
+
 // 获取用户信息请求
 type GetUserInfoRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	User          *v1.UserRef            `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"` // 统一用户标识，设置时优先于 user_id
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -414,17 +703,124 @@ func (x *GetUserInfoRequest) GetUserId() string {
 	return ""
 }
 
-// 签到请求
-type SignRequest struct {
+func (x *GetUserInfoRequest) GetUser() *v1.UserRef {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+// 批量获取用户信息请求
+type BatchGetUserInfoRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UserIds       []string               `protobuf:"bytes,1,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"` // 最多 100 个，重复 ID 会被去重
+	Users         []*v1.UserRef          `protobuf:"bytes,2,rep,name=users,proto3" json:"users,omitempty"`                    // 与 user_ids 合并查询，响应的 key 为 uid 的十进制字符串
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetUserInfoRequest) Reset() {
+	*x = BatchGetUserInfoRequest{}
+	mi := &file_points_system_v1_point_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetUserInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetUserInfoRequest) ProtoMessage() {}
+
+func (x *BatchGetUserInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_points_system_v1_point_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetUserInfoRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetUserInfoRequest) Descriptor() ([]byte, []int) {
+	return file_points_system_v1_point_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BatchGetUserInfoRequest) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+func (x *BatchGetUserInfoRequest) GetUsers() []*v1.UserRef {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+// 批量获取用户信息响应
+type BatchGetUserInfoResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         map[string]*UserInfo   `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // key 为 user_id，不存在的用户不返回
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
+func (x *BatchGetUserInfoResponse) Reset() {
+	*x = BatchGetUserInfoResponse{}
+	mi := &file_points_system_v1_point_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetUserInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetUserInfoResponse) ProtoMessage() {}
+
+func (x *BatchGetUserInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_points_system_v1_point_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetUserInfoResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetUserInfoResponse) Descriptor() ([]byte, []int) {
+	return file_points_system_v1_point_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *BatchGetUserInfoResponse) GetUsers() map[string]*UserInfo {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+// 签到请求
+type SignRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UserId         string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	IdempotencyKey string                 `protobuf:"bytes,2,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"` // 幂等键，相同键的重复请求只生效一次
+	User           *v1.UserRef            `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`                                           // 统一用户标识，设置时优先于 user_id
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
 func (x *SignRequest) Reset() {
 	*x = SignRequest{}
-	mi := &file_points_system_v1_point_proto_msgTypes[5]
+	mi := &file_points_system_v1_point_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -436,7 +832,7 @@ func (x *SignRequest) String() string {
 func (*SignRequest) ProtoMessage() {}
 
 func (x *SignRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_points_system_v1_point_proto_msgTypes[5]
+	mi := &file_points_system_v1_point_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -449,7 +845,7 @@ func (x *SignRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SignRequest.ProtoReflect.Descriptor instead.
 func (*SignRequest) Descriptor() ([]byte, []int) {
-	return file_points_system_v1_point_proto_rawDescGZIP(), []int{5}
+	return file_points_system_v1_point_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *SignRequest) GetUserId() string {
@@ -459,6 +855,20 @@ func (x *SignRequest) GetUserId() string {
 	return ""
 }
 
+func (x *SignRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *SignRequest) GetUser() *v1.UserRef {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
 // 签到响应
 type SignResponse struct {
 	state              protoimpl.MessageState `protogen:"open.v1"`
@@ -474,7 +884,7 @@ type SignResponse struct {
 
 func (x *SignResponse) Reset() {
 	*x = SignResponse{}
-	mi := &file_points_system_v1_point_proto_msgTypes[6]
+	mi := &file_points_system_v1_point_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -486,7 +896,7 @@ func (x *SignResponse) String() string {
 func (*SignResponse) ProtoMessage() {}
 
 func (x *SignResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_points_system_v1_point_proto_msgTypes[6]
+	mi := &file_points_system_v1_point_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -499,7 +909,7 @@ func (x *SignResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SignResponse.ProtoReflect.Descriptor instead.
 func (*SignResponse) Descriptor() ([]byte, []int) {
-	return file_points_system_v1_point_proto_rawDescGZIP(), []int{6}
+	return file_points_system_v1_point_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *SignResponse) GetSuccess() bool {
@@ -556,7 +966,7 @@ type AdminStats struct {
 
 func (x *AdminStats) Reset() {
 	*x = AdminStats{}
-	mi := &file_points_system_v1_point_proto_msgTypes[7]
+	mi := &file_points_system_v1_point_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -568,7 +978,7 @@ func (x *AdminStats) String() string {
 func (*AdminStats) ProtoMessage() {}
 
 func (x *AdminStats) ProtoReflect() protoreflect.Message {
-	mi := &file_points_system_v1_point_proto_msgTypes[7]
+	mi := &file_points_system_v1_point_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -581,7 +991,7 @@ func (x *AdminStats) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AdminStats.ProtoReflect.Descriptor instead.
 func (*AdminStats) Descriptor() ([]byte, []int) {
-	return file_points_system_v1_point_proto_rawDescGZIP(), []int{7}
+	return file_points_system_v1_point_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *AdminStats) GetLevelDistribution() []*LevelDistribution {
@@ -616,7 +1026,7 @@ type LevelDistribution struct {
 
 func (x *LevelDistribution) Reset() {
 	*x = LevelDistribution{}
-	mi := &file_points_system_v1_point_proto_msgTypes[8]
+	mi := &file_points_system_v1_point_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -628,7 +1038,7 @@ func (x *LevelDistribution) String() string {
 func (*LevelDistribution) ProtoMessage() {}
 
 func (x *LevelDistribution) ProtoReflect() protoreflect.Message {
-	mi := &file_points_system_v1_point_proto_msgTypes[8]
+	mi := &file_points_system_v1_point_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -641,7 +1051,7 @@ func (x *LevelDistribution) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LevelDistribution.ProtoReflect.Descriptor instead.
 func (*LevelDistribution) Descriptor() ([]byte, []int) {
-	return file_points_system_v1_point_proto_rawDescGZIP(), []int{8}
+	return file_points_system_v1_point_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *LevelDistribution) GetLevel() int32 {
@@ -658,11 +1068,1519 @@ func (x *LevelDistribution) GetUserCount() int64 {
 	return 0
 }
 
-var File_points_system_v1_point_proto protoreflect.FileDescriptor
+// 后台统计请求
+type AdminStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"` // 起始时间（含），为空表示 end_time 前 30 天
+	EndTime       *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`       // 结束时间（不含），为空表示当前时间
+	Granularity   StatsGranularity       `protobuf:"varint,3,opt,name=granularity,proto3,enum=mundo.system.point.StatsGranularity" json:"granularity,omitempty"`
+	Timezone      string                 `protobuf:"bytes,4,opt,name=timezone,proto3" json:"timezone,omitempty"` // 分桶时区（IANA 名称，如 "Asia/Shanghai"），为空使用服务端时区
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdminStatsRequest) Reset() {
+	*x = AdminStatsRequest{}
+	mi := &file_points_system_v1_point_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminStatsRequest) ProtoMessage() {}
+
+func (x *AdminStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_points_system_v1_point_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminStatsRequest.ProtoReflect.Descriptor instead.
+func (*AdminStatsRequest) Descriptor() ([]byte, []int) {
+	return file_points_system_v1_point_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *AdminStatsRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *AdminStatsRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *AdminStatsRequest) GetGranularity() StatsGranularity {
+	if x != nil {
+		return x.Granularity
+	}
+	return StatsGranularity_STATS_GRANULARITY_UNSPECIFIED
+}
+
+func (x *AdminStatsRequest) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+// 等级迁移：时间段内从 from_level 升到 to_level 的人数
+type LevelMigration struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromLevel     int32                  `protobuf:"varint,1,opt,name=from_level,json=fromLevel,proto3" json:"from_level,omitempty"`
+	ToLevel       int32                  `protobuf:"varint,2,opt,name=to_level,json=toLevel,proto3" json:"to_level,omitempty"`
+	UserCount     int64                  `protobuf:"varint,3,opt,name=user_count,json=userCount,proto3" json:"user_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LevelMigration) Reset() {
+	*x = LevelMigration{}
+	mi := &file_points_system_v1_point_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LevelMigration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LevelMigration) ProtoMessage() {}
+
+func (x *LevelMigration) ProtoReflect() protoreflect.Message {
+	mi := &file_points_system_v1_point_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LevelMigration.ProtoReflect.Descriptor instead.
+func (*LevelMigration) Descriptor() ([]byte, []int) {
+	return file_points_system_v1_point_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *LevelMigration) GetFromLevel() int32 {
+	if x != nil {
+		return x.FromLevel
+	}
+	return 0
+}
+
+func (x *LevelMigration) GetToLevel() int32 {
+	if x != nil {
+		return x.ToLevel
+	}
+	return 0
+}
+
+func (x *LevelMigration) GetUserCount() int64 {
+	if x != nil {
+		return x.UserCount
+	}
+	return 0
+}
+
+// 后台统计时间序列中的一个时间段
+type AdminStatsBucket struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	StartTime       *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`              // 时间段起点
+	PointsIssued    int64                  `protobuf:"varint,2,opt,name=points_issued,json=pointsIssued,proto3" json:"points_issued,omitempty"`    // 发放的积分（正向变更之和）
+	PointsSpent     int64                  `protobuf:"varint,3,opt,name=points_spent,json=pointsSpent,proto3" json:"points_spent,omitempty"`       // 消耗的积分（负向变更绝对值之和）
+	ActiveSigners   int64                  `protobuf:"varint,4,opt,name=active_signers,json=activeSigners,proto3" json:"active_signers,omitempty"` // 签到人数
+	LevelMigrations []*LevelMigration      `protobuf:"bytes,5,rep,name=level_migrations,json=levelMigrations,proto3" json:"level_migrations,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *AdminStatsBucket) Reset() {
+	*x = AdminStatsBucket{}
+	mi := &file_points_system_v1_point_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminStatsBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminStatsBucket) ProtoMessage() {}
+
+func (x *AdminStatsBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_points_system_v1_point_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminStatsBucket.ProtoReflect.Descriptor instead.
+func (*AdminStatsBucket) Descriptor() ([]byte, []int) {
+	return file_points_system_v1_point_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *AdminStatsBucket) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *AdminStatsBucket) GetPointsIssued() int64 {
+	if x != nil {
+		return x.PointsIssued
+	}
+	return 0
+}
+
+func (x *AdminStatsBucket) GetPointsSpent() int64 {
+	if x != nil {
+		return x.PointsSpent
+	}
+	return 0
+}
+
+func (x *AdminStatsBucket) GetActiveSigners() int64 {
+	if x != nil {
+		return x.ActiveSigners
+	}
+	return 0
+}
+
+func (x *AdminStatsBucket) GetLevelMigrations() []*LevelMigration {
+	if x != nil {
+		return x.LevelMigrations
+	}
+	return nil
+}
+
+// 后台统计响应
+type AdminStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	ErrorCode     ErrorCode              `protobuf:"varint,3,opt,name=error_code,json=errorCode,proto3,enum=mundo.system.point.ErrorCode" json:"error_code,omitempty"`
+	Snapshot      *AdminStats            `protobuf:"bytes,4,opt,name=snapshot,proto3" json:"snapshot,omitempty"` // 当前快照（等级分布、平均积分、本月消耗）
+	Buckets       []*AdminStatsBucket    `protobuf:"bytes,5,rep,name=buckets,proto3" json:"buckets,omitempty"`   // 按时间升序，无数据的时间段也会返回
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdminStatsResponse) Reset() {
+	*x = AdminStatsResponse{}
+	mi := &file_points_system_v1_point_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminStatsResponse) ProtoMessage() {}
+
+func (x *AdminStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_points_system_v1_point_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminStatsResponse.ProtoReflect.Descriptor instead.
+func (*AdminStatsResponse) Descriptor() ([]byte, []int) {
+	return file_points_system_v1_point_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *AdminStatsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *AdminStatsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *AdminStatsResponse) GetErrorCode() ErrorCode {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ErrorCode_UNKNOWN_ERROR
+}
+
+func (x *AdminStatsResponse) GetSnapshot() *AdminStats {
+	if x != nil {
+		return x.Snapshot
+	}
+	return nil
+}
+
+func (x *AdminStatsResponse) GetBuckets() []*AdminStatsBucket {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
+// 积分流水
+type PointTransaction struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId          string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DeltaPoints     int64                  `protobuf:"varint,3,opt,name=delta_points,json=deltaPoints,proto3" json:"delta_points,omitempty"`             // 积分变化量
+	DeltaExperience int64                  `protobuf:"varint,4,opt,name=delta_experience,json=deltaExperience,proto3" json:"delta_experience,omitempty"` // 经验变化量
+	Reason          string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`                                           // 变更原因
+	BalanceAfter    int64                  `protobuf:"varint,6,opt,name=balance_after,json=balanceAfter,proto3" json:"balance_after,omitempty"`          // 变更后的积分余额
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *PointTransaction) Reset() {
+	*x = PointTransaction{}
+	mi := &file_points_system_v1_point_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PointTransaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PointTransaction) ProtoMessage() {}
+
+func (x *PointTransaction) ProtoReflect() protoreflect.Message {
+	mi := &file_points_system_v1_point_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PointTransaction.ProtoReflect.Descriptor instead.
+func (*PointTransaction) Descriptor() ([]byte, []int) {
+	return file_points_system_v1_point_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *PointTransaction) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PointTransaction) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PointTransaction) GetDeltaPoints() int64 {
+	if x != nil {
+		return x.DeltaPoints
+	}
+	return 0
+}
+
+func (x *PointTransaction) GetDeltaExperience() int64 {
+	if x != nil {
+		return x.DeltaExperience
+	}
+	return 0
+}
+
+func (x *PointTransaction) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *PointTransaction) GetBalanceAfter() int64 {
+	if x != nil {
+		return x.BalanceAfter
+	}
+	return 0
+}
+
+func (x *PointTransaction) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// 积分流水查询请求
+type ListPointTransactionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Reasons       []string               `protobuf:"bytes,2,rep,name=reasons,proto3" json:"reasons,omitempty"`                      // 按变更原因过滤，为空表示全部
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"` // 起始时间（含），为空表示不限
+	EndTime       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`       // 结束时间（不含），为空表示不限
+	PageSize      int32                  `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // 每页数量，默认 20，最大 100
+	PageToken     string                 `protobuf:"bytes,6,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // 分页游标，首页为空
+	User          *v1.UserRef            `protobuf:"bytes,7,opt,name=user,proto3" json:"user,omitempty"`                            // 统一用户标识，设置时优先于 user_id
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPointTransactionsRequest) Reset() {
+	*x = ListPointTransactionsRequest{}
+	mi := &file_points_system_v1_point_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPointTransactionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPointTransactionsRequest) ProtoMessage() {}
+
+func (x *ListPointTransactionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_points_system_v1_point_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPointTransactionsRequest.ProtoReflect.Descriptor instead.
+func (*ListPointTransactionsRequest) Descriptor() ([]byte, []int) {
+	return file_points_system_v1_point_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListPointTransactionsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListPointTransactionsRequest) GetReasons() []string {
+	if x != nil {
+		return x.Reasons
+	}
+	return nil
+}
+
+func (x *ListPointTransactionsRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *ListPointTransactionsRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *ListPointTransactionsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListPointTransactionsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListPointTransactionsRequest) GetUser() *v1.UserRef {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+// 积分流水查询响应
+type ListPointTransactionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transactions  []*PointTransaction    `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`                          // 按时间倒序
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // 下一页游标，为空表示没有更多
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPointTransactionsResponse) Reset() {
+	*x = ListPointTransactionsResponse{}
+	mi := &file_points_system_v1_point_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPointTransactionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPointTransactionsResponse) ProtoMessage() {}
+
+func (x *ListPointTransactionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_points_system_v1_point_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPointTransactionsResponse.ProtoReflect.Descriptor instead.
+func (*ListPointTransactionsResponse) Descriptor() ([]byte, []int) {
+	return file_points_system_v1_point_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListPointTransactionsResponse) GetTransactions() []*PointTransaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+func (x *ListPointTransactionsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// 奖励预览请求（只计算不落库）
+type PreviewAwardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"` // 变更原因，对应规则中的奖励项（如"签到"、"点赞"）
+	User          *v1.UserRef            `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`     // 统一用户标识，设置时优先于 user_id
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreviewAwardRequest) Reset() {
+	*x = PreviewAwardRequest{}
+	mi := &file_points_system_v1_point_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreviewAwardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewAwardRequest) ProtoMessage() {}
+
+func (x *PreviewAwardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_points_system_v1_point_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewAwardRequest.ProtoReflect.Descriptor instead.
+func (*PreviewAwardRequest) Descriptor() ([]byte, []int) {
+	return file_points_system_v1_point_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *PreviewAwardRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PreviewAwardRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *PreviewAwardRequest) GetUser() *v1.UserRef {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+// 奖励预览响应
+type PreviewAwardResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	ErrorCode     ErrorCode              `protobuf:"varint,3,opt,name=error_code,json=errorCode,proto3,enum=mundo.system.point.ErrorCode" json:"error_code,omitempty"`
+	Points        int64                  `protobuf:"varint,4,opt,name=points,proto3" json:"points,omitempty"`                              // 将获得的积分
+	Experience    int64                  `protobuf:"varint,5,opt,name=experience,proto3" json:"experience,omitempty"`                      // 将获得的经验
+	Multiplier    float64                `protobuf:"fixed64,6,opt,name=multiplier,proto3" json:"multiplier,omitempty"`                     // 连续签到倍率
+	Capped        bool                   `protobuf:"varint,7,opt,name=capped,proto3" json:"capped,omitempty"`                              // 是否触达每日上限
+	LevelBefore   int32                  `protobuf:"varint,8,opt,name=level_before,json=levelBefore,proto3" json:"level_before,omitempty"` // 当前等级
+	LevelAfter    int32                  `protobuf:"varint,9,opt,name=level_after,json=levelAfter,proto3" json:"level_after,omitempty"`    // 奖励后的等级
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreviewAwardResponse) Reset() {
+	*x = PreviewAwardResponse{}
+	mi := &file_points_system_v1_point_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreviewAwardResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewAwardResponse) ProtoMessage() {}
+
+func (x *PreviewAwardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_points_system_v1_point_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewAwardResponse.ProtoReflect.Descriptor instead.
+func (*PreviewAwardResponse) Descriptor() ([]byte, []int) {
+	return file_points_system_v1_point_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *PreviewAwardResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *PreviewAwardResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *PreviewAwardResponse) GetErrorCode() ErrorCode {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ErrorCode_UNKNOWN_ERROR
+}
+
+func (x *PreviewAwardResponse) GetPoints() int64 {
+	if x != nil {
+		return x.Points
+	}
+	return 0
+}
+
+func (x *PreviewAwardResponse) GetExperience() int64 {
+	if x != nil {
+		return x.Experience
+	}
+	return 0
+}
+
+func (x *PreviewAwardResponse) GetMultiplier() float64 {
+	if x != nil {
+		return x.Multiplier
+	}
+	return 0
+}
+
+func (x *PreviewAwardResponse) GetCapped() bool {
+	if x != nil {
+		return x.Capped
+	}
+	return false
+}
+
+func (x *PreviewAwardResponse) GetLevelBefore() int32 {
+	if x != nil {
+		return x.LevelBefore
+	}
+	return 0
+}
+
+func (x *PreviewAwardResponse) GetLevelAfter() int32 {
+	if x != nil {
+		return x.LevelAfter
+	}
+	return 0
+}
+
+// 排行榜条目
+type LeaderboardEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rank          int64                  `protobuf:"varint,1,opt,name=rank,proto3" json:"rank,omitempty"` // 名次，从 1 开始，0 表示未上榜
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username      string                 `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	Score         int64                  `protobuf:"varint,4,opt,name=score,proto3" json:"score,omitempty"`
+	Level         int32                  `protobuf:"varint,5,opt,name=level,proto3" json:"level,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaderboardEntry) Reset() {
+	*x = LeaderboardEntry{}
+	mi := &file_points_system_v1_point_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaderboardEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaderboardEntry) ProtoMessage() {}
+
+func (x *LeaderboardEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_points_system_v1_point_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaderboardEntry.ProtoReflect.Descriptor instead.
+func (*LeaderboardEntry) Descriptor() ([]byte, []int) {
+	return file_points_system_v1_point_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *LeaderboardEntry) GetRank() int64 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *LeaderboardEntry) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *LeaderboardEntry) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *LeaderboardEntry) GetScore() int64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *LeaderboardEntry) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+// 排行榜请求
+type GetLeaderboardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metric        LeaderboardMetric      `protobuf:"varint,1,opt,name=metric,proto3,enum=mundo.system.point.LeaderboardMetric" json:"metric,omitempty"`
+	Period        LeaderboardPeriod      `protobuf:"varint,2,opt,name=period,proto3,enum=mundo.system.point.LeaderboardPeriod" json:"period,omitempty"`
+	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`                // 默认 10，最大 100
+	UserId        string                 `protobuf:"bytes,5,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // 调用者 ID，非空时在 self 中返回其名次
+	User          *v1.UserRef            `protobuf:"bytes,6,opt,name=user,proto3" json:"user,omitempty"`                   // 统一用户标识，设置时优先于 user_id
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLeaderboardRequest) Reset() {
+	*x = GetLeaderboardRequest{}
+	mi := &file_points_system_v1_point_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLeaderboardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLeaderboardRequest) ProtoMessage() {}
+
+func (x *GetLeaderboardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_points_system_v1_point_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLeaderboardRequest.ProtoReflect.Descriptor instead.
+func (*GetLeaderboardRequest) Descriptor() ([]byte, []int) {
+	return file_points_system_v1_point_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetLeaderboardRequest) GetMetric() LeaderboardMetric {
+	if x != nil {
+		return x.Metric
+	}
+	return LeaderboardMetric_LEADERBOARD_METRIC_UNSPECIFIED
+}
+
+func (x *GetLeaderboardRequest) GetPeriod() LeaderboardPeriod {
+	if x != nil {
+		return x.Period
+	}
+	return LeaderboardPeriod_LEADERBOARD_PERIOD_UNSPECIFIED
+}
+
+func (x *GetLeaderboardRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *GetLeaderboardRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetLeaderboardRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetLeaderboardRequest) GetUser() *v1.UserRef {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+// 排行榜响应
+type GetLeaderboardResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*LeaderboardEntry    `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	Self          *LeaderboardEntry      `protobuf:"bytes,2,opt,name=self,proto3" json:"self,omitempty"`    // 调用者自己的名次
+	Total         int64                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"` // 上榜总人数
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLeaderboardResponse) Reset() {
+	*x = GetLeaderboardResponse{}
+	mi := &file_points_system_v1_point_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLeaderboardResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLeaderboardResponse) ProtoMessage() {}
+
+func (x *GetLeaderboardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_points_system_v1_point_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLeaderboardResponse.ProtoReflect.Descriptor instead.
+func (*GetLeaderboardResponse) Descriptor() ([]byte, []int) {
+	return file_points_system_v1_point_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetLeaderboardResponse) GetEntries() []*LeaderboardEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *GetLeaderboardResponse) GetSelf() *LeaderboardEntry {
+	if x != nil {
+		return x.Self
+	}
+	return nil
+}
+
+func (x *GetLeaderboardResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+// 用户名次请求
+type GetUserRankRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Metric        LeaderboardMetric      `protobuf:"varint,2,opt,name=metric,proto3,enum=mundo.system.point.LeaderboardMetric" json:"metric,omitempty"`
+	Period        LeaderboardPeriod      `protobuf:"varint,3,opt,name=period,proto3,enum=mundo.system.point.LeaderboardPeriod" json:"period,omitempty"`
+	User          *v1.UserRef            `protobuf:"bytes,4,opt,name=user,proto3" json:"user,omitempty"` // 统一用户标识，设置时优先于 user_id
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserRankRequest) Reset() {
+	*x = GetUserRankRequest{}
+	mi := &file_points_system_v1_point_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserRankRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserRankRequest) ProtoMessage() {}
+
+func (x *GetUserRankRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_points_system_v1_point_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserRankRequest.ProtoReflect.Descriptor instead.
+func (*GetUserRankRequest) Descriptor() ([]byte, []int) {
+	return file_points_system_v1_point_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetUserRankRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetUserRankRequest) GetMetric() LeaderboardMetric {
+	if x != nil {
+		return x.Metric
+	}
+	return LeaderboardMetric_LEADERBOARD_METRIC_UNSPECIFIED
+}
+
+func (x *GetUserRankRequest) GetPeriod() LeaderboardPeriod {
+	if x != nil {
+		return x.Period
+	}
+	return LeaderboardPeriod_LEADERBOARD_PERIOD_UNSPECIFIED
+}
+
+func (x *GetUserRankRequest) GetUser() *v1.UserRef {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+// 用户名次响应
+type GetUserRankResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entry         *LeaderboardEntry      `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"` // 上榜总人数
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserRankResponse) Reset() {
+	*x = GetUserRankResponse{}
+	mi := &file_points_system_v1_point_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserRankResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserRankResponse) ProtoMessage() {}
+
+func (x *GetUserRankResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_points_system_v1_point_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserRankResponse.ProtoReflect.Descriptor instead.
+func (*GetUserRankResponse) Descriptor() ([]byte, []int) {
+	return file_points_system_v1_point_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetUserRankResponse) GetEntry() *LeaderboardEntry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+func (x *GetUserRankResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+// 积分预留
+type Reservation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Points        int64                  `protobuf:"varint,3,opt,name=points,proto3" json:"points,omitempty"` // 预留的积分
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`  // 用途（如"兑换商品"）
+	Status        ReservationStatus      `protobuf:"varint,5,opt,name=status,proto3,enum=mundo.system.point.ReservationStatus" json:"status,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // 过期时间，过期未确认自动退回
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Reservation) Reset() {
+	*x = Reservation{}
+	mi := &file_points_system_v1_point_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Reservation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reservation) ProtoMessage() {}
+
+func (x *Reservation) ProtoReflect() protoreflect.Message {
+	mi := &file_points_system_v1_point_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reservation.ProtoReflect.Descriptor instead.
+func (*Reservation) Descriptor() ([]byte, []int) {
+	return file_points_system_v1_point_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *Reservation) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Reservation) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Reservation) GetPoints() int64 {
+	if x != nil {
+		return x.Points
+	}
+	return 0
+}
+
+func (x *Reservation) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Reservation) GetStatus() ReservationStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ReservationStatus_RESERVATION_STATUS_UNSPECIFIED
+}
+
+func (x *Reservation) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *Reservation) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// 预留积分请求
+type ReservePointsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UserId         string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Points         int64                  `protobuf:"varint,2,opt,name=points,proto3" json:"points,omitempty"`                                      // 预留的积分，必须大于 0
+	Reason         string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`                                       // 用途
+	TtlSeconds     int32                  `protobuf:"varint,4,opt,name=ttl_seconds,json=ttlSeconds,proto3" json:"ttl_seconds,omitempty"`            // 预留有效期（秒），默认 300
+	IdempotencyKey string                 `protobuf:"bytes,5,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"` // 幂等键，相同键的重复请求只生效一次
+	User           *v1.UserRef            `protobuf:"bytes,6,opt,name=user,proto3" json:"user,omitempty"`                                           // 统一用户标识，设置时优先于 user_id
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ReservePointsRequest) Reset() {
+	*x = ReservePointsRequest{}
+	mi := &file_points_system_v1_point_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReservePointsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReservePointsRequest) ProtoMessage() {}
+
+func (x *ReservePointsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_points_system_v1_point_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReservePointsRequest.ProtoReflect.Descriptor instead.
+func (*ReservePointsRequest) Descriptor() ([]byte, []int) {
+	return file_points_system_v1_point_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ReservePointsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ReservePointsRequest) GetPoints() int64 {
+	if x != nil {
+		return x.Points
+	}
+	return 0
+}
+
+func (x *ReservePointsRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ReservePointsRequest) GetTtlSeconds() int32 {
+	if x != nil {
+		return x.TtlSeconds
+	}
+	return 0
+}
+
+func (x *ReservePointsRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *ReservePointsRequest) GetUser() *v1.UserRef {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+// 预留积分响应，积分不足时 error_code 为 POINTS_INSUFFICIENT
+type ReservePointsResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Success         bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message         string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	ErrorCode       ErrorCode              `protobuf:"varint,3,opt,name=error_code,json=errorCode,proto3,enum=mundo.system.point.ErrorCode" json:"error_code,omitempty"`
+	Reservation     *Reservation           `protobuf:"bytes,4,opt,name=reservation,proto3" json:"reservation,omitempty"`
+	AvailablePoints int64                  `protobuf:"varint,5,opt,name=available_points,json=availablePoints,proto3" json:"available_points,omitempty"` // 预留后的可用积分
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ReservePointsResponse) Reset() {
+	*x = ReservePointsResponse{}
+	mi := &file_points_system_v1_point_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReservePointsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReservePointsResponse) ProtoMessage() {}
+
+func (x *ReservePointsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_points_system_v1_point_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReservePointsResponse.ProtoReflect.Descriptor instead.
+func (*ReservePointsResponse) Descriptor() ([]byte, []int) {
+	return file_points_system_v1_point_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ReservePointsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ReservePointsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ReservePointsResponse) GetErrorCode() ErrorCode {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ErrorCode_UNKNOWN_ERROR
+}
+
+func (x *ReservePointsResponse) GetReservation() *Reservation {
+	if x != nil {
+		return x.Reservation
+	}
+	return nil
+}
+
+func (x *ReservePointsResponse) GetAvailablePoints() int64 {
+	if x != nil {
+		return x.AvailablePoints
+	}
+	return 0
+}
+
+// 确认扣除预留积分请求
+type CommitReservationRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ReservationId  string                 `protobuf:"bytes,1,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	UserId         string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	IdempotencyKey string                 `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"` // 幂等键，相同键的重复请求只生效一次
+	User           *v1.UserRef            `protobuf:"bytes,4,opt,name=user,proto3" json:"user,omitempty"`                                           // 统一用户标识，设置时优先于 user_id
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CommitReservationRequest) Reset() {
+	*x = CommitReservationRequest{}
+	mi := &file_points_system_v1_point_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommitReservationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitReservationRequest) ProtoMessage() {}
+
+func (x *CommitReservationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_points_system_v1_point_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitReservationRequest.ProtoReflect.Descriptor instead.
+func (*CommitReservationRequest) Descriptor() ([]byte, []int) {
+	return file_points_system_v1_point_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *CommitReservationRequest) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+func (x *CommitReservationRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CommitReservationRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *CommitReservationRequest) GetUser() *v1.UserRef {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+// 取消预留请求
+type CancelReservationRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ReservationId  string                 `protobuf:"bytes,1,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	UserId         string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Reason         string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`                                       // 取消原因
+	IdempotencyKey string                 `protobuf:"bytes,4,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"` // 幂等键，相同键的重复请求只生效一次
+	User           *v1.UserRef            `protobuf:"bytes,5,opt,name=user,proto3" json:"user,omitempty"`                                           // 统一用户标识，设置时优先于 user_id
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CancelReservationRequest) Reset() {
+	*x = CancelReservationRequest{}
+	mi := &file_points_system_v1_point_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelReservationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelReservationRequest) ProtoMessage() {}
+
+func (x *CancelReservationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_points_system_v1_point_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelReservationRequest.ProtoReflect.Descriptor instead.
+func (*CancelReservationRequest) Descriptor() ([]byte, []int) {
+	return file_points_system_v1_point_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *CancelReservationRequest) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+func (x *CancelReservationRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CancelReservationRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *CancelReservationRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *CancelReservationRequest) GetUser() *v1.UserRef {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+// 预留操作响应
+type ReservationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	ErrorCode     ErrorCode              `protobuf:"varint,3,opt,name=error_code,json=errorCode,proto3,enum=mundo.system.point.ErrorCode" json:"error_code,omitempty"`
+	Reservation   *Reservation           `protobuf:"bytes,4,opt,name=reservation,proto3" json:"reservation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReservationResponse) Reset() {
+	*x = ReservationResponse{}
+	mi := &file_points_system_v1_point_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReservationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReservationResponse) ProtoMessage() {}
+
+func (x *ReservationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_points_system_v1_point_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReservationResponse.ProtoReflect.Descriptor instead.
+func (*ReservationResponse) Descriptor() ([]byte, []int) {
+	return file_points_system_v1_point_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ReservationResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ReservationResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ReservationResponse) GetErrorCode() ErrorCode {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ErrorCode_UNKNOWN_ERROR
+}
+
+func (x *ReservationResponse) GetReservation() *Reservation {
+	if x != nil {
+		return x.Reservation
+	}
+	return nil
+}
+
+var File_points_system_v1_point_proto protoreflect.FileDescriptor
 
 const file_points_system_v1_point_proto_rawDesc = "" +
 	"\n" +
-	"\x1cpoints-system/v1/point.proto\x12\x12mundo.system.point\"\x84\x02\n" +
+	"\x1cpoints-system/v1/point.proto\x12\x12mundo.system.point\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x16common/v1/common.proto\"\xd5\x02\n" +
 	"\bUserInfo\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
 	"\busername\x18\x02 \x01(\tR\busername\x12\x1e\n" +
@@ -673,25 +2591,46 @@ const file_points_system_v1_point_proto_rawDesc = "" +
 	"\x05level\x18\x05 \x01(\x05R\x05level\x12\x1b\n" +
 	"\tis_signed\x18\x06 \x01(\bR\bisSigned\x120\n" +
 	"\x14continuous_sign_days\x18\a \x01(\x05R\x12continuousSignDays\x12&\n" +
-	"\x0ftotal_sign_days\x18\b \x01(\x05R\rtotalSignDays\"\x94\x01\n" +
+	"\x0ftotal_sign_days\x18\b \x01(\x05R\rtotalSignDays\x12'\n" +
+	"\x0freserved_points\x18\t \x01(\x03R\x0ereservedPoints\x12&\n" +
+	"\x04user\x18\n" +
+	" \x01(\v2\x12.common.v1.UserRefR\x04user\"\xe5\x01\n" +
 	"\x13UpdatePointsRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12!\n" +
 	"\fdelta_points\x18\x02 \x01(\x03R\vdeltaPoints\x12)\n" +
 	"\x10delta_experience\x18\x03 \x01(\x03R\x0fdeltaExperience\x12\x16\n" +
-	"\x06reason\x18\x04 \x01(\tR\x06reason\"\x82\x01\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12'\n" +
+	"\x0fidempotency_key\x18\x05 \x01(\tR\x0eidempotencyKey\x12&\n" +
+	"\x04user\x18\x06 \x01(\v2\x12.common.v1.UserRefR\x04user\"\x82\x01\n" +
 	"\x0eCommonResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12<\n" +
 	"\n" +
-	"error_code\x18\x03 \x01(\x0e2\x1d.mundo.system.point.ErrorCodeR\terrorCode\"e\n" +
+	"error_code\x18\x03 \x01(\x0e2\x1d.mundo.system.point.ErrorCodeR\terrorCode\"\xeb\x01\n" +
 	"\vLikeRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
 	"\apost_id\x18\x02 \x01(\tR\x06postId\x12$\n" +
-	"\x0etarget_user_id\x18\x03 \x01(\tR\ftargetUserId\"-\n" +
+	"\x0etarget_user_id\x18\x03 \x01(\tR\ftargetUserId\x12'\n" +
+	"\x0fidempotency_key\x18\x04 \x01(\tR\x0eidempotencyKey\x12&\n" +
+	"\x04user\x18\x05 \x01(\v2\x12.common.v1.UserRefR\x04user\x123\n" +
+	"\vtarget_user\x18\x06 \x01(\v2\x12.common.v1.UserRefR\n" +
+	"targetUser\"U\n" +
 	"\x12GetUserInfoRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\"&\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12&\n" +
+	"\x04user\x18\x02 \x01(\v2\x12.common.v1.UserRefR\x04user\"^\n" +
+	"\x17BatchGetUserInfoRequest\x12\x19\n" +
+	"\buser_ids\x18\x01 \x03(\tR\auserIds\x12(\n" +
+	"\x05users\x18\x02 \x03(\v2\x12.common.v1.UserRefR\x05users\"\xc1\x01\n" +
+	"\x18BatchGetUserInfoResponse\x12M\n" +
+	"\x05users\x18\x01 \x03(\v27.mundo.system.point.BatchGetUserInfoResponse.UsersEntryR\x05users\x1aV\n" +
+	"\n" +
+	"UsersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x122\n" +
+	"\x05value\x18\x02 \x01(\v2\x1c.mundo.system.point.UserInfoR\x05value:\x028\x01\"w\n" +
 	"\vSignRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\"\xea\x01\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12'\n" +
+	"\x0fidempotency_key\x18\x02 \x01(\tR\x0eidempotencyKey\x12&\n" +
+	"\x04user\x18\x03 \x01(\v2\x12.common.v1.UserRefR\x04user\"\xea\x01\n" +
 	"\fSignResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12<\n" +
@@ -711,22 +2650,193 @@ const file_points_system_v1_point_proto_rawDesc = "" +
 	"\x11LevelDistribution\x12\x14\n" +
 	"\x05level\x18\x01 \x01(\x05R\x05level\x12\x1d\n" +
 	"\n" +
-	"user_count\x18\x02 \x01(\x03R\tuserCount*r\n" +
+	"user_count\x18\x02 \x01(\x03R\tuserCount\"\xe9\x01\n" +
+	"\x11AdminStatsRequest\x129\n" +
+	"\n" +
+	"start_time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12F\n" +
+	"\vgranularity\x18\x03 \x01(\x0e2$.mundo.system.point.StatsGranularityR\vgranularity\x12\x1a\n" +
+	"\btimezone\x18\x04 \x01(\tR\btimezone\"i\n" +
+	"\x0eLevelMigration\x12\x1d\n" +
+	"\n" +
+	"from_level\x18\x01 \x01(\x05R\tfromLevel\x12\x19\n" +
+	"\bto_level\x18\x02 \x01(\x05R\atoLevel\x12\x1d\n" +
+	"\n" +
+	"user_count\x18\x03 \x01(\x03R\tuserCount\"\x8b\x02\n" +
+	"\x10AdminStatsBucket\x129\n" +
+	"\n" +
+	"start_time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x12#\n" +
+	"\rpoints_issued\x18\x02 \x01(\x03R\fpointsIssued\x12!\n" +
+	"\fpoints_spent\x18\x03 \x01(\x03R\vpointsSpent\x12%\n" +
+	"\x0eactive_signers\x18\x04 \x01(\x03R\ractiveSigners\x12M\n" +
+	"\x10level_migrations\x18\x05 \x03(\v2\".mundo.system.point.LevelMigrationR\x0flevelMigrations\"\x82\x02\n" +
+	"\x12AdminStatsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12<\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\x0e2\x1d.mundo.system.point.ErrorCodeR\terrorCode\x12:\n" +
+	"\bsnapshot\x18\x04 \x01(\v2\x1e.mundo.system.point.AdminStatsR\bsnapshot\x12>\n" +
+	"\abuckets\x18\x05 \x03(\v2$.mundo.system.point.AdminStatsBucketR\abuckets\"\x81\x02\n" +
+	"\x10PointTransaction\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12!\n" +
+	"\fdelta_points\x18\x03 \x01(\x03R\vdeltaPoints\x12)\n" +
+	"\x10delta_experience\x18\x04 \x01(\x03R\x0fdeltaExperience\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x12#\n" +
+	"\rbalance_after\x18\x06 \x01(\x03R\fbalanceAfter\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xa7\x02\n" +
+	"\x1cListPointTransactionsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x18\n" +
+	"\areasons\x18\x02 \x03(\tR\areasons\x129\n" +
+	"\n" +
+	"start_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12\x1b\n" +
+	"\tpage_size\x18\x05 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x06 \x01(\tR\tpageToken\x12&\n" +
+	"\x04user\x18\a \x01(\v2\x12.common.v1.UserRefR\x04user\"\x91\x01\n" +
+	"\x1dListPointTransactionsResponse\x12H\n" +
+	"\ftransactions\x18\x01 \x03(\v2$.mundo.system.point.PointTransactionR\ftransactions\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"n\n" +
+	"\x13PreviewAwardRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12&\n" +
+	"\x04user\x18\x03 \x01(\v2\x12.common.v1.UserRefR\x04user\"\xbc\x02\n" +
+	"\x14PreviewAwardResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12<\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\x0e2\x1d.mundo.system.point.ErrorCodeR\terrorCode\x12\x16\n" +
+	"\x06points\x18\x04 \x01(\x03R\x06points\x12\x1e\n" +
+	"\n" +
+	"experience\x18\x05 \x01(\x03R\n" +
+	"experience\x12\x1e\n" +
+	"\n" +
+	"multiplier\x18\x06 \x01(\x01R\n" +
+	"multiplier\x12\x16\n" +
+	"\x06capped\x18\a \x01(\bR\x06capped\x12!\n" +
+	"\flevel_before\x18\b \x01(\x05R\vlevelBefore\x12\x1f\n" +
+	"\vlevel_after\x18\t \x01(\x05R\n" +
+	"levelAfter\"\x87\x01\n" +
+	"\x10LeaderboardEntry\x12\x12\n" +
+	"\x04rank\x18\x01 \x01(\x03R\x04rank\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x03 \x01(\tR\busername\x12\x14\n" +
+	"\x05score\x18\x04 \x01(\x03R\x05score\x12\x14\n" +
+	"\x05level\x18\x05 \x01(\x05R\x05level\"\x84\x02\n" +
+	"\x15GetLeaderboardRequest\x12=\n" +
+	"\x06metric\x18\x01 \x01(\x0e2%.mundo.system.point.LeaderboardMetricR\x06metric\x12=\n" +
+	"\x06period\x18\x02 \x01(\x0e2%.mundo.system.point.LeaderboardPeriodR\x06period\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12\x17\n" +
+	"\auser_id\x18\x05 \x01(\tR\x06userId\x12&\n" +
+	"\x04user\x18\x06 \x01(\v2\x12.common.v1.UserRefR\x04user\"\xa8\x01\n" +
+	"\x16GetLeaderboardResponse\x12>\n" +
+	"\aentries\x18\x01 \x03(\v2$.mundo.system.point.LeaderboardEntryR\aentries\x128\n" +
+	"\x04self\x18\x02 \x01(\v2$.mundo.system.point.LeaderboardEntryR\x04self\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x03R\x05total\"\xd3\x01\n" +
+	"\x12GetUserRankRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12=\n" +
+	"\x06metric\x18\x02 \x01(\x0e2%.mundo.system.point.LeaderboardMetricR\x06metric\x12=\n" +
+	"\x06period\x18\x03 \x01(\x0e2%.mundo.system.point.LeaderboardPeriodR\x06period\x12&\n" +
+	"\x04user\x18\x04 \x01(\v2\x12.common.v1.UserRefR\x04user\"g\n" +
+	"\x13GetUserRankResponse\x12:\n" +
+	"\x05entry\x18\x01 \x01(\v2$.mundo.system.point.LeaderboardEntryR\x05entry\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\"\x9b\x02\n" +
+	"\vReservation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06points\x18\x03 \x01(\x03R\x06points\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12=\n" +
+	"\x06status\x18\x05 \x01(\x0e2%.mundo.system.point.ReservationStatusR\x06status\x129\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xd1\x01\n" +
+	"\x14ReservePointsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06points\x18\x02 \x01(\x03R\x06points\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x1f\n" +
+	"\vttl_seconds\x18\x04 \x01(\x05R\n" +
+	"ttlSeconds\x12'\n" +
+	"\x0fidempotency_key\x18\x05 \x01(\tR\x0eidempotencyKey\x12&\n" +
+	"\x04user\x18\x06 \x01(\v2\x12.common.v1.UserRefR\x04user\"\xf7\x01\n" +
+	"\x15ReservePointsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12<\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\x0e2\x1d.mundo.system.point.ErrorCodeR\terrorCode\x12A\n" +
+	"\vreservation\x18\x04 \x01(\v2\x1f.mundo.system.point.ReservationR\vreservation\x12)\n" +
+	"\x10available_points\x18\x05 \x01(\x03R\x0favailablePoints\"\xab\x01\n" +
+	"\x18CommitReservationRequest\x12%\n" +
+	"\x0ereservation_id\x18\x01 \x01(\tR\rreservationId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12'\n" +
+	"\x0fidempotency_key\x18\x03 \x01(\tR\x0eidempotencyKey\x12&\n" +
+	"\x04user\x18\x04 \x01(\v2\x12.common.v1.UserRefR\x04user\"\xc3\x01\n" +
+	"\x18CancelReservationRequest\x12%\n" +
+	"\x0ereservation_id\x18\x01 \x01(\tR\rreservationId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12'\n" +
+	"\x0fidempotency_key\x18\x04 \x01(\tR\x0eidempotencyKey\x12&\n" +
+	"\x04user\x18\x05 \x01(\v2\x12.common.v1.UserRefR\x04user\"\xca\x01\n" +
+	"\x13ReservationResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12<\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\x0e2\x1d.mundo.system.point.ErrorCodeR\terrorCode\x12A\n" +
+	"\vreservation\x18\x04 \x01(\v2\x1f.mundo.system.point.ReservationR\vreservation*\x89\x01\n" +
+	"\x10StatsGranularity\x12!\n" +
+	"\x1dSTATS_GRANULARITY_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15STATS_GRANULARITY_DAY\x10\x01\x12\x1a\n" +
+	"\x16STATS_GRANULARITY_WEEK\x10\x02\x12\x1b\n" +
+	"\x17STATS_GRANULARITY_MONTH\x10\x03*\x9d\x01\n" +
+	"\x11LeaderboardMetric\x12\"\n" +
+	"\x1eLEADERBOARD_METRIC_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19LEADERBOARD_METRIC_POINTS\x10\x01\x12!\n" +
+	"\x1dLEADERBOARD_METRIC_EXPERIENCE\x10\x02\x12\"\n" +
+	"\x1eLEADERBOARD_METRIC_SIGN_STREAK\x10\x03*\xb5\x01\n" +
+	"\x11LeaderboardPeriod\x12\"\n" +
+	"\x1eLEADERBOARD_PERIOD_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18LEADERBOARD_PERIOD_DAILY\x10\x01\x12\x1d\n" +
+	"\x19LEADERBOARD_PERIOD_WEEKLY\x10\x02\x12\x1e\n" +
+	"\x1aLEADERBOARD_PERIOD_MONTHLY\x10\x03\x12\x1f\n" +
+	"\x1bLEADERBOARD_PERIOD_ALL_TIME\x10\x04*\xdd\x01\n" +
 	"\tErrorCode\x12\x11\n" +
 	"\rUNKNOWN_ERROR\x10\x00\x12\x17\n" +
 	"\x13POINTS_INSUFFICIENT\x10\x01\x12\x14\n" +
 	"\x10OPERATION_FAILED\x10\x02\x12\x13\n" +
 	"\x0fINVALID_REQUEST\x10\x03\x12\x0e\n" +
 	"\n" +
-	"NONE_ERROR\x10\x042\xc6\x03\n" +
-	"\vUserService\x12K\n" +
-	"\x04Sign\x12\x1f.mundo.system.point.SignRequest\x1a\".mundo.system.point.CommonResponse\x12h\n" +
+	"NONE_ERROR\x10\x04\x12\x19\n" +
+	"\x15RESERVATION_NOT_FOUND\x10\x05\x12\x17\n" +
+	"\x13RESERVATION_EXPIRED\x10\x06\x12\x1e\n" +
+	"\x1aRESERVATION_STATE_CONFLICT\x10\a\x12\x15\n" +
+	"\x11PERMISSION_DENIED\x10\b*\xbb\x01\n" +
+	"\x11ReservationStatus\x12\"\n" +
+	"\x1eRESERVATION_STATUS_UNSPECIFIED\x10\x00\x12\x1e\n" +
+	"\x1aRESERVATION_STATUS_PENDING\x10\x01\x12 \n" +
+	"\x1cRESERVATION_STATUS_COMMITTED\x10\x02\x12 \n" +
+	"\x1cRESERVATION_STATUS_CANCELLED\x10\x03\x12\x1e\n" +
+	"\x1aRESERVATION_STATUS_EXPIRED\x10\x042\xd6\v\n" +
+	"\vUserService\x12P\n" +
+	"\x04Sign\x12\x1f.mundo.system.point.SignRequest\x1a\".mundo.system.point.CommonResponse\"\x03\x88\x02\x01\x12K\n" +
+	"\x06SignV2\x12\x1f.mundo.system.point.SignRequest\x1a .mundo.system.point.SignResponse\x12h\n" +
 	"\x19UpdatePointsAndExperience\x12'.mundo.system.point.UpdatePointsRequest\x1a\".mundo.system.point.CommonResponse\x12S\n" +
-	"\vGetUserInfo\x12&.mundo.system.point.GetUserInfoRequest\x1a\x1c.mundo.system.point.UserInfo\x12R\n" +
-	"\vProcessLike\x12\x1f.mundo.system.point.LikeRequest\x1a\".mundo.system.point.CommonResponse\x12W\n" +
-	"\rGetAdminStats\x12&.mundo.system.point.GetUserInfoRequest\x1a\x1e.mundo.system.point.AdminStatsB\xc9\x01\n" +
+	"\vGetUserInfo\x12&.mundo.system.point.GetUserInfoRequest\x1a\x1c.mundo.system.point.UserInfo\x12m\n" +
+	"\x10BatchGetUserInfo\x12+.mundo.system.point.BatchGetUserInfoRequest\x1a,.mundo.system.point.BatchGetUserInfoResponse\x12R\n" +
+	"\vProcessLike\x12\x1f.mundo.system.point.LikeRequest\x1a\".mundo.system.point.CommonResponse\x12\\\n" +
+	"\rGetAdminStats\x12&.mundo.system.point.GetUserInfoRequest\x1a\x1e.mundo.system.point.AdminStats\"\x03\x88\x02\x01\x12`\n" +
+	"\x0fGetAdminStatsV2\x12%.mundo.system.point.AdminStatsRequest\x1a&.mundo.system.point.AdminStatsResponse\x12|\n" +
+	"\x15ListPointTransactions\x120.mundo.system.point.ListPointTransactionsRequest\x1a1.mundo.system.point.ListPointTransactionsResponse\x12d\n" +
+	"\rReservePoints\x12(.mundo.system.point.ReservePointsRequest\x1a).mundo.system.point.ReservePointsResponse\x12j\n" +
+	"\x11CommitReservation\x12,.mundo.system.point.CommitReservationRequest\x1a'.mundo.system.point.ReservationResponse\x12j\n" +
+	"\x11CancelReservation\x12,.mundo.system.point.CancelReservationRequest\x1a'.mundo.system.point.ReservationResponse\x12a\n" +
+	"\fPreviewAward\x12'.mundo.system.point.PreviewAwardRequest\x1a(.mundo.system.point.PreviewAwardResponse\x12g\n" +
+	"\x0eGetLeaderboard\x12).mundo.system.point.GetLeaderboardRequest\x1a*.mundo.system.point.GetLeaderboardResponse\x12^\n" +
+	"\vGetUserRank\x12&.mundo.system.point.GetUserRankRequest\x1a'.mundo.system.point.GetUserRankResponseB\xc5\x01\n" +
 	"\x16com.mundo.system.pointB\n" +
-	"PointProtoP\x01Z9github.com/betterde/focusly/internal/gen/points-system/v1\xa2\x02\x03MSP\xaa\x02\x12Mundo.System.Point\xca\x02\x12Mundo\\System\\Point\xe2\x02\x1eMundo\\System\\Point\\GPBMetadata\xea\x02\x14Mundo::System::Pointb\x06proto3"
+	"PointProtoP\x01Z5github.com/trancecho/mundo-proto-sdk/points-system/v1\xa2\x02\x03MSP\xaa\x02\x12Mundo.System.Point\xca\x02\x12Mundo\\System\\Point\xe2\x02\x1eMundo\\System\\Point\\GPBMetadata\xea\x02\x14Mundo::System::Pointb\x06proto3"
 
 var (
 	file_points_system_v1_point_proto_rawDescOnce sync.Once
@@ -740,39 +2850,131 @@ func file_points_system_v1_point_proto_rawDescGZIP() []byte {
 	return file_points_system_v1_point_proto_rawDescData
 }
 
-var file_points_system_v1_point_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_points_system_v1_point_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_points_system_v1_point_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_points_system_v1_point_proto_msgTypes = make([]protoimpl.MessageInfo, 32)
 var file_points_system_v1_point_proto_goTypes = []any{
-	(ErrorCode)(0),              // 0: mundo.system.point.ErrorCode
-	(*UserInfo)(nil),            // 1: mundo.system.point.UserInfo
-	(*UpdatePointsRequest)(nil), // 2: mundo.system.point.UpdatePointsRequest
-	(*CommonResponse)(nil),      // 3: mundo.system.point.CommonResponse
-	(*LikeRequest)(nil),         // 4: mundo.system.point.LikeRequest
-	(*GetUserInfoRequest)(nil),  // 5: mundo.system.point.GetUserInfoRequest
-	(*SignRequest)(nil),         // 6: mundo.system.point.SignRequest
-	(*SignResponse)(nil),        // 7: mundo.system.point.SignResponse
-	(*AdminStats)(nil),          // 8: mundo.system.point.AdminStats
-	(*LevelDistribution)(nil),   // 9: mundo.system.point.LevelDistribution
+	(StatsGranularity)(0),                 // 0: mundo.system.point.StatsGranularity
+	(LeaderboardMetric)(0),                // 1: mundo.system.point.LeaderboardMetric
+	(LeaderboardPeriod)(0),                // 2: mundo.system.point.LeaderboardPeriod
+	(ErrorCode)(0),                        // 3: mundo.system.point.ErrorCode
+	(ReservationStatus)(0),                // 4: mundo.system.point.ReservationStatus
+	(*UserInfo)(nil),                      // 5: mundo.system.point.UserInfo
+	(*UpdatePointsRequest)(nil),           // 6: mundo.system.point.UpdatePointsRequest
+	(*CommonResponse)(nil),                // 7: mundo.system.point.CommonResponse
+	(*LikeRequest)(nil),                   // 8: mundo.system.point.LikeRequest
+	(*GetUserInfoRequest)(nil),            // 9: mundo.system.point.GetUserInfoRequest
+	(*BatchGetUserInfoRequest)(nil),       // 10: mundo.system.point.BatchGetUserInfoRequest
+	(*BatchGetUserInfoResponse)(nil),      // 11: mundo.system.point.BatchGetUserInfoResponse
+	(*SignRequest)(nil),                   // 12: mundo.system.point.SignRequest
+	(*SignResponse)(nil),                  // 13: mundo.system.point.SignResponse
+	(*AdminStats)(nil),                    // 14: mundo.system.point.AdminStats
+	(*LevelDistribution)(nil),             // 15: mundo.system.point.LevelDistribution
+	(*AdminStatsRequest)(nil),             // 16: mundo.system.point.AdminStatsRequest
+	(*LevelMigration)(nil),                // 17: mundo.system.point.LevelMigration
+	(*AdminStatsBucket)(nil),              // 18: mundo.system.point.AdminStatsBucket
+	(*AdminStatsResponse)(nil),            // 19: mundo.system.point.AdminStatsResponse
+	(*PointTransaction)(nil),              // 20: mundo.system.point.PointTransaction
+	(*ListPointTransactionsRequest)(nil),  // 21: mundo.system.point.ListPointTransactionsRequest
+	(*ListPointTransactionsResponse)(nil), // 22: mundo.system.point.ListPointTransactionsResponse
+	(*PreviewAwardRequest)(nil),           // 23: mundo.system.point.PreviewAwardRequest
+	(*PreviewAwardResponse)(nil),          // 24: mundo.system.point.PreviewAwardResponse
+	(*LeaderboardEntry)(nil),              // 25: mundo.system.point.LeaderboardEntry
+	(*GetLeaderboardRequest)(nil),         // 26: mundo.system.point.GetLeaderboardRequest
+	(*GetLeaderboardResponse)(nil),        // 27: mundo.system.point.GetLeaderboardResponse
+	(*GetUserRankRequest)(nil),            // 28: mundo.system.point.GetUserRankRequest
+	(*GetUserRankResponse)(nil),           // 29: mundo.system.point.GetUserRankResponse
+	(*Reservation)(nil),                   // 30: mundo.system.point.Reservation
+	(*ReservePointsRequest)(nil),          // 31: mundo.system.point.ReservePointsRequest
+	(*ReservePointsResponse)(nil),         // 32: mundo.system.point.ReservePointsResponse
+	(*CommitReservationRequest)(nil),      // 33: mundo.system.point.CommitReservationRequest
+	(*CancelReservationRequest)(nil),      // 34: mundo.system.point.CancelReservationRequest
+	(*ReservationResponse)(nil),           // 35: mundo.system.point.ReservationResponse
+	nil,                                   // 36: mundo.system.point.BatchGetUserInfoResponse.UsersEntry
+	(*v1.UserRef)(nil),                    // 37: common.v1.UserRef
+	(*timestamppb.Timestamp)(nil),         // 38: google.protobuf.Timestamp
 }
 var file_points_system_v1_point_proto_depIdxs = []int32{
-	0, // 0: mundo.system.point.CommonResponse.error_code:type_name -> mundo.system.point.ErrorCode
-	0, // 1: mundo.system.point.SignResponse.error_code:type_name -> mundo.system.point.ErrorCode
-	9, // 2: mundo.system.point.AdminStats.level_distribution:type_name -> mundo.system.point.LevelDistribution
-	6, // 3: mundo.system.point.UserService.Sign:input_type -> mundo.system.point.SignRequest
-	2, // 4: mundo.system.point.UserService.UpdatePointsAndExperience:input_type -> mundo.system.point.UpdatePointsRequest
-	5, // 5: mundo.system.point.UserService.GetUserInfo:input_type -> mundo.system.point.GetUserInfoRequest
-	4, // 6: mundo.system.point.UserService.ProcessLike:input_type -> mundo.system.point.LikeRequest
-	5, // 7: mundo.system.point.UserService.GetAdminStats:input_type -> mundo.system.point.GetUserInfoRequest
-	3, // 8: mundo.system.point.UserService.Sign:output_type -> mundo.system.point.CommonResponse
-	3, // 9: mundo.system.point.UserService.UpdatePointsAndExperience:output_type -> mundo.system.point.CommonResponse
-	1, // 10: mundo.system.point.UserService.GetUserInfo:output_type -> mundo.system.point.UserInfo
-	3, // 11: mundo.system.point.UserService.ProcessLike:output_type -> mundo.system.point.CommonResponse
-	8, // 12: mundo.system.point.UserService.GetAdminStats:output_type -> mundo.system.point.AdminStats
-	8, // [8:13] is the sub-list for method output_type
-	3, // [3:8] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	37, // 0: mundo.system.point.UserInfo.user:type_name -> common.v1.UserRef
+	37, // 1: mundo.system.point.UpdatePointsRequest.user:type_name -> common.v1.UserRef
+	3,  // 2: mundo.system.point.CommonResponse.error_code:type_name -> mundo.system.point.ErrorCode
+	37, // 3: mundo.system.point.LikeRequest.user:type_name -> common.v1.UserRef
+	37, // 4: mundo.system.point.LikeRequest.target_user:type_name -> common.v1.UserRef
+	37, // 5: mundo.system.point.GetUserInfoRequest.user:type_name -> common.v1.UserRef
+	37, // 6: mundo.system.point.BatchGetUserInfoRequest.users:type_name -> common.v1.UserRef
+	36, // 7: mundo.system.point.BatchGetUserInfoResponse.users:type_name -> mundo.system.point.BatchGetUserInfoResponse.UsersEntry
+	37, // 8: mundo.system.point.SignRequest.user:type_name -> common.v1.UserRef
+	3,  // 9: mundo.system.point.SignResponse.error_code:type_name -> mundo.system.point.ErrorCode
+	15, // 10: mundo.system.point.AdminStats.level_distribution:type_name -> mundo.system.point.LevelDistribution
+	38, // 11: mundo.system.point.AdminStatsRequest.start_time:type_name -> google.protobuf.Timestamp
+	38, // 12: mundo.system.point.AdminStatsRequest.end_time:type_name -> google.protobuf.Timestamp
+	0,  // 13: mundo.system.point.AdminStatsRequest.granularity:type_name -> mundo.system.point.StatsGranularity
+	38, // 14: mundo.system.point.AdminStatsBucket.start_time:type_name -> google.protobuf.Timestamp
+	17, // 15: mundo.system.point.AdminStatsBucket.level_migrations:type_name -> mundo.system.point.LevelMigration
+	3,  // 16: mundo.system.point.AdminStatsResponse.error_code:type_name -> mundo.system.point.ErrorCode
+	14, // 17: mundo.system.point.AdminStatsResponse.snapshot:type_name -> mundo.system.point.AdminStats
+	18, // 18: mundo.system.point.AdminStatsResponse.buckets:type_name -> mundo.system.point.AdminStatsBucket
+	38, // 19: mundo.system.point.PointTransaction.created_at:type_name -> google.protobuf.Timestamp
+	38, // 20: mundo.system.point.ListPointTransactionsRequest.start_time:type_name -> google.protobuf.Timestamp
+	38, // 21: mundo.system.point.ListPointTransactionsRequest.end_time:type_name -> google.protobuf.Timestamp
+	37, // 22: mundo.system.point.ListPointTransactionsRequest.user:type_name -> common.v1.UserRef
+	20, // 23: mundo.system.point.ListPointTransactionsResponse.transactions:type_name -> mundo.system.point.PointTransaction
+	37, // 24: mundo.system.point.PreviewAwardRequest.user:type_name -> common.v1.UserRef
+	3,  // 25: mundo.system.point.PreviewAwardResponse.error_code:type_name -> mundo.system.point.ErrorCode
+	1,  // 26: mundo.system.point.GetLeaderboardRequest.metric:type_name -> mundo.system.point.LeaderboardMetric
+	2,  // 27: mundo.system.point.GetLeaderboardRequest.period:type_name -> mundo.system.point.LeaderboardPeriod
+	37, // 28: mundo.system.point.GetLeaderboardRequest.user:type_name -> common.v1.UserRef
+	25, // 29: mundo.system.point.GetLeaderboardResponse.entries:type_name -> mundo.system.point.LeaderboardEntry
+	25, // 30: mundo.system.point.GetLeaderboardResponse.self:type_name -> mundo.system.point.LeaderboardEntry
+	1,  // 31: mundo.system.point.GetUserRankRequest.metric:type_name -> mundo.system.point.LeaderboardMetric
+	2,  // 32: mundo.system.point.GetUserRankRequest.period:type_name -> mundo.system.point.LeaderboardPeriod
+	37, // 33: mundo.system.point.GetUserRankRequest.user:type_name -> common.v1.UserRef
+	25, // 34: mundo.system.point.GetUserRankResponse.entry:type_name -> mundo.system.point.LeaderboardEntry
+	4,  // 35: mundo.system.point.Reservation.status:type_name -> mundo.system.point.ReservationStatus
+	38, // 36: mundo.system.point.Reservation.expires_at:type_name -> google.protobuf.Timestamp
+	38, // 37: mundo.system.point.Reservation.created_at:type_name -> google.protobuf.Timestamp
+	37, // 38: mundo.system.point.ReservePointsRequest.user:type_name -> common.v1.UserRef
+	3,  // 39: mundo.system.point.ReservePointsResponse.error_code:type_name -> mundo.system.point.ErrorCode
+	30, // 40: mundo.system.point.ReservePointsResponse.reservation:type_name -> mundo.system.point.Reservation
+	37, // 41: mundo.system.point.CommitReservationRequest.user:type_name -> common.v1.UserRef
+	37, // 42: mundo.system.point.CancelReservationRequest.user:type_name -> common.v1.UserRef
+	3,  // 43: mundo.system.point.ReservationResponse.error_code:type_name -> mundo.system.point.ErrorCode
+	30, // 44: mundo.system.point.ReservationResponse.reservation:type_name -> mundo.system.point.Reservation
+	5,  // 45: mundo.system.point.BatchGetUserInfoResponse.UsersEntry.value:type_name -> mundo.system.point.UserInfo
+	12, // 46: mundo.system.point.UserService.Sign:input_type -> mundo.system.point.SignRequest
+	12, // 47: mundo.system.point.UserService.SignV2:input_type -> mundo.system.point.SignRequest
+	6,  // 48: mundo.system.point.UserService.UpdatePointsAndExperience:input_type -> mundo.system.point.UpdatePointsRequest
+	9,  // 49: mundo.system.point.UserService.GetUserInfo:input_type -> mundo.system.point.GetUserInfoRequest
+	10, // 50: mundo.system.point.UserService.BatchGetUserInfo:input_type -> mundo.system.point.BatchGetUserInfoRequest
+	8,  // 51: mundo.system.point.UserService.ProcessLike:input_type -> mundo.system.point.LikeRequest
+	9,  // 52: mundo.system.point.UserService.GetAdminStats:input_type -> mundo.system.point.GetUserInfoRequest
+	16, // 53: mundo.system.point.UserService.GetAdminStatsV2:input_type -> mundo.system.point.AdminStatsRequest
+	21, // 54: mundo.system.point.UserService.ListPointTransactions:input_type -> mundo.system.point.ListPointTransactionsRequest
+	31, // 55: mundo.system.point.UserService.ReservePoints:input_type -> mundo.system.point.ReservePointsRequest
+	33, // 56: mundo.system.point.UserService.CommitReservation:input_type -> mundo.system.point.CommitReservationRequest
+	34, // 57: mundo.system.point.UserService.CancelReservation:input_type -> mundo.system.point.CancelReservationRequest
+	23, // 58: mundo.system.point.UserService.PreviewAward:input_type -> mundo.system.point.PreviewAwardRequest
+	26, // 59: mundo.system.point.UserService.GetLeaderboard:input_type -> mundo.system.point.GetLeaderboardRequest
+	28, // 60: mundo.system.point.UserService.GetUserRank:input_type -> mundo.system.point.GetUserRankRequest
+	7,  // 61: mundo.system.point.UserService.Sign:output_type -> mundo.system.point.CommonResponse
+	13, // 62: mundo.system.point.UserService.SignV2:output_type -> mundo.system.point.SignResponse
+	7,  // 63: mundo.system.point.UserService.UpdatePointsAndExperience:output_type -> mundo.system.point.CommonResponse
+	5,  // 64: mundo.system.point.UserService.GetUserInfo:output_type -> mundo.system.point.UserInfo
+	11, // 65: mundo.system.point.UserService.BatchGetUserInfo:output_type -> mundo.system.point.BatchGetUserInfoResponse
+	7,  // 66: mundo.system.point.UserService.ProcessLike:output_type -> mundo.system.point.CommonResponse
+	14, // 67: mundo.system.point.UserService.GetAdminStats:output_type -> mundo.system.point.AdminStats
+	19, // 68: mundo.system.point.UserService.GetAdminStatsV2:output_type -> mundo.system.point.AdminStatsResponse
+	22, // 69: mundo.system.point.UserService.ListPointTransactions:output_type -> mundo.system.point.ListPointTransactionsResponse
+	32, // 70: mundo.system.point.UserService.ReservePoints:output_type -> mundo.system.point.ReservePointsResponse
+	35, // 71: mundo.system.point.UserService.CommitReservation:output_type -> mundo.system.point.ReservationResponse
+	35, // 72: mundo.system.point.UserService.CancelReservation:output_type -> mundo.system.point.ReservationResponse
+	24, // 73: mundo.system.point.UserService.PreviewAward:output_type -> mundo.system.point.PreviewAwardResponse
+	27, // 74: mundo.system.point.UserService.GetLeaderboard:output_type -> mundo.system.point.GetLeaderboardResponse
+	29, // 75: mundo.system.point.UserService.GetUserRank:output_type -> mundo.system.point.GetUserRankResponse
+	61, // [61:76] is the sub-list for method output_type
+	46, // [46:61] is the sub-list for method input_type
+	46, // [46:46] is the sub-list for extension type_name
+	46, // [46:46] is the sub-list for extension extendee
+	0,  // [0:46] is the sub-list for field type_name
 }
 
 func init() { file_points_system_v1_point_proto_init() }
@@ -785,8 +2987,8 @@ func file_points_system_v1_point_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_points_system_v1_point_proto_rawDesc), len(file_points_system_v1_point_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   9,
+			NumEnums:      5,
+			NumMessages:   32,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
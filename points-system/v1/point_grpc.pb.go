@@ -20,10 +20,20 @@ const _ = grpc.SupportPackageIsVersion9
 
 const (
 	UserService_Sign_FullMethodName                      = "/mundo.system.point.UserService/Sign"
+	UserService_SignV2_FullMethodName                    = "/mundo.system.point.UserService/SignV2"
 	UserService_UpdatePointsAndExperience_FullMethodName = "/mundo.system.point.UserService/UpdatePointsAndExperience"
 	UserService_GetUserInfo_FullMethodName               = "/mundo.system.point.UserService/GetUserInfo"
+	UserService_BatchGetUserInfo_FullMethodName          = "/mundo.system.point.UserService/BatchGetUserInfo"
 	UserService_ProcessLike_FullMethodName               = "/mundo.system.point.UserService/ProcessLike"
 	UserService_GetAdminStats_FullMethodName             = "/mundo.system.point.UserService/GetAdminStats"
+	UserService_GetAdminStatsV2_FullMethodName           = "/mundo.system.point.UserService/GetAdminStatsV2"
+	UserService_ListPointTransactions_FullMethodName     = "/mundo.system.point.UserService/ListPointTransactions"
+	UserService_ReservePoints_FullMethodName             = "/mundo.system.point.UserService/ReservePoints"
+	UserService_CommitReservation_FullMethodName         = "/mundo.system.point.UserService/CommitReservation"
+	UserService_CancelReservation_FullMethodName         = "/mundo.system.point.UserService/CancelReservation"
+	UserService_PreviewAward_FullMethodName              = "/mundo.system.point.UserService/PreviewAward"
+	UserService_GetLeaderboard_FullMethodName            = "/mundo.system.point.UserService/GetLeaderboard"
+	UserService_GetUserRank_FullMethodName               = "/mundo.system.point.UserService/GetUserRank"
 )
 
 // UserServiceClient is the client API for UserService service.
@@ -32,16 +42,39 @@ const (
 //
 // 用户服务
 type UserServiceClient interface {
+	// Deprecated: Do not use.
 	// 用户签到
+	// Deprecated: 无法返回签到奖励，请使用 SignV2
 	Sign(ctx context.Context, in *SignRequest, opts ...grpc.CallOption) (*CommonResponse, error)
+	// 用户签到，返回本次获得的积分、经验和连续签到天数
+	SignV2(ctx context.Context, in *SignRequest, opts ...grpc.CallOption) (*SignResponse, error)
 	// 更新积分和经验
 	UpdatePointsAndExperience(ctx context.Context, in *UpdatePointsRequest, opts ...grpc.CallOption) (*CommonResponse, error)
 	// 获取用户信息
 	GetUserInfo(ctx context.Context, in *GetUserInfoRequest, opts ...grpc.CallOption) (*UserInfo, error)
+	// 批量获取用户信息
+	BatchGetUserInfo(ctx context.Context, in *BatchGetUserInfoRequest, opts ...grpc.CallOption) (*BatchGetUserInfoResponse, error)
 	// 处理点赞
 	ProcessLike(ctx context.Context, in *LikeRequest, opts ...grpc.CallOption) (*CommonResponse, error)
-	// 后台统计接口
+	// Deprecated: Do not use.
+	// 后台统计接口，已废弃：请求中的 user_id 未被使用，请改用 GetAdminStatsV2
 	GetAdminStats(ctx context.Context, in *GetUserInfoRequest, opts ...grpc.CallOption) (*AdminStats, error)
+	// 后台统计接口，支持时间范围和粒度，仅管理员可调用
+	GetAdminStatsV2(ctx context.Context, in *AdminStatsRequest, opts ...grpc.CallOption) (*AdminStatsResponse, error)
+	// 查询积分流水
+	ListPointTransactions(ctx context.Context, in *ListPointTransactionsRequest, opts ...grpc.CallOption) (*ListPointTransactionsResponse, error)
+	// 预留积分（两阶段消费第一步），积分从可用余额中冻结
+	ReservePoints(ctx context.Context, in *ReservePointsRequest, opts ...grpc.CallOption) (*ReservePointsResponse, error)
+	// 确认扣除预留的积分
+	CommitReservation(ctx context.Context, in *CommitReservationRequest, opts ...grpc.CallOption) (*ReservationResponse, error)
+	// 取消预留，积分退回可用余额
+	CancelReservation(ctx context.Context, in *CancelReservationRequest, opts ...grpc.CallOption) (*ReservationResponse, error)
+	// 预览一次奖励的结果（dry-run），不修改任何数据
+	PreviewAward(ctx context.Context, in *PreviewAwardRequest, opts ...grpc.CallOption) (*PreviewAwardResponse, error)
+	// 排行榜
+	GetLeaderboard(ctx context.Context, in *GetLeaderboardRequest, opts ...grpc.CallOption) (*GetLeaderboardResponse, error)
+	// 查询用户在排行榜中的名次
+	GetUserRank(ctx context.Context, in *GetUserRankRequest, opts ...grpc.CallOption) (*GetUserRankResponse, error)
 }
 
 type userServiceClient struct {
@@ -52,6 +85,7 @@ func NewUserServiceClient(cc grpc.ClientConnInterface) UserServiceClient {
 	return &userServiceClient{cc}
 }
 
+// Deprecated: Do not use.
 func (c *userServiceClient) Sign(ctx context.Context, in *SignRequest, opts ...grpc.CallOption) (*CommonResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CommonResponse)
@@ -62,6 +96,16 @@ func (c *userServiceClient) Sign(ctx context.Context, in *SignRequest, opts ...g
 	return out, nil
 }
 
+func (c *userServiceClient) SignV2(ctx context.Context, in *SignRequest, opts ...grpc.CallOption) (*SignResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SignResponse)
+	err := c.cc.Invoke(ctx, UserService_SignV2_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userServiceClient) UpdatePointsAndExperience(ctx context.Context, in *UpdatePointsRequest, opts ...grpc.CallOption) (*CommonResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CommonResponse)
@@ -82,6 +126,16 @@ func (c *userServiceClient) GetUserInfo(ctx context.Context, in *GetUserInfoRequ
 	return out, nil
 }
 
+func (c *userServiceClient) BatchGetUserInfo(ctx context.Context, in *BatchGetUserInfoRequest, opts ...grpc.CallOption) (*BatchGetUserInfoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchGetUserInfoResponse)
+	err := c.cc.Invoke(ctx, UserService_BatchGetUserInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userServiceClient) ProcessLike(ctx context.Context, in *LikeRequest, opts ...grpc.CallOption) (*CommonResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CommonResponse)
@@ -92,6 +146,7 @@ func (c *userServiceClient) ProcessLike(ctx context.Context, in *LikeRequest, op
 	return out, nil
 }
 
+// Deprecated: Do not use.
 func (c *userServiceClient) GetAdminStats(ctx context.Context, in *GetUserInfoRequest, opts ...grpc.CallOption) (*AdminStats, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(AdminStats)
@@ -102,22 +157,125 @@ func (c *userServiceClient) GetAdminStats(ctx context.Context, in *GetUserInfoRe
 	return out, nil
 }
 
+func (c *userServiceClient) GetAdminStatsV2(ctx context.Context, in *AdminStatsRequest, opts ...grpc.CallOption) (*AdminStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminStatsResponse)
+	err := c.cc.Invoke(ctx, UserService_GetAdminStatsV2_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListPointTransactions(ctx context.Context, in *ListPointTransactionsRequest, opts ...grpc.CallOption) (*ListPointTransactionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPointTransactionsResponse)
+	err := c.cc.Invoke(ctx, UserService_ListPointTransactions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ReservePoints(ctx context.Context, in *ReservePointsRequest, opts ...grpc.CallOption) (*ReservePointsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReservePointsResponse)
+	err := c.cc.Invoke(ctx, UserService_ReservePoints_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) CommitReservation(ctx context.Context, in *CommitReservationRequest, opts ...grpc.CallOption) (*ReservationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReservationResponse)
+	err := c.cc.Invoke(ctx, UserService_CommitReservation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) CancelReservation(ctx context.Context, in *CancelReservationRequest, opts ...grpc.CallOption) (*ReservationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReservationResponse)
+	err := c.cc.Invoke(ctx, UserService_CancelReservation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) PreviewAward(ctx context.Context, in *PreviewAwardRequest, opts ...grpc.CallOption) (*PreviewAwardResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PreviewAwardResponse)
+	err := c.cc.Invoke(ctx, UserService_PreviewAward_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetLeaderboard(ctx context.Context, in *GetLeaderboardRequest, opts ...grpc.CallOption) (*GetLeaderboardResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLeaderboardResponse)
+	err := c.cc.Invoke(ctx, UserService_GetLeaderboard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetUserRank(ctx context.Context, in *GetUserRankRequest, opts ...grpc.CallOption) (*GetUserRankResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUserRankResponse)
+	err := c.cc.Invoke(ctx, UserService_GetUserRank_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UserServiceServer is the server API for UserService service.
 // All implementations must embed UnimplementedUserServiceServer
 // for forward compatibility.
 //
 // 用户服务
 type UserServiceServer interface {
+	// Deprecated: Do not use.
 	// 用户签到
+	// Deprecated: 无法返回签到奖励，请使用 SignV2
 	Sign(context.Context, *SignRequest) (*CommonResponse, error)
+	// 用户签到，返回本次获得的积分、经验和连续签到天数
+	SignV2(context.Context, *SignRequest) (*SignResponse, error)
 	// 更新积分和经验
 	UpdatePointsAndExperience(context.Context, *UpdatePointsRequest) (*CommonResponse, error)
 	// 获取用户信息
 	GetUserInfo(context.Context, *GetUserInfoRequest) (*UserInfo, error)
+	// 批量获取用户信息
+	BatchGetUserInfo(context.Context, *BatchGetUserInfoRequest) (*BatchGetUserInfoResponse, error)
 	// 处理点赞
 	ProcessLike(context.Context, *LikeRequest) (*CommonResponse, error)
-	// 后台统计接口
+	// Deprecated: Do not use.
+	// 后台统计接口，已废弃：请求中的 user_id 未被使用，请改用 GetAdminStatsV2
 	GetAdminStats(context.Context, *GetUserInfoRequest) (*AdminStats, error)
+	// 后台统计接口，支持时间范围和粒度，仅管理员可调用
+	GetAdminStatsV2(context.Context, *AdminStatsRequest) (*AdminStatsResponse, error)
+	// 查询积分流水
+	ListPointTransactions(context.Context, *ListPointTransactionsRequest) (*ListPointTransactionsResponse, error)
+	// 预留积分（两阶段消费第一步），积分从可用余额中冻结
+	ReservePoints(context.Context, *ReservePointsRequest) (*ReservePointsResponse, error)
+	// 确认扣除预留的积分
+	CommitReservation(context.Context, *CommitReservationRequest) (*ReservationResponse, error)
+	// 取消预留，积分退回可用余额
+	CancelReservation(context.Context, *CancelReservationRequest) (*ReservationResponse, error)
+	// 预览一次奖励的结果（dry-run），不修改任何数据
+	PreviewAward(context.Context, *PreviewAwardRequest) (*PreviewAwardResponse, error)
+	// 排行榜
+	GetLeaderboard(context.Context, *GetLeaderboardRequest) (*GetLeaderboardResponse, error)
+	// 查询用户在排行榜中的名次
+	GetUserRank(context.Context, *GetUserRankRequest) (*GetUserRankResponse, error)
 	mustEmbedUnimplementedUserServiceServer()
 }
 
@@ -131,18 +289,48 @@ type UnimplementedUserServiceServer struct{}
 func (UnimplementedUserServiceServer) Sign(context.Context, *SignRequest) (*CommonResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method Sign not implemented")
 }
+func (UnimplementedUserServiceServer) SignV2(context.Context, *SignRequest) (*SignResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SignV2 not implemented")
+}
 func (UnimplementedUserServiceServer) UpdatePointsAndExperience(context.Context, *UpdatePointsRequest) (*CommonResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method UpdatePointsAndExperience not implemented")
 }
 func (UnimplementedUserServiceServer) GetUserInfo(context.Context, *GetUserInfoRequest) (*UserInfo, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetUserInfo not implemented")
 }
+func (UnimplementedUserServiceServer) BatchGetUserInfo(context.Context, *BatchGetUserInfoRequest) (*BatchGetUserInfoResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BatchGetUserInfo not implemented")
+}
 func (UnimplementedUserServiceServer) ProcessLike(context.Context, *LikeRequest) (*CommonResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ProcessLike not implemented")
 }
 func (UnimplementedUserServiceServer) GetAdminStats(context.Context, *GetUserInfoRequest) (*AdminStats, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetAdminStats not implemented")
 }
+func (UnimplementedUserServiceServer) GetAdminStatsV2(context.Context, *AdminStatsRequest) (*AdminStatsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetAdminStatsV2 not implemented")
+}
+func (UnimplementedUserServiceServer) ListPointTransactions(context.Context, *ListPointTransactionsRequest) (*ListPointTransactionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListPointTransactions not implemented")
+}
+func (UnimplementedUserServiceServer) ReservePoints(context.Context, *ReservePointsRequest) (*ReservePointsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReservePoints not implemented")
+}
+func (UnimplementedUserServiceServer) CommitReservation(context.Context, *CommitReservationRequest) (*ReservationResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CommitReservation not implemented")
+}
+func (UnimplementedUserServiceServer) CancelReservation(context.Context, *CancelReservationRequest) (*ReservationResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CancelReservation not implemented")
+}
+func (UnimplementedUserServiceServer) PreviewAward(context.Context, *PreviewAwardRequest) (*PreviewAwardResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PreviewAward not implemented")
+}
+func (UnimplementedUserServiceServer) GetLeaderboard(context.Context, *GetLeaderboardRequest) (*GetLeaderboardResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetLeaderboard not implemented")
+}
+func (UnimplementedUserServiceServer) GetUserRank(context.Context, *GetUserRankRequest) (*GetUserRankResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetUserRank not implemented")
+}
 func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
 func (UnimplementedUserServiceServer) testEmbeddedByValue()                     {}
 
@@ -182,6 +370,24 @@ func _UserService_Sign_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_SignV2_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).SignV2(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_SignV2_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).SignV2(ctx, req.(*SignRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _UserService_UpdatePointsAndExperience_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdatePointsRequest)
 	if err := dec(in); err != nil {
@@ -218,6 +424,24 @@ func _UserService_GetUserInfo_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_BatchGetUserInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetUserInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).BatchGetUserInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_BatchGetUserInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).BatchGetUserInfo(ctx, req.(*BatchGetUserInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _UserService_ProcessLike_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(LikeRequest)
 	if err := dec(in); err != nil {
@@ -254,6 +478,150 @@ func _UserService_GetAdminStats_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_GetAdminStatsV2_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetAdminStatsV2(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetAdminStatsV2_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetAdminStatsV2(ctx, req.(*AdminStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListPointTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPointTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListPointTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListPointTransactions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListPointTransactions(ctx, req.(*ListPointTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ReservePoints_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReservePointsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ReservePoints(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ReservePoints_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ReservePoints(ctx, req.(*ReservePointsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_CommitReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CommitReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).CommitReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_CommitReservation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).CommitReservation(ctx, req.(*CommitReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_CancelReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).CancelReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_CancelReservation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).CancelReservation(ctx, req.(*CancelReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_PreviewAward_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreviewAwardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).PreviewAward(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_PreviewAward_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).PreviewAward(ctx, req.(*PreviewAwardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetLeaderboard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLeaderboardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetLeaderboard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetLeaderboard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetLeaderboard(ctx, req.(*GetLeaderboardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetUserRank_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserRankRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetUserRank(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetUserRank_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetUserRank(ctx, req.(*GetUserRankRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -265,6 +633,10 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Sign",
 			Handler:    _UserService_Sign_Handler,
 		},
+		{
+			MethodName: "SignV2",
+			Handler:    _UserService_SignV2_Handler,
+		},
 		{
 			MethodName: "UpdatePointsAndExperience",
 			Handler:    _UserService_UpdatePointsAndExperience_Handler,
@@ -273,6 +645,10 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetUserInfo",
 			Handler:    _UserService_GetUserInfo_Handler,
 		},
+		{
+			MethodName: "BatchGetUserInfo",
+			Handler:    _UserService_BatchGetUserInfo_Handler,
+		},
 		{
 			MethodName: "ProcessLike",
 			Handler:    _UserService_ProcessLike_Handler,
@@ -281,6 +657,38 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetAdminStats",
 			Handler:    _UserService_GetAdminStats_Handler,
 		},
+		{
+			MethodName: "GetAdminStatsV2",
+			Handler:    _UserService_GetAdminStatsV2_Handler,
+		},
+		{
+			MethodName: "ListPointTransactions",
+			Handler:    _UserService_ListPointTransactions_Handler,
+		},
+		{
+			MethodName: "ReservePoints",
+			Handler:    _UserService_ReservePoints_Handler,
+		},
+		{
+			MethodName: "CommitReservation",
+			Handler:    _UserService_CommitReservation_Handler,
+		},
+		{
+			MethodName: "CancelReservation",
+			Handler:    _UserService_CancelReservation_Handler,
+		},
+		{
+			MethodName: "PreviewAward",
+			Handler:    _UserService_PreviewAward_Handler,
+		},
+		{
+			MethodName: "GetLeaderboard",
+			Handler:    _UserService_GetLeaderboard_Handler,
+		},
+		{
+			MethodName: "GetUserRank",
+			Handler:    _UserService_GetUserRank_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "points-system/v1/point.proto",
@@ -0,0 +1,168 @@
+package rauth
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// 网关鉴权后透传给下游服务的 metadata
+const (
+	MetadataUid  = "x-user-id"
+	MetadataMuid = "x-user-muid"
+	MetadataRole = "x-user-role" // 多个角色以逗号分隔
+)
+
+// 内置角色
+const (
+	RoleAdmin     = "admin"
+	RoleModerator = "moderator"
+)
+
+var ErrUnauthenticated = errors.New("rauth: unauthenticated")
+
+// Principal 当前请求的已认证用户
+type Principal struct {
+	Uid   int64
+	Muid  string
+	Roles []string
+}
+
+// HasRole 是否拥有某个角色
+func (p *Principal) HasRole(role string) bool {
+	return p != nil && slices.Contains(p.Roles, role)
+}
+
+// IsAdmin 是否为管理员
+func (p *Principal) IsAdmin() bool {
+	return p.HasRole(RoleAdmin)
+}
+
+// IsModerator 是否为版主，管理员同样视为版主
+func (p *Principal) IsModerator() bool {
+	return p.HasRole(RoleModerator) || p.IsAdmin()
+}
+
+type principalKey struct{}
+
+// NewContext 将 Principal 放入 context
+func NewContext(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// FromContext 从 context 读取 Principal，未认证时返回 nil, false
+func FromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+	return p, ok && p != nil
+}
+
+// FromIncomingMetadata 从网关透传的 incoming metadata 解析 Principal，缺少 x-user-id 时返回 ErrUnauthenticated
+func FromIncomingMetadata(ctx context.Context) (*Principal, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	uid, err := strconv.ParseInt(first(md, MetadataUid), 10, 64)
+	if err != nil || uid <= 0 {
+		return nil, ErrUnauthenticated
+	}
+	return &Principal{Uid: uid, Muid: first(md, MetadataMuid), Roles: Roles(md)}, nil
+}
+
+// Roles 解析 metadata 中的全部 x-user-role 值，每个值可包含以逗号分隔的多个角色
+func Roles(md metadata.MD) []string {
+	var roles []string
+	for _, v := range md.Get(MetadataRole) {
+		for _, r := range strings.Split(v, ",") {
+			if r = strings.TrimSpace(r); r != "" {
+				roles = append(roles, r)
+			}
+		}
+	}
+	return roles
+}
+
+// AppendToOutgoingContext 将 Principal 写入 outgoing metadata，供网关或服务间调用透传
+func AppendToOutgoingContext(ctx context.Context, p *Principal) context.Context {
+	kv := []string{MetadataUid, strconv.FormatInt(p.Uid, 10)}
+	if p.Muid != "" {
+		kv = append(kv, MetadataMuid, p.Muid)
+	}
+	if len(p.Roles) > 0 {
+		kv = append(kv, MetadataRole, strings.Join(p.Roles, ","))
+	}
+	return metadata.AppendToOutgoingContext(ctx, kv...)
+}
+
+func first(md metadata.MD, key string) string {
+	if v := md.Get(key); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+// UnaryServerInterceptor 解析 Principal 并放入 context，未认证的请求照常放行，
+// 由后续拦截器或 handler 通过 FromContext / Require 决定是否拒绝。
+// resolve 为空时使用 FromIncomingMetadata（信任网关透传的 metadata）。
+func UnaryServerInterceptor(resolve func(ctx context.Context) (*Principal, error)) grpc.UnaryServerInterceptor {
+	if resolve == nil {
+		resolve = FromIncomingMetadata
+	}
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		p, err := resolve(ctx)
+		if err != nil && !errors.Is(err, ErrUnauthenticated) {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		if p != nil {
+			ctx = NewContext(ctx, p)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// Require 读取 Principal，未认证时返回 codes.Unauthenticated
+func Require(ctx context.Context) (*Principal, error) {
+	p, ok := FromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+	return p, nil
+}
+
+// HeaderMatcher 返回供 grpc-gateway 的 runtime.WithIncomingHeaderMatcher 使用的请求头匹配函数，
+// 其余请求头按默认规则处理。
+//
+// trustProxy 为 false 时丢弃 X-User-Id / X-User-Muid / X-User-Role，这些请求头可由客户端任意伪造；
+// 为 true 时将其转为 metadata，只能在网关前的反向代理会在鉴权后覆盖这些请求头、且网关无法绕过代理直接访问时使用。
+// 两种情况下 Grpc-Metadata-X-User-* 形式的请求头都会被丢弃。
+func HeaderMatcher(trustProxy bool) runtime.HeaderMatcherFunc {
+	return func(key string) (string, bool) {
+		k := strings.ToLower(key)
+		if rest, ok := strings.CutPrefix(k, grpcMetadataPrefix); ok && isIdentityKey(rest) {
+			return "", false
+		}
+		if isIdentityKey(k) {
+			if !trustProxy {
+				return "", false
+			}
+			return k, true
+		}
+		return runtime.DefaultHeaderMatcher(key)
+	}
+}
+
+var grpcMetadataPrefix = strings.ToLower(runtime.MetadataHeaderPrefix)
+
+// GatewayHeaderMatcher 等同于 HeaderMatcher(false)，不信任客户端传入的身份请求头
+func GatewayHeaderMatcher(key string) (string, bool) {
+	return HeaderMatcher(false)(key)
+}
+
+func isIdentityKey(k string) bool {
+	return k == MetadataUid || k == MetadataMuid || k == MetadataRole
+}
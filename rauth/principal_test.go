@@ -0,0 +1,140 @@
+package rauth
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestFromIncomingMetadata(t *testing.T) {
+	tests := []struct {
+		name    string
+		md      metadata.MD
+		want    *Principal
+		wantErr error
+	}{
+		{"no metadata", nil, nil, ErrUnauthenticated},
+		{"bad uid", metadata.Pairs(MetadataUid, "abc"), nil, ErrUnauthenticated},
+		{"zero uid", metadata.Pairs(MetadataUid, "0"), nil, ErrUnauthenticated},
+		{"uid only", metadata.Pairs(MetadataUid, "7"), &Principal{Uid: 7}, nil},
+		{"comma separated roles", metadata.Pairs(MetadataUid, "7", MetadataMuid, "m7", MetadataRole, "moderator, admin"),
+			&Principal{Uid: 7, Muid: "m7", Roles: []string{RoleModerator, RoleAdmin}}, nil},
+		{"repeated role header", metadata.Pairs(MetadataUid, "7", MetadataRole, "moderator", MetadataRole, ",admin,"),
+			&Principal{Uid: 7, Roles: []string{RoleModerator, RoleAdmin}}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), tt.md)
+			got, err := FromIncomingMetadata(ctx)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.want == nil {
+				return
+			}
+			if got.Uid != tt.want.Uid || got.Muid != tt.want.Muid || !slices.Equal(got.Roles, tt.want.Roles) {
+				t.Fatalf("Principal = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoles(t *testing.T) {
+	tests := []struct {
+		name      string
+		p         *Principal
+		admin     bool
+		moderator bool
+	}{
+		{"nil", nil, false, false},
+		{"user", &Principal{Uid: 1}, false, false},
+		{"moderator", &Principal{Uid: 1, Roles: []string{RoleModerator}}, false, true},
+		{"admin is moderator", &Principal{Uid: 1, Roles: []string{RoleAdmin}}, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.p.IsAdmin() != tt.admin || tt.p.IsModerator() != tt.moderator {
+				t.Fatalf("IsAdmin = %v, IsModerator = %v", tt.p.IsAdmin(), tt.p.IsModerator())
+			}
+		})
+	}
+}
+
+func TestAppendToOutgoingContext(t *testing.T) {
+	p := &Principal{Uid: 9, Muid: "m9", Roles: []string{RoleModerator, RoleAdmin}}
+	md, _ := metadata.FromOutgoingContext(AppendToOutgoingContext(context.Background(), p))
+	got, err := FromIncomingMetadata(metadata.NewIncomingContext(context.Background(), md))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Uid != p.Uid || got.Muid != p.Muid || !slices.Equal(got.Roles, p.Roles) {
+		t.Fatalf("round trip = %+v, want %+v", got, p)
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	errResolve := errors.New("token store down")
+	tests := []struct {
+		name     string
+		resolve  func(context.Context) (*Principal, error)
+		md       metadata.MD
+		wantUid  int64
+		wantCode codes.Code
+	}{
+		{"metadata", nil, metadata.Pairs(MetadataUid, "5"), 5, codes.OK},
+		{"anonymous passes through", nil, nil, 0, codes.OK},
+		{"resolver error", func(context.Context) (*Principal, error) { return nil, errResolve }, nil, 0, codes.Internal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), tt.md)
+			var uid int64
+			_, err := UnaryServerInterceptor(tt.resolve)(ctx, nil, &grpc.UnaryServerInfo{},
+				func(ctx context.Context, _ any) (any, error) {
+					if p, ok := FromContext(ctx); ok {
+						uid = p.Uid
+					}
+					return nil, nil
+				})
+			if status.Code(err) != tt.wantCode || uid != tt.wantUid {
+				t.Fatalf("err = %v, uid = %d; want %v, %d", err, uid, tt.wantCode, tt.wantUid)
+			}
+		})
+	}
+}
+
+func TestHeaderMatcher(t *testing.T) {
+	tests := []struct {
+		key        string
+		trustProxy bool
+		want       string
+		ok         bool
+	}{
+		{"X-User-Id", false, "", false},
+		{"X-User-Role", false, "", false},
+		{"x-user-muid", false, "", false},
+		{"X-User-Id", true, MetadataUid, true},
+		{"X-User-Role", true, MetadataRole, true},
+		{"Grpc-Metadata-X-User-Role", false, "", false},
+		{"Grpc-Metadata-X-User-Id", true, "", false},
+		{"Grpc-Metadata-Trace-Id", false, "Trace-Id", true},
+		{"Authorization", false, "grpcgateway-Authorization", true},
+		{"Content-Type", true, "grpcgateway-Content-Type", true},
+	}
+	for _, tt := range tests {
+		if got, ok := HeaderMatcher(tt.trustProxy)(tt.key); got != tt.want || ok != tt.ok {
+			t.Errorf("HeaderMatcher(%v)(%q) = %q, %v; want %q, %v", tt.trustProxy, tt.key, got, ok, tt.want, tt.ok)
+		}
+		if !tt.trustProxy {
+			if got, ok := GatewayHeaderMatcher(tt.key); got != tt.want || ok != tt.ok {
+				t.Errorf("GatewayHeaderMatcher(%q) = %q, %v; want %q, %v", tt.key, got, ok, tt.want, tt.ok)
+			}
+		}
+	}
+}
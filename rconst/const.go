@@ -4,10 +4,29 @@ var (
 	StreamComment      = "mundo_comment_event" // 评论事件 (发布评论后触发
 	NavyGroup          = "navy_group"          // 评论事件消费者组
 	NavyConsumerPrefix = "navy_consumer_"      // 评论事件消费者前缀
+	StreamPost         = "mundo_post_event"    // 帖子事件 (发布、更新、删除帖子后触发
+	SearchGroup        = "search_group"        // 搜索索引消费者组
 )
 
+// 帖子事件动作
+const (
+	PostActionCreate = "create"
+	PostActionUpdate = "update"
+	PostActionDelete = "delete"
+)
+
+type PostEvent struct {
+	PostId uint   `json:"post_id"`       // 帖子ID
+	Action string `json:"action"`        // 见 PostAction 常量
+	Uid    int64  `json:"uid,omitempty"` // 操作者ID
+}
+
 type CommentEvent struct {
-	PostId uint `json:"post_id"` // 被评论的帖子ID
+	PostId    uint  `json:"post_id"`              // 被评论的帖子ID
+	CommentId uint  `json:"comment_id,omitempty"` // 新评论ID
+	ParentId  uint  `json:"parent_id,omitempty"`  // 被回复的评论ID，顶层评论为 0
+	RootId    uint  `json:"root_id,omitempty"`    // 所属楼层的顶层评论ID
+	Uid       int64 `json:"uid,omitempty"`        // 评论者ID
 }
 
 // StatEventType 统计事件类型常量
@@ -32,3 +51,27 @@ const (
 	// 聊天相关事件
 	EventChatSend = "chat_sent" // 发送聊天消息
 )
+
+// StatEventTypes 所有内置统计事件类型
+var StatEventTypes = []string{
+	EventLogin,
+	EventLogout,
+	EventFirstLogin,
+	EventOnline,
+	EventOffline,
+	EventPageView,
+	EventPageStay,
+	EventClick,
+	EventQuestionSolve,
+	EventChatSend,
+}
+
+// IsStatEventType 判断是否为内置统计事件类型
+func IsStatEventType(eventType string) bool {
+	for _, t := range StatEventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,443 @@
+package rmodel
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/trancecho/mundo-proto-sdk/rauth"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// 审计日志的操作类型
+const (
+	AuditCreate  = "create"
+	AuditUpdate  = "update"
+	AuditDelete  = "delete"
+	AuditRestore = "restore"
+)
+
+const auditBeforeKey = "rmodel:audit_before"
+
+var ErrAuditUnsupported = errors.New("rmodel: audit log requires an integer primary key")
+
+// 不记录到变更中的字段，每次更新都会变化
+var auditIgnored = map[string]bool{"updated_at": true, "updated_by": true}
+
+// AuditLog 审计日志，每条记录对应一行数据的一次变更。
+// Before/After 为 JSON 对象：创建只有 After（全部字段），删除只有 Before（全部字段），
+// 更新和恢复只包含发生变化的字段。
+type AuditLog struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+	Table     string    `gorm:"column:table_name;size:64;index:idx_audit_logs_record,priority:1" json:"table"`
+	RecordID  uint      `gorm:"index:idx_audit_logs_record,priority:2" json:"record_id"`
+	Action    string    `gorm:"size:16" json:"action"`
+	Uid       int64     `gorm:"index" json:"uid"`
+	Before    string    `gorm:"type:text" json:"before,omitempty"`
+	After     string    `gorm:"type:text" json:"after,omitempty"`
+}
+
+// GetID 返回主键，供 Repository 使用
+func (l AuditLog) GetID() uint {
+	return l.ID
+}
+
+// AuditConfig 审计配置，零值字段使用默认值
+type AuditConfig struct {
+	Actor      func(ctx context.Context) int64 // 当前操作人，默认取 rauth.FromContext 的 Uid
+	DisableLog bool                            // 只填充操作人字段，不写审计日志
+}
+
+func (c *AuditConfig) setDefaults() {
+	if c.Actor == nil {
+		c.Actor = func(ctx context.Context) int64 {
+			if p, ok := rauth.FromContext(ctx); ok {
+				return p.Uid
+			}
+			return 0
+		}
+	}
+}
+
+type auditable interface {
+	audited()
+}
+
+var auditableType = reflect.TypeOf((*auditable)(nil)).Elem()
+
+type auditor struct {
+	cfg AuditConfig
+}
+
+// RegisterAudit 为嵌入 AuditedModel 的模型注册 GORM 回调：
+// 创建时填充 CreatedBy/UpdatedBy，更新时填充 UpdatedBy，软删除时填充 DeletedBy，恢复时清空 DeletedBy；
+// 未关闭日志时在同一事务中写入 AuditLog，需先 AutoMigrate(&AuditLog{})。
+// 操作人从 db.WithContext(ctx) 的 ctx 中读取。
+// 更新和删除前会按原语句的条件查出受影响的行，批量操作时注意开销；不带条件且没有主键的语句不记录日志。
+func RegisterAudit(db *gorm.DB, cfg AuditConfig) error {
+	cfg.setDefaults()
+	a := &auditor{cfg: cfg}
+	cb := db.Callback()
+	if err := cb.Create().Before("gorm:create").Register("rmodel:audit_before_create", a.beforeCreate); err != nil {
+		return err
+	}
+	if err := cb.Create().After("gorm:create").Register("rmodel:audit_after_create", a.afterCreate); err != nil {
+		return err
+	}
+	if err := cb.Update().Before("gorm:update").Register("rmodel:audit_before_update", a.beforeUpdate); err != nil {
+		return err
+	}
+	if err := cb.Update().After("gorm:update").Register("rmodel:audit_after_update", a.afterUpdate); err != nil {
+		return err
+	}
+	if err := cb.Delete().Before("gorm:delete").Register("rmodel:audit_before_delete", a.beforeDelete); err != nil {
+		return err
+	}
+	return cb.Delete().After("gorm:delete").Register("rmodel:audit_after_delete", a.afterDelete)
+}
+
+func enabled(db *gorm.DB) bool {
+	s := db.Statement.Schema
+	return db.Error == nil && !db.DryRun && s != nil && reflect.PointerTo(s.ModelType).Implements(auditableType)
+}
+
+func (a *auditor) beforeCreate(db *gorm.DB) {
+	if !enabled(db) {
+		return
+	}
+	uid := a.cfg.Actor(db.Statement.Context)
+	if uid == 0 {
+		return
+	}
+	// 调用方显式设置的操作人不覆盖
+	for _, name := range []string{"CreatedBy", "UpdatedBy"} {
+		field := db.Statement.Schema.LookUpField(name)
+		eachRow(db.Statement.ReflectValue, func(rv reflect.Value) {
+			if _, zero := field.ValueOf(db.Statement.Context, rv); zero && rv.CanAddr() {
+				db.AddError(field.Set(db.Statement.Context, rv, uid))
+			}
+		})
+	}
+}
+
+func (a *auditor) afterCreate(db *gorm.DB) {
+	if !enabled(db) || a.cfg.DisableLog {
+		return
+	}
+	uid := a.cfg.Actor(db.Statement.Context)
+	var logs []AuditLog
+	eachRow(db.Statement.ReflectValue, func(rv reflect.Value) {
+		if id, ok := recordID(db.Statement, rv); ok {
+			logs = append(logs, AuditLog{
+				Table: db.Statement.Table, RecordID: id, Action: AuditCreate, Uid: uid,
+				After: toJSON(snapshot(db.Statement, rv)),
+			})
+		}
+	})
+	a.write(db, logs)
+}
+
+func (a *auditor) beforeUpdate(db *gorm.DB) {
+	if !enabled(db) {
+		return
+	}
+	if uid := a.cfg.Actor(db.Statement.Context); uid != 0 {
+		db.Statement.SetColumn("UpdatedBy", uid, true)
+	}
+	if restoring(db.Statement) {
+		db.Statement.SetColumn("DeletedBy", 0, true)
+	}
+	if a.cfg.DisableLog {
+		return
+	}
+	a.loadBefore(db)
+}
+
+func (a *auditor) afterUpdate(db *gorm.DB) {
+	if !enabled(db) || a.cfg.DisableLog {
+		return
+	}
+	before, ids := beforeRows(db)
+	if len(ids) == 0 {
+		return
+	}
+	after := reflect.New(reflect.SliceOf(db.Statement.Schema.ModelType))
+	if err := a.session(db).Unscoped().Table(db.Statement.Table).Where(pkIn(db.Statement, ids)).Find(after.Interface()).Error; err != nil {
+		db.AddError(err)
+		return
+	}
+	afterByID := make(map[uint]map[string]any, after.Elem().Len())
+	eachRow(after.Elem(), func(rv reflect.Value) {
+		if id, ok := recordID(db.Statement, rv); ok {
+			afterByID[id] = snapshot(db.Statement, rv)
+		}
+	})
+	uid := a.cfg.Actor(db.Statement.Context)
+	var logs []AuditLog
+	for i, id := range ids {
+		b, ok := afterByID[id]
+		if !ok {
+			continue
+		}
+		old, changed := diff(snapshot(db.Statement, before.Index(i)), b)
+		if len(changed) == 0 {
+			continue
+		}
+		action := AuditUpdate
+		if old["deleted_at"] != nil && changed["deleted_at"] == nil {
+			action = AuditRestore
+		}
+		logs = append(logs, AuditLog{
+			Table: db.Statement.Table, RecordID: id, Action: action, Uid: uid,
+			Before: toJSON(old), After: toJSON(changed),
+		})
+	}
+	a.write(db, logs)
+}
+
+func (a *auditor) beforeDelete(db *gorm.DB) {
+	if !enabled(db) {
+		return
+	}
+	a.loadBefore(db)
+}
+
+func (a *auditor) afterDelete(db *gorm.DB) {
+	if !enabled(db) {
+		return
+	}
+	before, ids := beforeRows(db)
+	if len(ids) == 0 {
+		return
+	}
+	uid := a.cfg.Actor(db.Statement.Context)
+	if uid != 0 && !db.Statement.Unscoped {
+		err := a.session(db).Unscoped().Table(db.Statement.Table).
+			Where(pkIn(db.Statement, ids)).UpdateColumn("deleted_by", uid).Error
+		if err != nil {
+			db.AddError(err)
+			return
+		}
+	}
+	if a.cfg.DisableLog {
+		return
+	}
+	logs := make([]AuditLog, 0, len(ids))
+	for i, id := range ids {
+		logs = append(logs, AuditLog{
+			Table: db.Statement.Table, RecordID: id, Action: AuditDelete, Uid: uid,
+			Before: toJSON(snapshot(db.Statement, before.Index(i))),
+		})
+	}
+	a.write(db, logs)
+}
+
+// session 复用当前语句的连接（事务）和 ctx，不触发模型钩子
+func (a *auditor) session(db *gorm.DB) *gorm.DB {
+	return db.Session(&gorm.Session{NewDB: true, SkipHooks: true})
+}
+
+// loadBefore 按原语句的条件和主键查出将被修改的行
+func (a *auditor) loadBefore(db *gorm.DB) {
+	stmt := db.Statement
+	tx := a.session(db).Table(stmt.Table)
+	if stmt.Unscoped {
+		tx = tx.Unscoped()
+	}
+	conds := false
+	if c, ok := stmt.Clauses["WHERE"]; ok {
+		if where, ok := c.Expression.(clause.Where); ok && len(where.Exprs) > 0 {
+			tx = tx.Clauses(where)
+			conds = true
+		}
+	}
+	_, values := schema.GetIdentityFieldValuesMap(stmt.Context, stmt.ReflectValue, stmt.Schema.PrimaryFields)
+	if len(values) > 0 {
+		column, vals := schema.ToQueryValues(stmt.Table, stmt.Schema.PrimaryFieldDBNames, values)
+		tx = tx.Where(clause.IN{Column: column, Values: vals})
+		conds = true
+	}
+	if !conds {
+		return
+	}
+	rows := reflect.New(reflect.SliceOf(stmt.Schema.ModelType))
+	if err := tx.Find(rows.Interface()).Error; err != nil {
+		db.AddError(err)
+		return
+	}
+	db.InstanceSet(auditBeforeKey, rows.Elem())
+}
+
+func beforeRows(db *gorm.DB) (reflect.Value, []uint) {
+	v, ok := db.InstanceGet(auditBeforeKey)
+	if !ok {
+		return reflect.Value{}, nil
+	}
+	rows := v.(reflect.Value)
+	ids := make([]uint, rows.Len())
+	for i := range ids {
+		ids[i], _ = recordID(db.Statement, rows.Index(i))
+	}
+	return rows, ids
+}
+
+func (a *auditor) write(db *gorm.DB, logs []AuditLog) {
+	if len(logs) == 0 {
+		return
+	}
+	db.AddError(a.session(db).Create(&logs).Error)
+}
+
+// restoring 是否为 Repository.Restore 这类将 deleted_at 置空的更新
+func restoring(stmt *gorm.Statement) bool {
+	m, ok := stmt.Dest.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	for _, key := range []string{"deleted_at", "DeletedAt"} {
+		if v, ok := m[key]; ok && v == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func eachRow(rv reflect.Value, fn func(reflect.Value)) {
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			fn(reflect.Indirect(rv.Index(i)))
+		}
+	case reflect.Struct:
+		fn(rv)
+	}
+}
+
+func recordID(stmt *gorm.Statement, rv reflect.Value) (uint, bool) {
+	f := stmt.Schema.PrioritizedPrimaryField
+	if f == nil {
+		return 0, false
+	}
+	v, zero := f.ValueOf(stmt.Context, rv)
+	if zero {
+		return 0, false
+	}
+	switch id := reflect.ValueOf(v); id.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return uint(id.Uint()), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return uint(id.Int()), id.Int() > 0
+	}
+	return 0, false
+}
+
+func pkIn(stmt *gorm.Statement, ids []uint) clause.IN {
+	values := make([]interface{}, len(ids))
+	for i, id := range ids {
+		values[i] = id
+	}
+	return clause.IN{Column: clause.Column{Table: stmt.Table, Name: stmt.Schema.PrioritizedPrimaryField.DBName}, Values: values}
+}
+
+func snapshot(stmt *gorm.Statement, rv reflect.Value) map[string]any {
+	m := make(map[string]any, len(stmt.Schema.DBNames))
+	for _, name := range stmt.Schema.DBNames {
+		v, _ := stmt.Schema.FieldsByDBName[name].ValueOf(stmt.Context, rv)
+		if dt, ok := v.(gorm.DeletedAt); ok {
+			// 未删除时记为 null
+			if !dt.Valid {
+				v = nil
+			} else {
+				v = dt.Time
+			}
+		}
+		m[name] = v
+	}
+	return m
+}
+
+// diff 返回 before 与 after 中值不同的字段，按 JSON 编码比较
+func diff(before, after map[string]any) (old, changed map[string]any) {
+	old, changed = make(map[string]any), make(map[string]any)
+	for k, b := range before {
+		if auditIgnored[k] {
+			continue
+		}
+		x, _ := json.Marshal(b)
+		y, _ := json.Marshal(after[k])
+		if string(x) != string(y) {
+			old[k], changed[k] = b, after[k]
+		}
+	}
+	return old, changed
+}
+
+func toJSON(m map[string]any) string {
+	b, _ := json.Marshal(m)
+	return string(b)
+}
+
+// AuditQuery 审计日志查询条件，零值字段不参与过滤
+type AuditQuery struct {
+	Table    string
+	RecordID uint
+	Uid      int64
+	Action   string
+	Since    time.Time // 包含
+	Until    time.Time // 不包含
+}
+
+// QueryAuditLogs 按条件分页查询审计日志，默认按 ID 倒序（最新的在前）
+func QueryAuditLogs(ctx context.Context, db *gorm.DB, q AuditQuery, opts ListOptions) ([]AuditLog, string, error) {
+	scope := opts.Scope
+	opts.Scope = func(db *gorm.DB) *gorm.DB {
+		if q.Table != "" {
+			db = db.Where("table_name = ?", q.Table)
+		}
+		if q.RecordID != 0 {
+			db = db.Where("record_id = ?", q.RecordID)
+		}
+		if q.Uid != 0 {
+			db = db.Where("uid = ?", q.Uid)
+		}
+		if q.Action != "" {
+			db = db.Where("action = ?", q.Action)
+		}
+		if !q.Since.IsZero() {
+			db = db.Where("created_at >= ?", q.Since)
+		}
+		if !q.Until.IsZero() {
+			db = db.Where("created_at < ?", q.Until)
+		}
+		if scope != nil {
+			db = scope(db)
+		}
+		return db
+	}
+	return NewRepository[AuditLog](db).List(ctx, opts)
+}
+
+// History 查询一条记录的审计日志，包括软删除之后的变更。
+// 审计日志以整数记录主键，UUID 主键的仓储返回 ErrAuditUnsupported
+func (r *RepositoryOf[K, T, PT]) History(ctx context.Context, id K, opts ListOptions) ([]AuditLog, string, error) {
+	var rid uint
+	switch v := any(id).(type) {
+	case uint:
+		rid = v
+	case SnowflakeID:
+		rid = uint(v)
+	default:
+		return nil, "", fmt.Errorf("%w: %T", ErrAuditUnsupported, id)
+	}
+	stmt := &gorm.Statement{DB: r.db}
+	if err := stmt.Parse(new(T)); err != nil {
+		return nil, "", err
+	}
+	return QueryAuditLogs(ctx, r.db, AuditQuery{Table: stmt.Table, RecordID: rid}, opts)
+}
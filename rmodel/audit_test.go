@@ -0,0 +1,178 @@
+package rmodel_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/trancecho/mundo-proto-sdk/rauth"
+	"github.com/trancecho/mundo-proto-sdk/rmodel"
+	"gorm.io/gorm"
+)
+
+type article struct {
+	rmodel.AuditedModel
+	Title string
+}
+
+func as(uid int64) context.Context {
+	if uid == 0 {
+		return context.Background()
+	}
+	return rauth.NewContext(context.Background(), &rauth.Principal{Uid: uid})
+}
+
+func openAuditDB(t *testing.T, cfg rmodel.AuditConfig) *gorm.DB {
+	t.Helper()
+	db := openDB(t, &article{}, &rmodel.AuditLog{})
+	if err := rmodel.RegisterAudit(db, cfg); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestAuditLifecycle(t *testing.T) {
+	db := openAuditDB(t, rmodel.AuditConfig{})
+	r := rmodel.NewRepository[article](db)
+	a := &article{Title: "v1"}
+	tests := []struct {
+		name       string
+		uid        int64
+		op         func(ctx context.Context) error
+		wantAction string
+		wantBefore map[string]any // 只比较列出的字段
+		wantAfter  map[string]any
+		wantBy     [3]int64 // CreatedBy, UpdatedBy, DeletedBy
+	}{
+		{"create", 7, func(ctx context.Context) error { return r.Create(ctx, a) },
+			rmodel.AuditCreate, nil, map[string]any{"title": "v1", "created_by": 7.0}, [3]int64{7, 7, 0}},
+		{"update", 8, func(ctx context.Context) error { a.Title = "v2"; return r.Update(ctx, a) },
+			rmodel.AuditUpdate, map[string]any{"title": "v1"}, map[string]any{"title": "v2"}, [3]int64{7, 8, 0}},
+		{"soft delete", 9, func(ctx context.Context) error { return r.SoftDelete(ctx, a.ID) },
+			rmodel.AuditDelete, map[string]any{"title": "v2", "deleted_at": nil}, nil, [3]int64{7, 8, 9}},
+		{"restore", 10, func(ctx context.Context) error { return r.Restore(ctx, a.ID) },
+			rmodel.AuditRestore, map[string]any{"deleted_by": 9.0}, map[string]any{"deleted_at": nil, "deleted_by": 0.0}, [3]int64{7, 10, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.op(as(tt.uid)); err != nil {
+				t.Fatal(err)
+			}
+			var got article
+			if err := db.Unscoped().First(&got, a.ID).Error; err != nil {
+				t.Fatal(err)
+			}
+			if by := [3]int64{got.CreatedBy, got.UpdatedBy, got.DeletedBy}; by != tt.wantBy {
+				t.Fatalf("created/updated/deleted by = %v, want %v", by, tt.wantBy)
+			}
+			logs, _, err := r.History(context.Background(), a.ID, rmodel.ListOptions{Limit: 1})
+			if err != nil || len(logs) != 1 {
+				t.Fatalf("History = %v, %v", logs, err)
+			}
+			l := logs[0]
+			if l.Action != tt.wantAction || l.Uid != tt.uid || l.Table != "articles" {
+				t.Fatalf("log = %+v", l)
+			}
+			checkJSON(t, "before", l.Before, tt.wantBefore)
+			checkJSON(t, "after", l.After, tt.wantAfter)
+			// 更新只记录变化的业务字段
+			if tt.wantAction == rmodel.AuditUpdate && l.After != `{"title":"v2"}` {
+				t.Fatalf("update after = %s", l.After)
+			}
+		})
+	}
+	all, _, err := rmodel.QueryAuditLogs(context.Background(), db, rmodel.AuditQuery{Table: "articles", Uid: 8}, rmodel.ListOptions{})
+	if err != nil || len(all) != 1 || all[0].Action != rmodel.AuditUpdate {
+		t.Fatalf("QueryAuditLogs by uid = %+v, %v", all, err)
+	}
+}
+
+// checkJSON 校验 JSON 对象包含 want 中的字段；want 为 nil 时要求 s 为空
+func checkJSON(t *testing.T, name, s string, want map[string]any) {
+	t.Helper()
+	if want == nil {
+		if s != "" {
+			t.Fatalf("%s = %s, want empty", name, s)
+		}
+		return
+	}
+	var m map[string]any
+	if err := json.Unmarshal([]byte(s), &m); err != nil {
+		t.Fatalf("%s = %q: %v", name, s, err)
+	}
+	for k, v := range want {
+		if got, ok := m[k]; !ok || got != v {
+			t.Fatalf("%s[%s] = %v, want %v (%s)", name, k, got, v, s)
+		}
+	}
+}
+
+func TestAuditFill(t *testing.T) {
+	tests := []struct {
+		name      string
+		cfg       rmodel.AuditConfig
+		uid       int64
+		createdBy int64
+		wantBy    int64
+		wantLogs  int64
+	}{
+		{"anonymous", rmodel.AuditConfig{}, 0, 0, 0, 1},
+		{"explicit actor kept", rmodel.AuditConfig{}, 7, 3, 3, 1},
+		{"custom actor", rmodel.AuditConfig{Actor: func(context.Context) int64 { return 42 }}, 0, 0, 42, 1},
+		{"log disabled", rmodel.AuditConfig{DisableLog: true}, 7, 0, 7, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := openAuditDB(t, tt.cfg)
+			a := &article{Title: "x"}
+			a.CreatedBy = tt.createdBy
+			if err := rmodel.NewRepository[article](db).Create(as(tt.uid), a); err != nil {
+				t.Fatal(err)
+			}
+			if a.CreatedBy != tt.wantBy {
+				t.Fatalf("CreatedBy = %d, want %d", a.CreatedBy, tt.wantBy)
+			}
+			var n int64
+			if err := db.Model(&rmodel.AuditLog{}).Count(&n).Error; err != nil || n != tt.wantLogs {
+				t.Fatalf("audit logs = %d, %v; want %d", n, err, tt.wantLogs)
+			}
+		})
+	}
+}
+
+func TestAuditSkipsUnauditedModels(t *testing.T) {
+	db := openDB(t, &note{}, &rmodel.AuditLog{})
+	if err := rmodel.RegisterAudit(db, rmodel.AuditConfig{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := rmodel.NewRepository[note](db).Create(as(7), &note{Body: "x"}); err != nil {
+		t.Fatal(err)
+	}
+	var n int64
+	if err := db.Model(&rmodel.AuditLog{}).Count(&n).Error; err != nil || n != 0 {
+		t.Fatalf("audit logs = %d, %v; want 0", n, err)
+	}
+}
+
+func TestAuditUpdateKeepsCreatedAndDeletedBy(t *testing.T) {
+	db := openAuditDB(t, rmodel.AuditConfig{})
+	r := rmodel.NewRepository[article](db)
+	a := &article{Title: "v1"}
+	if err := r.Create(as(7), a); err != nil {
+		t.Fatal(err)
+	}
+	// 调用方构造的模型未填写 CreatedBy，DeletedBy 为脏值
+	u := &article{Title: "v2"}
+	u.ID = a.ID
+	u.DeletedBy = 5
+	if err := r.Update(as(8), u); err != nil {
+		t.Fatal(err)
+	}
+	var got article
+	if err := db.First(&got, a.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if by := [3]int64{got.CreatedBy, got.UpdatedBy, got.DeletedBy}; got.Title != "v2" || by != [3]int64{7, 8, 0} {
+		t.Fatalf("stored = %q, created/updated/deleted by %v; want \"v2\", [7 8 0]", got.Title, by)
+	}
+}
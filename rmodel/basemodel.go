@@ -11,3 +11,79 @@ type BaseModel struct {
 	UpdatedAt time.Time
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
 }
+
+// GetID 返回主键，供 Repository 使用
+func (m BaseModel) GetID() uint {
+	return m.ID
+}
+
+// VersionedModel 带版本号的 BaseModel，Repository.Update 据此做乐观锁
+type VersionedModel struct {
+	BaseModel
+	Version int64 `gorm:"not null;default:1" json:"version"`
+}
+
+// GetVersion 返回当前版本号
+func (m VersionedModel) GetVersion() int64 {
+	return m.Version
+}
+
+// SetVersion 设置版本号
+func (m *VersionedModel) SetVersion(v int64) {
+	m.Version = v
+}
+
+// AuditedModel 带操作人的 BaseModel，需调用 RegisterAudit 注册回调后才会自动填充，
+// 操作人为 0 表示系统操作或未登录
+type AuditedModel struct {
+	BaseModel
+	CreatedBy int64 `gorm:"index" json:"created_by"`
+	UpdatedBy int64 `json:"updated_by"`
+	DeletedBy int64 `json:"-"`
+}
+
+func (AuditedModel) audited() {}
+
+// UUIDModel 以 UUIDv7 为主键的 BaseModel，创建时由 BeforeCreate 生成，ID 按时间有序且不暴露记录数。
+// 嵌入的模型若自定义了 BeforeCreate，需自行调用 UUIDModel.BeforeCreate。
+type UUIDModel struct {
+	ID        UUID `gorm:"primarykey;size:36" json:"id"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// GetID 返回主键，供 RepositoryOf 使用
+func (m UUIDModel) GetID() UUID {
+	return m.ID
+}
+
+// BeforeCreate 未设置 ID 时生成 UUIDv7
+func (m *UUIDModel) BeforeCreate(*gorm.DB) error {
+	if m.ID.IsZero() {
+		m.ID = NewUUIDv7()
+	}
+	return nil
+}
+
+// SnowflakeModel 以雪花 ID 为主键的 BaseModel，创建时由 BeforeCreate 使用默认生成器生成，
+// 节点 ID 见 SetSnowflakeNode。嵌入的模型若自定义了 BeforeCreate，需自行调用 SnowflakeModel.BeforeCreate。
+type SnowflakeModel struct {
+	ID        SnowflakeID `gorm:"primarykey;autoIncrement:false" json:"id"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// GetID 返回主键，供 RepositoryOf 使用
+func (m SnowflakeModel) GetID() SnowflakeID {
+	return m.ID
+}
+
+// BeforeCreate 未设置 ID 时生成雪花 ID
+func (m *SnowflakeModel) BeforeCreate(*gorm.DB) error {
+	if m.ID == 0 {
+		m.ID = NewSnowflakeID()
+	}
+	return nil
+}
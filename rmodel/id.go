@@ -0,0 +1,319 @@
+package rmodel
+
+import (
+	"crypto/rand"
+	"database/sql/driver"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var (
+	ErrInvalidUUID      = errors.New("rmodel: invalid uuid")
+	ErrInvalidSnowflake = errors.New("rmodel: invalid snowflake id")
+	ErrInvalidNode      = errors.New("rmodel: snowflake node out of range")
+)
+
+// UUID RFC 9562 UUID，数据库中以 36 位字符串存储，JSON 和 proto string 字段使用标准格式
+type UUID [16]byte
+
+// NewUUIDv7 生成 UUIDv7：前 48 位为毫秒时间戳，同一毫秒内以 12 位计数器保证单调递增
+func NewUUIDv7() UUID {
+	return defaultUUIDGen.next(time.Now())
+}
+
+type uuidGen struct {
+	mu      sync.Mutex
+	lastMs  int64
+	counter uint16
+}
+
+var defaultUUIDGen = &uuidGen{}
+
+func (g *uuidGen) next(now time.Time) UUID {
+	var u UUID
+	if _, err := rand.Read(u[:]); err != nil {
+		panic("rmodel: crypto/rand failed: " + err.Error())
+	}
+	g.mu.Lock()
+	ms := now.UnixMilli()
+	if ms > g.lastMs {
+		// 新的毫秒从随机值开始计数，预留一半空间避免很快溢出
+		g.lastMs = ms
+		g.counter = binary.BigEndian.Uint16(u[6:8]) & 0x7ff
+	} else {
+		// 时钟回拨或同一毫秒内沿用上次的时间戳，计数器溢出时借用下一毫秒
+		g.counter++
+		if g.counter > 0xfff {
+			g.lastMs++
+			g.counter = 0
+		}
+	}
+	ms, counter := g.lastMs, g.counter
+	g.mu.Unlock()
+
+	u[0] = byte(ms >> 40)
+	u[1] = byte(ms >> 32)
+	u[2] = byte(ms >> 24)
+	u[3] = byte(ms >> 16)
+	u[4] = byte(ms >> 8)
+	u[5] = byte(ms)
+	u[6] = 0x70 | byte(counter>>8)
+	u[7] = byte(counter)
+	u[8] = 0x80 | u[8]&0x3f
+	return u
+}
+
+// ParseUUID 解析标准格式（8-4-4-4-12）的 UUID，大小写均可
+func ParseUUID(s string) (UUID, error) {
+	var u UUID
+	if len(s) != 36 || s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return u, fmt.Errorf("%w: %q", ErrInvalidUUID, s)
+	}
+	b := []byte(s[0:8] + s[9:13] + s[14:18] + s[19:23] + s[24:36])
+	if _, err := hex.Decode(u[:], b); err != nil {
+		return UUID{}, fmt.Errorf("%w: %q", ErrInvalidUUID, s)
+	}
+	return u, nil
+}
+
+// IsZero 是否为全零的 Nil UUID
+func (u UUID) IsZero() bool {
+	return u == UUID{}
+}
+
+// Version UUID 版本号，UUIDv7 为 7
+func (u UUID) Version() int {
+	return int(u[6] >> 4)
+}
+
+// Time UUIDv7 中的毫秒时间戳，其他版本返回零值
+func (u UUID) Time() time.Time {
+	if u.Version() != 7 {
+		return time.Time{}
+	}
+	ms := int64(u[0])<<40 | int64(u[1])<<32 | int64(u[2])<<24 | int64(u[3])<<16 | int64(u[4])<<8 | int64(u[5])
+	return time.UnixMilli(ms)
+}
+
+func (u UUID) String() string {
+	var b [36]byte
+	hex.Encode(b[0:8], u[0:4])
+	b[8] = '-'
+	hex.Encode(b[9:13], u[4:6])
+	b[13] = '-'
+	hex.Encode(b[14:18], u[6:8])
+	b[18] = '-'
+	hex.Encode(b[19:23], u[8:10])
+	b[23] = '-'
+	hex.Encode(b[24:], u[10:])
+	return string(b[:])
+}
+
+// MarshalText 用于 JSON 编码
+func (u UUID) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText 空字符串解析为 Nil UUID
+func (u *UUID) UnmarshalText(b []byte) error {
+	if len(b) == 0 {
+		*u = UUID{}
+		return nil
+	}
+	v, err := ParseUUID(string(b))
+	if err != nil {
+		return err
+	}
+	*u = v
+	return nil
+}
+
+// Value Nil UUID 存为 NULL
+func (u UUID) Value() (driver.Value, error) {
+	if u.IsZero() {
+		return nil, nil
+	}
+	return u.String(), nil
+}
+
+// Scan 支持 NULL、标准格式字符串及 16 字节二进制
+func (u *UUID) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*u = UUID{}
+		return nil
+	case string:
+		return u.UnmarshalText([]byte(v))
+	case []byte:
+		if len(v) == 16 {
+			copy(u[:], v)
+			return nil
+		}
+		return u.UnmarshalText(v)
+	}
+	return fmt.Errorf("%w: cannot scan %T", ErrInvalidUUID, src)
+}
+
+// GormDataType 配合 size:36 在各数据库中映射为定长字符串列
+func (UUID) GormDataType() string {
+	return "string"
+}
+
+// 雪花 ID 布局：1 位符号 + 41 位毫秒时间戳 + 10 位节点 + 12 位序列号
+const (
+	snowflakeNodeBits = 10
+	snowflakeSeqBits  = 12
+	MaxSnowflakeNode  = 1<<snowflakeNodeBits - 1
+	snowflakeMaxSeq   = 1<<snowflakeSeqBits - 1
+)
+
+// SnowflakeEpoch 雪花 ID 的时间起点，41 位时间戳约可使用 69 年
+var SnowflakeEpoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// SnowflakeID 雪花 ID，数据库和 proto 中为 int64；JSON 编码为字符串，避免 JavaScript 丢失精度
+type SnowflakeID int64
+
+// Snowflake 雪花 ID 生成器，同一节点 ID 在集群内只能被一个进程使用
+type Snowflake struct {
+	mu     sync.Mutex
+	node   int64
+	lastMs int64
+	seq    int64
+}
+
+// NewSnowflake 创建生成器，node 取值 0~1023
+func NewSnowflake(node int64) (*Snowflake, error) {
+	if node < 0 || node > MaxSnowflakeNode {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidNode, node)
+	}
+	return &Snowflake{node: node}, nil
+}
+
+// Next 生成下一个 ID。时钟回拨时沿用上次的时间戳，同一毫秒内序列号用尽时借用下一毫秒，保证单调递增
+func (s *Snowflake) Next() SnowflakeID {
+	ms := time.Since(SnowflakeEpoch).Milliseconds()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ms > s.lastMs {
+		s.lastMs = ms
+		s.seq = 0
+	} else {
+		s.seq++
+		if s.seq > snowflakeMaxSeq {
+			s.lastMs++
+			s.seq = 0
+		}
+	}
+	return SnowflakeID(s.lastMs<<(snowflakeNodeBits+snowflakeSeqBits) | s.node<<snowflakeSeqBits | s.seq)
+}
+
+var (
+	snowflakeMu      sync.RWMutex
+	defaultSnowflake = &Snowflake{}
+)
+
+// SetSnowflakeNode 设置 NewSnowflakeID 及 SnowflakeModel 使用的节点 ID，默认为 0，应在服务启动时调用
+func SetSnowflakeNode(node int64) error {
+	s, err := NewSnowflake(node)
+	if err != nil {
+		return err
+	}
+	snowflakeMu.Lock()
+	defaultSnowflake = s
+	snowflakeMu.Unlock()
+	return nil
+}
+
+// NewSnowflakeID 使用默认生成器生成雪花 ID
+func NewSnowflakeID() SnowflakeID {
+	snowflakeMu.RLock()
+	s := defaultSnowflake
+	snowflakeMu.RUnlock()
+	return s.Next()
+}
+
+// ParseSnowflakeID 解析十进制字符串形式的雪花 ID
+func ParseSnowflakeID(s string) (SnowflakeID, error) {
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidSnowflake, s)
+	}
+	return SnowflakeID(n), nil
+}
+
+// Time ID 中的毫秒时间戳
+func (id SnowflakeID) Time() time.Time {
+	return SnowflakeEpoch.Add(time.Duration(int64(id)>>(snowflakeNodeBits+snowflakeSeqBits)) * time.Millisecond)
+}
+
+// Node 生成该 ID 的节点
+func (id SnowflakeID) Node() int64 {
+	return int64(id) >> snowflakeSeqBits & MaxSnowflakeNode
+}
+
+// Int64 用于写入 proto 的 int64 字段
+func (id SnowflakeID) Int64() int64 {
+	return int64(id)
+}
+
+func (id SnowflakeID) String() string {
+	return strconv.FormatInt(int64(id), 10)
+}
+
+// Value 以 int64 存储
+func (id SnowflakeID) Value() (driver.Value, error) {
+	return int64(id), nil
+}
+
+// Scan 支持整数及十进制字符串
+func (id *SnowflakeID) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*id = 0
+		return nil
+	case int64:
+		*id = SnowflakeID(v)
+		return nil
+	case string:
+		return id.scanString(v)
+	case []byte:
+		return id.scanString(string(v))
+	}
+	return fmt.Errorf("%w: cannot scan %T", ErrInvalidSnowflake, src)
+}
+
+func (id *SnowflakeID) scanString(s string) error {
+	v, err := ParseSnowflakeID(s)
+	if err != nil {
+		return err
+	}
+	*id = v
+	return nil
+}
+
+// MarshalJSON 编码为字符串
+func (id SnowflakeID) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + id.String() + `"`), nil
+}
+
+// UnmarshalJSON 同时接受字符串和数字
+func (id *SnowflakeID) UnmarshalJSON(b []byte) error {
+	s := string(b)
+	if s == "null" {
+		return nil
+	}
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		s = s[1 : len(s)-1]
+	}
+	v, err := ParseSnowflakeID(s)
+	if err != nil {
+		return err
+	}
+	*id = v
+	return nil
+}
@@ -0,0 +1,190 @@
+package rmodel
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestUUIDv7Monotonic(t *testing.T) {
+	g := &uuidGen{}
+	now := time.UnixMilli(1760000000000)
+	tests := []struct {
+		name string
+		at   time.Time
+	}{
+		{"same millisecond", now},
+		{"clock moved back", now.Add(-time.Second)},
+		{"next millisecond", now.Add(time.Millisecond)},
+	}
+	prev := g.next(now)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for range 5000 { // 超过 12 位计数器的剩余空间，验证借用下一毫秒
+				u := g.next(tt.at)
+				if u.String() <= prev.String() {
+					t.Fatalf("%s not after %s", u, prev)
+				}
+				if u.Version() != 7 || u[8]&0xc0 != 0x80 {
+					t.Fatalf("bad version/variant: %s", u)
+				}
+				prev = u
+			}
+		})
+	}
+	if got := g.next(now).Time(); got.Before(now) {
+		t.Fatalf("Time = %v, want >= %v", got, now)
+	}
+}
+
+func TestParseUUID(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"0190a1b2-c3d4-7e5f-8a6b-7c8d9e0f1a2b", "0190a1b2-c3d4-7e5f-8a6b-7c8d9e0f1a2b", false},
+		{"0190A1B2-C3D4-7E5F-8A6B-7C8D9E0F1A2B", "0190a1b2-c3d4-7e5f-8a6b-7c8d9e0f1a2b", false},
+		{"0190a1b2c3d47e5f8a6b7c8d9e0f1a2b", "", true},
+		{"0190a1b2-c3d4-7e5f-8a6b_7c8d9e0f1a2b", "", true},
+		{"0190a1b2-c3d4-7e5f-8a6b-7c8d9e0f1a2g", "", true},
+		{"", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			u, err := ParseUUID(tt.in)
+			if (err != nil) != tt.wantErr || (err != nil && !errors.Is(err, ErrInvalidUUID)) {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && u.String() != tt.want {
+				t.Fatalf("String = %s, want %s", u, tt.want)
+			}
+		})
+	}
+}
+
+func TestUUIDScanValue(t *testing.T) {
+	u := NewUUIDv7()
+	tests := []struct {
+		name    string
+		src     any
+		want    UUID
+		wantErr bool
+	}{
+		{"null", nil, UUID{}, false},
+		{"string", u.String(), u, false},
+		{"text bytes", []byte(u.String()), u, false},
+		{"binary", u[:], u, false},
+		{"empty string", "", UUID{}, false},
+		{"bad string", "nope", UUID{}, true},
+		{"bad type", int64(1), UUID{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewUUIDv7()
+			err := got.Scan(tt.src)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Scan err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got != tt.want {
+				t.Fatalf("Scan = %s, want %s", got, tt.want)
+			}
+		})
+	}
+	if v, _ := (UUID{}).Value(); v != nil {
+		t.Fatalf("zero Value = %v, want nil", v)
+	}
+	if v, _ := u.Value(); v != u.String() {
+		t.Fatalf("Value = %v, want %s", v, u)
+	}
+}
+
+func TestSnowflake(t *testing.T) {
+	for _, node := range []int64{-1, MaxSnowflakeNode + 1} {
+		if _, err := NewSnowflake(node); !errors.Is(err, ErrInvalidNode) {
+			t.Fatalf("NewSnowflake(%d) err = %v, want ErrInvalidNode", node, err)
+		}
+	}
+	s, err := NewSnowflake(42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now().Add(-time.Millisecond)
+	prev := s.Next()
+	for range 10000 {
+		id := s.Next()
+		if id <= prev {
+			t.Fatalf("%d not after %d", id, prev)
+		}
+		prev = id
+	}
+	if prev.Node() != 42 {
+		t.Fatalf("Node = %d, want 42", prev.Node())
+	}
+	if at := prev.Time(); at.Before(start) || at.After(time.Now().Add(time.Second)) {
+		t.Fatalf("Time = %v, want around now", at)
+	}
+}
+
+func TestSnowflakeIDEncoding(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		want    SnowflakeID
+		wantErr bool
+	}{
+		{"string", `"123456789012345678"`, 123456789012345678, false},
+		{"number", `42`, 42, false},
+		{"null", `null`, 0, false},
+		{"negative", `"-1"`, 0, true},
+		{"garbage", `"abc"`, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var id SnowflakeID
+			err := json.Unmarshal([]byte(tt.json), &id)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if id != tt.want {
+				t.Fatalf("Unmarshal = %d, want %d", id, tt.want)
+			}
+		})
+	}
+	b, _ := json.Marshal(SnowflakeID(123456789012345678))
+	if string(b) != `"123456789012345678"` {
+		t.Fatalf("Marshal = %s", b)
+	}
+}
+
+func TestSnowflakeIDScanValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     any
+		want    SnowflakeID
+		wantErr bool
+	}{
+		{"null", nil, 0, false},
+		{"int64", int64(99), 99, false},
+		{"string", "100", 100, false},
+		{"bytes", []byte("101"), 101, false},
+		{"bad string", "x", 0, true},
+		{"bad type", 1.5, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var id SnowflakeID
+			err := id.Scan(tt.src)
+			if (err != nil) != tt.wantErr || (err != nil && !errors.Is(err, ErrInvalidSnowflake)) {
+				t.Fatalf("Scan err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if id != tt.want {
+				t.Fatalf("Scan = %d, want %d", id, tt.want)
+			}
+		})
+	}
+	if v, _ := SnowflakeID(7).Value(); v != int64(7) {
+		t.Fatalf("Value = %#v, want int64(7)", v)
+	}
+}
@@ -0,0 +1,555 @@
+// Package protomap 在基于 rmodel.BaseModel 的 GORM 模型与生成的 proto 消息之间按字段名转换。
+//
+// 模型字段按 `pb:"name"` 标签或 Go 字段名的 snake_case 形式（ID -> id，CreatedAt -> created_at）
+// 匹配 proto 字段，`pb:"-"` 表示忽略；匿名嵌入的结构体（如 BaseModel）会被展开。
+// 两边未匹配的字段保持不变。整数在不同宽度和符号之间转换时做溢出检查，不会静默截断；
+// time.Time 与 google.protobuf.Timestamp 互转，零值对应未设置，也可映射到 RFC3339 格式的 string 字段；
+// rmodel.UUID 映射到 string 字段，rmodel.SnowflakeID 映射到整数字段或十进制 string 字段，零值均对应未设置。
+package protomap
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/trancecho/mundo-proto-sdk/rmodel"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrOverflow    = errors.New("protomap: integer out of range")
+	ErrUnsupported = errors.New("protomap: unsupported field type")
+	ErrInvalidTime = errors.New("protomap: invalid time")
+	ErrInvalidDest = errors.New("protomap: destination must be a non-nil struct pointer")
+)
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	deletedAtType = reflect.TypeOf(gorm.DeletedAt{})
+	messageType   = reflect.TypeOf((*proto.Message)(nil)).Elem()
+	uuidType      = reflect.TypeOf(rmodel.UUID{})
+	snowflakeType = reflect.TypeOf(rmodel.SnowflakeID(0))
+)
+
+const timestampName = "google.protobuf.Timestamp"
+
+// ToProto 将模型 model（结构体或结构体指针）的字段写入 msg
+func ToProto(model any, msg proto.Message) error {
+	v := reflect.Indirect(reflect.ValueOf(model))
+	if v.Kind() != reflect.Struct {
+		return fmt.Errorf("%w: %T", ErrUnsupported, model)
+	}
+	return toMessage(v, msg.ProtoReflect(), "")
+}
+
+// FromProto 将 msg 的字段写入模型 model（结构体指针），覆盖所有匹配的字段
+func FromProto(msg proto.Message, model any) error {
+	v := reflect.ValueOf(model)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return ErrInvalidDest
+	}
+	return fromMessage(msg.ProtoReflect(), v.Elem(), "")
+}
+
+// To 由模型构造 proto 消息，如 To[*forum.ForumPostResponse](&post)
+func To[P proto.Message](model any) (P, error) {
+	var zero P
+	msg := zero.ProtoReflect().Type().New().Interface().(P)
+	if err := ToProto(model, msg); err != nil {
+		return zero, err
+	}
+	return msg, nil
+}
+
+// From 由 proto 消息构造模型，如 From[Post](resp)
+func From[M any](msg proto.Message) (*M, error) {
+	m := new(M)
+	if err := FromProto(msg, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ToSlice 批量转换模型，任一元素失败时返回错误
+func ToSlice[P proto.Message, M any](models []M) ([]P, error) {
+	out := make([]P, 0, len(models))
+	for i := range models {
+		msg, err := To[P](&models[i])
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, msg)
+	}
+	return out, nil
+}
+
+// FromSlice 批量转换 proto 消息，任一元素失败时返回错误
+func FromSlice[M any, P proto.Message](msgs []P) ([]M, error) {
+	out := make([]M, len(msgs))
+	for i, msg := range msgs {
+		if err := FromProto(msg, &out[i]); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+type field struct {
+	index  []int
+	goName string
+	pbName protoreflect.Name
+}
+
+var fieldCache sync.Map // reflect.Type -> []field
+
+func fieldsOf(t reflect.Type) []field {
+	if fs, ok := fieldCache.Load(t); ok {
+		return fs.([]field)
+	}
+	var fs []field
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag, tagged := sf.Tag.Lookup("pb")
+		if tag == "-" {
+			continue
+		}
+		if sf.Anonymous && !tagged && sf.Type.Kind() == reflect.Struct && sf.Type != timeType {
+			for _, sub := range fieldsOf(sf.Type) {
+				sub.index = append([]int{i}, sub.index...)
+				fs = append(fs, sub)
+			}
+			continue
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		name := tag
+		if name == "" {
+			name = snakeCase(sf.Name)
+		}
+		fs = append(fs, field{index: []int{i}, goName: sf.Name, pbName: protoreflect.Name(name)})
+	}
+	// 与 Go 的字段提升规则一致，同名时外层字段覆盖嵌入结构体中的字段
+	out := make([]field, 0, len(fs))
+	for _, f := range fs {
+		if !slices.ContainsFunc(fs, func(o field) bool { return o.pbName == f.pbName && len(o.index) < len(f.index) }) {
+			out = append(out, f)
+		}
+	}
+	fieldCache.Store(t, out)
+	return out
+}
+
+// snakeCase 将 Go 字段名转换为 proto 字段名，连续大写视为一个缩写：UserID -> user_id，HTTPCode -> http_code
+func snakeCase(s string) string {
+	rs := []rune(s)
+	var b strings.Builder
+	for i, r := range rs {
+		if unicode.IsUpper(r) && i > 0 {
+			prev := rs[i-1]
+			nextLower := i+1 < len(rs) && unicode.IsLower(rs[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+func toMessage(v reflect.Value, msg protoreflect.Message, prefix string) error {
+	fds := msg.Descriptor().Fields()
+	for _, f := range fieldsOf(v.Type()) {
+		fd := fds.ByName(f.pbName)
+		if fd == nil {
+			continue
+		}
+		path := prefix + f.goName
+		fv := v.FieldByIndex(f.index)
+		switch {
+		case fd.IsMap():
+			return fmt.Errorf("%w: %s", ErrUnsupported, path)
+		case fd.IsList():
+			if fv.Kind() != reflect.Slice && fv.Kind() != reflect.Array {
+				return fmt.Errorf("%w: %s", ErrUnsupported, path)
+			}
+			msg.Clear(fd)
+			if fv.Len() == 0 {
+				continue
+			}
+			list := msg.Mutable(fd).List()
+			for i := 0; i < fv.Len(); i++ {
+				pv, ok, err := toValue(fd, fv.Index(i), list.NewElement, fmt.Sprintf("%s[%d]", path, i))
+				if err != nil {
+					return err
+				}
+				if ok {
+					list.Append(pv)
+				}
+			}
+		default:
+			pv, ok, err := toValue(fd, fv, func() protoreflect.Value { return msg.NewField(fd) }, path)
+			if err != nil {
+				return err
+			}
+			if ok {
+				msg.Set(fd, pv)
+			} else {
+				msg.Clear(fd)
+			}
+		}
+	}
+	return nil
+}
+
+// toValue 转换单个值，ok 为 false 表示对应 proto 字段应保持未设置
+func toValue(fd protoreflect.FieldDescriptor, v reflect.Value, newMsg func() protoreflect.Value, path string) (protoreflect.Value, bool, error) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return protoreflect.Value{}, false, nil
+		}
+		if m, ok := v.Interface().(proto.Message); ok && fd.Kind() == protoreflect.MessageKind &&
+			m.ProtoReflect().Descriptor().FullName() == fd.Message().FullName() {
+			return protoreflect.ValueOfMessage(proto.Clone(m).ProtoReflect()), true, nil
+		}
+		v = v.Elem()
+	}
+	if v.Type() == deletedAtType {
+		dt := v.Interface().(gorm.DeletedAt)
+		if !dt.Valid {
+			return protoreflect.Value{}, false, nil
+		}
+		v = reflect.ValueOf(dt.Time)
+	}
+	if v.Type() == timeType {
+		t := v.Interface().(time.Time)
+		switch {
+		case t.IsZero():
+			return protoreflect.Value{}, false, nil
+		case fd.Kind() == protoreflect.StringKind:
+			return protoreflect.ValueOfString(t.Format(time.RFC3339Nano)), true, nil
+		case fd.Kind() == protoreflect.MessageKind && fd.Message().FullName() == timestampName:
+			return protoreflect.ValueOfMessage(timestamppb.New(t).ProtoReflect()), true, nil
+		}
+		return protoreflect.Value{}, false, fmt.Errorf("%w: %s", ErrUnsupported, path)
+	}
+	if v.Type() == uuidType || (v.Type() == snowflakeType && fd.Kind() == protoreflect.StringKind) {
+		return idToValue(fd, v, path)
+	}
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		if v.Kind() == reflect.Bool {
+			return protoreflect.ValueOfBool(v.Bool()), true, nil
+		}
+	case protoreflect.StringKind:
+		if v.Kind() == reflect.String {
+			return protoreflect.ValueOfString(v.String()), true, nil
+		}
+	case protoreflect.BytesKind:
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			return protoreflect.ValueOfBytes(v.Bytes()), true, nil
+		}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind, protoreflect.EnumKind:
+		if !isInt(v) {
+			break
+		}
+		n, ok := toInt64(v)
+		if !ok || n < -1<<31 || n > 1<<31-1 {
+			return protoreflect.Value{}, false, overflowError(path)
+		}
+		if fd.Kind() == protoreflect.EnumKind {
+			return protoreflect.ValueOfEnum(protoreflect.EnumNumber(n)), true, nil
+		}
+		return protoreflect.ValueOfInt32(int32(n)), true, nil
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		if !isInt(v) {
+			break
+		}
+		n, ok := toInt64(v)
+		if !ok {
+			return protoreflect.Value{}, false, overflowError(path)
+		}
+		return protoreflect.ValueOfInt64(n), true, nil
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		if !isInt(v) {
+			break
+		}
+		n, ok := toUint64(v)
+		if !ok || n > 1<<32-1 {
+			return protoreflect.Value{}, false, overflowError(path)
+		}
+		return protoreflect.ValueOfUint32(uint32(n)), true, nil
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		if !isInt(v) {
+			break
+		}
+		n, ok := toUint64(v)
+		if !ok {
+			return protoreflect.Value{}, false, overflowError(path)
+		}
+		return protoreflect.ValueOfUint64(n), true, nil
+	case protoreflect.FloatKind:
+		if v.Kind() == reflect.Float32 || v.Kind() == reflect.Float64 {
+			return protoreflect.ValueOfFloat32(float32(v.Float())), true, nil
+		}
+	case protoreflect.DoubleKind:
+		if v.Kind() == reflect.Float32 || v.Kind() == reflect.Float64 {
+			return protoreflect.ValueOfFloat64(v.Float()), true, nil
+		}
+	case protoreflect.MessageKind:
+		if v.Kind() == reflect.Struct {
+			pv := newMsg()
+			if err := toMessage(v, pv.Message(), path+"."); err != nil {
+				return protoreflect.Value{}, false, err
+			}
+			return pv, true, nil
+		}
+	}
+	return protoreflect.Value{}, false, unsupportedError(path, v.Type().String(), fd.Kind().String())
+}
+
+func fromMessage(msg protoreflect.Message, v reflect.Value, prefix string) error {
+	fds := msg.Descriptor().Fields()
+	for _, f := range fieldsOf(v.Type()) {
+		fd := fds.ByName(f.pbName)
+		if fd == nil {
+			continue
+		}
+		path := prefix + f.goName
+		fv := v.FieldByIndex(f.index)
+		switch {
+		case fd.IsMap():
+			return fmt.Errorf("%w: %s", ErrUnsupported, path)
+		case fd.IsList():
+			if fv.Kind() != reflect.Slice {
+				return fmt.Errorf("%w: %s", ErrUnsupported, path)
+			}
+			list := msg.Get(fd).List()
+			if list.Len() == 0 {
+				fv.SetZero()
+				continue
+			}
+			s := reflect.MakeSlice(fv.Type(), list.Len(), list.Len())
+			for i := 0; i < list.Len(); i++ {
+				if err := fromValue(fd, list.Get(i), true, s.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+			fv.Set(s)
+		default:
+			if err := fromValue(fd, msg.Get(fd), msg.Has(fd), fv, path); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// fromValue 将 proto 值写入 v，has 为 false 的 message 字段写入零值
+func fromValue(fd protoreflect.FieldDescriptor, pv protoreflect.Value, has bool, v reflect.Value, path string) error {
+	if fd.Kind() == protoreflect.MessageKind && !has {
+		v.SetZero()
+		return nil
+	}
+	if v.Kind() == reflect.Pointer {
+		if v.Type().Implements(messageType) && fd.Kind() == protoreflect.MessageKind {
+			m := proto.Clone(pv.Message().Interface())
+			if reflect.TypeOf(m) == v.Type() {
+				v.Set(reflect.ValueOf(m))
+				return nil
+			}
+		}
+		elem := reflect.New(v.Type().Elem())
+		if err := fromValue(fd, pv, has, elem.Elem(), path); err != nil {
+			return err
+		}
+		v.Set(elem)
+		return nil
+	}
+	if v.Type() == timeType || v.Type() == deletedAtType {
+		var t time.Time
+		switch {
+		case fd.Kind() == protoreflect.StringKind:
+			if s := pv.String(); s != "" {
+				var err error
+				if t, err = time.Parse(time.RFC3339Nano, s); err != nil {
+					return fmt.Errorf("%w: %s", ErrInvalidTime, path)
+				}
+			}
+		case fd.Kind() == protoreflect.MessageKind && fd.Message().FullName() == timestampName:
+			ts := &timestamppb.Timestamp{}
+			proto.Merge(ts, pv.Message().Interface())
+			if err := ts.CheckValid(); err != nil {
+				return fmt.Errorf("%w: %s", ErrInvalidTime, path)
+			}
+			t = ts.AsTime()
+		default:
+			return fmt.Errorf("%w: %s", ErrUnsupported, path)
+		}
+		if v.Type() == deletedAtType {
+			v.Set(reflect.ValueOf(gorm.DeletedAt{Time: t, Valid: !t.IsZero()}))
+		} else {
+			v.Set(reflect.ValueOf(t))
+		}
+		return nil
+	}
+	if v.Type() == uuidType || (v.Type() == snowflakeType && fd.Kind() == protoreflect.StringKind) {
+		return idFromValue(fd, pv, v, path)
+	}
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		if v.Kind() == reflect.Bool {
+			v.SetBool(pv.Bool())
+			return nil
+		}
+	case protoreflect.StringKind:
+		if v.Kind() == reflect.String {
+			v.SetString(pv.String())
+			return nil
+		}
+	case protoreflect.BytesKind:
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			v.SetBytes(append([]byte(nil), pv.Bytes()...))
+			return nil
+		}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind, protoreflect.EnumKind:
+		var n int64
+		if fd.Kind() == protoreflect.EnumKind {
+			n = int64(pv.Enum())
+		} else {
+			n = pv.Int()
+		}
+		if !isInt(v) {
+			break
+		}
+		if !setInt(v, n) {
+			return overflowError(path)
+		}
+		return nil
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		if !isInt(v) {
+			break
+		}
+		if !setUint(v, pv.Uint()) {
+			return overflowError(path)
+		}
+		return nil
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		if v.Kind() == reflect.Float32 || v.Kind() == reflect.Float64 {
+			v.SetFloat(pv.Float())
+			return nil
+		}
+	case protoreflect.MessageKind:
+		if v.Kind() == reflect.Struct {
+			return fromMessage(pv.Message(), v, path+".")
+		}
+	}
+	return unsupportedError(path, fd.Kind().String(), v.Type().String())
+}
+
+// idToValue 将 rmodel.UUID 或 rmodel.SnowflakeID 写为 string 字段，零值对应未设置；
+// SnowflakeID 写入整数字段时走普通整数转换
+func idToValue(fd protoreflect.FieldDescriptor, v reflect.Value, path string) (protoreflect.Value, bool, error) {
+	if fd.Kind() != protoreflect.StringKind {
+		return protoreflect.Value{}, false, unsupportedError(path, v.Type().String(), fd.Kind().String())
+	}
+	if v.IsZero() {
+		return protoreflect.Value{}, false, nil
+	}
+	return protoreflect.ValueOfString(v.Interface().(fmt.Stringer).String()), true, nil
+}
+
+// idFromValue 解析 string 字段中的 rmodel.UUID 或 rmodel.SnowflakeID，空字符串对应零值
+func idFromValue(fd protoreflect.FieldDescriptor, pv protoreflect.Value, v reflect.Value, path string) error {
+	if fd.Kind() != protoreflect.StringKind {
+		return unsupportedError(path, fd.Kind().String(), v.Type().String())
+	}
+	s := pv.String()
+	if s == "" {
+		v.SetZero()
+		return nil
+	}
+	var id any
+	var err error
+	if v.Type() == uuidType {
+		id, err = rmodel.ParseUUID(s)
+	} else {
+		id, err = rmodel.ParseSnowflakeID(s)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	v.Set(reflect.ValueOf(id))
+	return nil
+}
+
+func overflowError(path string) error {
+	return fmt.Errorf("%w: %s", ErrOverflow, path)
+}
+
+func unsupportedError(path, from, to string) error {
+	return fmt.Errorf("%w: %s (%s -> %s)", ErrUnsupported, path, from, to)
+}
+
+func isInt(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+	return false
+}
+
+func toInt64(v reflect.Value) (int64, bool) {
+	if v.CanInt() {
+		return v.Int(), true
+	}
+	u := v.Uint()
+	return int64(u), u <= 1<<63-1
+}
+
+func toUint64(v reflect.Value) (uint64, bool) {
+	if v.CanUint() {
+		return v.Uint(), true
+	}
+	n := v.Int()
+	return uint64(n), n >= 0
+}
+
+func setInt(v reflect.Value, n int64) bool {
+	if v.CanInt() {
+		if v.OverflowInt(n) {
+			return false
+		}
+		v.SetInt(n)
+		return true
+	}
+	if n < 0 {
+		return false
+	}
+	return setUint(v, uint64(n))
+}
+
+func setUint(v reflect.Value, n uint64) bool {
+	if v.CanUint() {
+		if v.OverflowUint(n) {
+			return false
+		}
+		v.SetUint(n)
+		return true
+	}
+	if n > 1<<63-1 || v.OverflowInt(int64(n)) {
+		return false
+	}
+	v.SetInt(int64(n))
+	return true
+}
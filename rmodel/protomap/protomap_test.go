@@ -0,0 +1,234 @@
+package protomap
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	commonv1 "github.com/trancecho/mundo-proto-sdk/common/v1"
+	forum "github.com/trancecho/mundo-proto-sdk/forum_pb"
+	messagev1 "github.com/trancecho/mundo-proto-sdk/message/v1"
+	pointv1 "github.com/trancecho/mundo-proto-sdk/points-system/v1"
+	"github.com/trancecho/mundo-proto-sdk/rmodel"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type post struct {
+	rmodel.VersionedModel
+	Uid      int64
+	Title    string
+	Tags     []string
+	Category string `pb:"-"`
+	Status   int32
+	Views    int64 `pb:"view_count"`
+	Author   *commonv1.UserRef
+	internal string
+}
+
+type message struct {
+	rmodel.UUIDModel
+	Content string
+	State   uint8
+	ReadAt  *time.Time
+}
+
+func TestRoundTrip(t *testing.T) {
+	at := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+	p := post{Uid: 7, Title: "hi", Tags: []string{"go"}, Category: "c", Status: 2, Views: 9,
+		Author: &commonv1.UserRef{Uid: 7, Muid: "m7"}, internal: "x"}
+	p.ID, p.Version, p.CreatedAt = 3, 4, at
+
+	msg, err := To[*forum.ForumPostResponse](&p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &forum.ForumPostResponse{Id: 3, Uid: 7, Title: "hi", Tags: []string{"go"}, Status: 2, ViewCount: 9, Version: 4,
+		Author: &commonv1.UserRef{Uid: 7, Muid: "m7"}, CreatedAt: timestamppb.New(at)}
+	if !proto.Equal(msg, want) {
+		t.Fatalf("To = %v, want %v", msg, want)
+	}
+	if msg.Author == p.Author {
+		t.Fatal("message field not cloned")
+	}
+
+	got, err := From[post](msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != 3 || got.Version != 4 || !got.CreatedAt.Equal(at) || !got.UpdatedAt.IsZero() || got.Views != 9 ||
+		!slices.Equal(got.Tags, p.Tags) || got.Category != "" || !proto.Equal(got.Author, p.Author) {
+		t.Fatalf("From = %+v", got)
+	}
+}
+
+func TestTextAndStringTime(t *testing.T) {
+	at := time.Date(2026, 10, 19, 8, 0, 0, 5, time.UTC)
+	m := message{Content: "hi", State: 2, ReadAt: &at}
+	m.ID, m.CreatedAt = rmodel.NewUUIDv7(), at
+
+	msg, err := To[*messagev1.Message](m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.GetId() != m.ID.String() || msg.GetCreatedAt() != at.Format(time.RFC3339Nano) ||
+		!msg.GetReadAt().AsTime().Equal(at) || msg.GetState() != messagev1.MessageStatus(2) {
+		t.Fatalf("To = %v", msg)
+	}
+	got, err := From[message](msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != m.ID || !got.CreatedAt.Equal(at) || got.ReadAt == nil || !got.ReadAt.Equal(at) || got.State != 2 {
+		t.Fatalf("From = %+v", got)
+	}
+
+	// 零值对应未设置
+	empty, err := To[*messagev1.Message](message{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if empty.GetId() != "" || empty.GetCreatedAt() != "" || empty.ReadAt != nil {
+		t.Fatalf("To(zero) = %v", empty)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	big := post{}
+	big.ID = 1 << 63
+	tests := []struct {
+		name    string
+		run     func() error
+		wantErr error
+	}{
+		{"to non struct", func() error { return ToProto(1, &forum.ForumPostResponse{}) }, ErrUnsupported},
+		{"to uint overflow", func() error { return ToProto(big, &forum.ForumPostResponse{}) }, ErrOverflow},
+		{"to type mismatch", func() error {
+			return ToProto(struct{ Title int }{1}, &forum.ForumPostResponse{})
+		}, ErrUnsupported},
+		{"to map field", func() error {
+			return ToProto(struct{ Users []string }{}, &pointv1.BatchGetUserInfoResponse{})
+		}, ErrUnsupported},
+		{"from non pointer", func() error { return FromProto(&forum.ForumPostResponse{}, post{}) }, ErrInvalidDest},
+		{"from nil pointer", func() error { return FromProto(&forum.ForumPostResponse{}, (*post)(nil)) }, ErrInvalidDest},
+		{"from negative into uint", func() error { return FromProto(&forum.ForumPostResponse{Id: -1}, &post{}) }, ErrOverflow},
+		{"from narrow int", func() error {
+			return FromProto(&messagev1.Message{State: 300}, &message{})
+		}, ErrOverflow},
+		{"from invalid timestamp", func() error {
+			return FromProto(&forum.ForumPostResponse{CreatedAt: &timestamppb.Timestamp{Seconds: 1 << 62}}, &post{})
+		}, ErrInvalidTime},
+		{"from invalid string time", func() error { return FromProto(&messagev1.Message{CreatedAt: "yesterday"}, &message{}) }, ErrInvalidTime},
+		{"from invalid uuid", func() error { return FromProto(&messagev1.Message{Id: "nope"}, &message{}) }, rmodel.ErrInvalidUUID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.run(); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// textID 实现了 encoding.TextMarshaler/TextUnmarshaler 但不是 rmodel 的 ID 类型，不会被映射到 string 字段
+type textID struct{ v string }
+
+func (t textID) MarshalText() ([]byte, error) { return []byte(t.v), nil }
+
+func (t *textID) UnmarshalText(b []byte) error { t.v = string(b); return nil }
+
+func TestIDs(t *testing.T) {
+	u := rmodel.NewUUIDv7()
+	sf := rmodel.NewSnowflakeID()
+	type uuidModel struct{ ID rmodel.UUID }
+	type snowflakeModel struct{ ID rmodel.SnowflakeID }
+	tests := []struct {
+		name    string
+		model   any
+		msg     proto.Message
+		want    proto.Message
+		wantErr error
+	}{
+		{"uuid to string", uuidModel{u}, &messagev1.Message{}, &messagev1.Message{Id: u.String()}, nil},
+		{"zero uuid unset", uuidModel{}, &messagev1.Message{Id: "old"}, &messagev1.Message{}, nil},
+		{"uuid to int", uuidModel{u}, &forum.ForumPostResponse{}, nil, ErrUnsupported},
+		{"snowflake to string", snowflakeModel{sf}, &messagev1.Message{}, &messagev1.Message{Id: sf.String()}, nil},
+		{"zero snowflake unset", snowflakeModel{}, &messagev1.Message{Id: "old"}, &messagev1.Message{}, nil},
+		{"snowflake to int", snowflakeModel{sf}, &forum.ForumPostResponse{}, &forum.ForumPostResponse{Id: sf.Int64()}, nil},
+		{"other text marshaler", struct{ ID textID }{textID{"x"}}, &messagev1.Message{}, nil, ErrUnsupported},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ToProto(tt.model, tt.msg)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ToProto err = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !proto.Equal(tt.msg, tt.want) {
+				t.Fatalf("ToProto = %v, want %v", tt.msg, tt.want)
+			}
+			back := reflect.New(reflect.TypeOf(tt.model))
+			if err := FromProto(tt.msg, back.Interface()); err != nil {
+				t.Fatal(err)
+			}
+			if got := back.Elem().Interface(); got != tt.model {
+				t.Fatalf("FromProto = %+v, want %+v", got, tt.model)
+			}
+		})
+	}
+
+	fromTests := []struct {
+		name    string
+		msg     proto.Message
+		dest    any
+		wantErr error
+	}{
+		{"invalid uuid", &messagev1.Message{Id: "nope"}, &uuidModel{}, rmodel.ErrInvalidUUID},
+		{"invalid snowflake", &messagev1.Message{Id: "-1"}, &snowflakeModel{}, rmodel.ErrInvalidSnowflake},
+		{"uuid from int", &forum.ForumPostResponse{Id: 1}, &uuidModel{}, ErrUnsupported},
+		{"other text unmarshaler", &messagev1.Message{Id: "x"}, &struct{ ID textID }{}, ErrUnsupported},
+	}
+	for _, tt := range fromTests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := FromProto(tt.msg, tt.dest); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("FromProto err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSlices(t *testing.T) {
+	posts := []post{{Title: "a"}, {Title: "b"}}
+	msgs, err := ToSlice[*forum.ForumPostResponse](posts)
+	if err != nil || len(msgs) != 2 || msgs[1].GetTitle() != "b" {
+		t.Fatalf("ToSlice = %v, %v", msgs, err)
+	}
+	back, err := FromSlice[post](msgs)
+	if err != nil || len(back) != 2 || back[0].Title != "a" {
+		t.Fatalf("FromSlice = %+v, %v", back, err)
+	}
+	msgs[0].Id = -1
+	if _, err := FromSlice[post](msgs); !errors.Is(err, ErrOverflow) {
+		t.Fatalf("FromSlice err = %v, want ErrOverflow", err)
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	tests := map[string]string{
+		"ID":        "id",
+		"UserID":    "user_id",
+		"CreatedAt": "created_at",
+		"HTTPCode":  "http_code",
+		"V2Name":    "v2_name",
+		"Title":     "title",
+	}
+	for in, want := range tests {
+		if got := snakeCase(in); got != want {
+			t.Errorf("snakeCase(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
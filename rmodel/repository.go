@@ -0,0 +1,255 @@
+package rmodel
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrNotFound        = errors.New("rmodel: record not found")
+	ErrVersionConflict = errors.New("rmodel: version conflict")
+	ErrInvalidCursor   = errors.New("rmodel: invalid cursor")
+)
+
+// updateOmit Update 不覆盖的列：主键、创建和删除相关字段（含 AuditedModel 的 created_by、deleted_by）
+var updateOmit = []string{"id", "created_at", "created_by", "deleted_at", "deleted_by"}
+
+const (
+	DefaultListLimit = 20
+	MaxListLimit     = 100
+)
+
+// ID 仓储支持的主键类型
+type ID interface {
+	uint | SnowflakeID | UUID
+}
+
+// Identifiable 嵌入 BaseModel、UUIDModel 或 SnowflakeModel 的模型（及其指针）自动满足，K 为主键类型
+type Identifiable[K ID] interface {
+	GetID() K
+}
+
+// Versioned 嵌入 VersionedModel 的模型（指针）自动满足
+type Versioned interface {
+	GetVersion() int64
+	SetVersion(int64)
+}
+
+// RepositoryOf 通用仓储，K 为主键类型，T 为嵌入 BaseModel、VersionedModel、UUIDModel 或 SnowflakeModel 的模型结构体，
+// PT 为 *T，编译期保证模型的 GetID 返回 K
+type RepositoryOf[K ID, T any, PT interface {
+	*T
+	Identifiable[K]
+}] struct {
+	db *gorm.DB
+}
+
+// Repository 以 uint 为主键（嵌入 BaseModel 或 VersionedModel）的仓储
+type Repository[T any, PT interface {
+	*T
+	Identifiable[uint]
+}] = RepositoryOf[uint, T, PT]
+
+// NewRepository 创建以 uint 为主键的仓储，PT 由编译器推导，如 NewRepository[Post](db)
+func NewRepository[T any, PT interface {
+	*T
+	Identifiable[uint]
+}](db *gorm.DB) *Repository[T, PT] {
+	return &Repository[T, PT]{db: db}
+}
+
+// NewRepositoryOf 创建以 K 为主键的仓储，如 NewRepositoryOf[rmodel.UUID, Item](db)
+func NewRepositoryOf[K ID, T any, PT interface {
+	*T
+	Identifiable[K]
+}](db *gorm.DB) *RepositoryOf[K, T, PT] {
+	return &RepositoryOf[K, T, PT]{db: db}
+}
+
+// DB 返回绑定 ctx 的 *gorm.DB，用于仓储未覆盖的查询
+func (r *RepositoryOf[K, T, PT]) DB(ctx context.Context) *gorm.DB {
+	return r.db.WithContext(ctx)
+}
+
+// ListOptions 列表查询参数
+type ListOptions struct {
+	Cursor string                     // 上一页返回的游标，首页为空
+	Limit  int                        // 每页数量，默认 20，最大 100
+	Asc    bool                       // 按 ID 正序，默认倒序；UUIDv7 和雪花 ID 按生成时间排序
+	Scope  func(db *gorm.DB) *gorm.DB // 附加的查询条件，如 Where("uid = ?", uid)
+}
+
+// Get 按 ID 查询未删除的记录
+func (r *RepositoryOf[K, T, PT]) Get(ctx context.Context, id K) (*T, error) {
+	var m T
+	// 使用 Find 而非 First，避免 gorm 对 ErrRecordNotFound 打印错误日志；
+	// 不使用 Find(&m, id)，UUID 为数组类型，会被当作多个主键展开
+	res := r.db.WithContext(ctx).Where("id = ?", id).Limit(1).Find(&m)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return nil, ErrNotFound
+	}
+	return &m, nil
+}
+
+// List 按 ID 游标分页查询未删除的记录，next 为空表示没有更多
+func (r *RepositoryOf[K, T, PT]) List(ctx context.Context, opts ListOptions) (items []T, next string, err error) {
+	return r.list(r.db.WithContext(ctx), opts)
+}
+
+// ListDeleted 按 ID 游标分页查询已软删除的记录
+func (r *RepositoryOf[K, T, PT]) ListDeleted(ctx context.Context, opts ListOptions) (items []T, next string, err error) {
+	return r.list(r.db.WithContext(ctx).Unscoped().Where("deleted_at IS NOT NULL"), opts)
+}
+
+func (r *RepositoryOf[K, T, PT]) list(db *gorm.DB, opts ListOptions) ([]T, string, error) {
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = DefaultListLimit
+	}
+	limit = min(limit, MaxListLimit)
+	if opts.Scope != nil {
+		db = opts.Scope(db)
+	}
+	after, ok, err := decodeCursor[K](opts.Cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	if opts.Asc {
+		if ok {
+			db = db.Where("id > ?", after)
+		}
+		db = db.Order("id ASC")
+	} else {
+		if ok {
+			db = db.Where("id < ?", after)
+		}
+		db = db.Order("id DESC")
+	}
+	// 多取一条判断是否还有下一页
+	var items []T
+	if err := db.Limit(limit + 1).Find(&items).Error; err != nil {
+		return nil, "", err
+	}
+	if len(items) <= limit {
+		return items, "", nil
+	}
+	items = items[:limit]
+	return items, encodeCursor(PT(&items[limit-1]).GetID()), nil
+}
+
+// Create 插入记录，VersionedModel 的版本号从 1 开始
+func (r *RepositoryOf[K, T, PT]) Create(ctx context.Context, m *T) error {
+	if v, ok := any(m).(Versioned); ok && v.GetVersion() == 0 {
+		v.SetVersion(1)
+	}
+	return r.db.WithContext(ctx).Create(m).Error
+}
+
+// Update 保存记录除主键和创建、删除信息外的所有字段。嵌入 VersionedModel 时做乐观锁：
+// 仅当数据库中的版本号等于 m 的版本号时更新，并将版本号加 1；否则返回 ErrVersionConflict。
+// 记录不存在或已删除时返回 ErrNotFound。
+func (r *RepositoryOf[K, T, PT]) Update(ctx context.Context, m *T) error {
+	id := PT(m).GetID()
+	db := r.db.WithContext(ctx)
+	v, ok := any(m).(Versioned)
+	if !ok {
+		res := db.Model(m).Where("id = ?", id).Select("*").Omit(updateOmit...).Updates(m)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrNotFound
+		}
+		return nil
+	}
+	expected := v.GetVersion()
+	v.SetVersion(expected + 1)
+	res := db.Model(m).Where("id = ? AND version = ?", id, expected).
+		Select("*").Omit(updateOmit...).Updates(m)
+	if res.Error != nil {
+		v.SetVersion(expected)
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		v.SetVersion(expected)
+		if _, err := r.Get(ctx, id); err != nil {
+			return err
+		}
+		return ErrVersionConflict
+	}
+	return nil
+}
+
+// SoftDelete 软删除记录，记录不存在或已删除时返回 ErrNotFound
+func (r *RepositoryOf[K, T, PT]) SoftDelete(ctx context.Context, id K) error {
+	res := r.db.WithContext(ctx).Where("id = ?", id).Delete(new(T))
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Restore 恢复软删除的记录，记录不存在或未删除时返回 ErrNotFound
+func (r *RepositoryOf[K, T, PT]) Restore(ctx context.Context, id K) error {
+	res := r.db.WithContext(ctx).Unscoped().Model(new(T)).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// HardDeletePurge 物理删除软删除时间早于 olderThan 之前的记录，返回删除的行数
+func (r *RepositoryOf[K, T, PT]) HardDeletePurge(ctx context.Context, olderThan time.Duration) (int64, error) {
+	before := time.Now().Add(-olderThan)
+	res := r.db.WithContext(ctx).Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Delete(new(T))
+	return res.RowsAffected, res.Error
+}
+
+// encodeCursor 游标为主键字符串形式的 base64 编码
+func encodeCursor[K ID](id K) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprint(id)))
+}
+
+// decodeCursor 解析游标，cursor 为空时 ok 为 false
+func decodeCursor[K ID](cursor string) (id K, ok bool, err error) {
+	if cursor == "" {
+		return id, false, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return id, false, ErrInvalidCursor
+	}
+	switch p := any(&id).(type) {
+	case *uint:
+		var n uint64
+		n, err = strconv.ParseUint(string(b), 10, 64)
+		*p = uint(n)
+	case *SnowflakeID:
+		*p, err = ParseSnowflakeID(string(b))
+	case *UUID:
+		*p, err = ParseUUID(string(b))
+	}
+	if err != nil {
+		return id, false, ErrInvalidCursor
+	}
+	return id, true, nil
+}
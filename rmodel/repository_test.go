@@ -0,0 +1,302 @@
+package rmodel_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/trancecho/mundo-proto-sdk/rmodel"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type post struct {
+	rmodel.VersionedModel
+	Title string
+}
+
+type note struct {
+	rmodel.BaseModel
+	Body string
+}
+
+// openDB 每个测试使用独立的内存库
+func openDB(t *testing.T, models ...any) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open("file:"+t.Name()+"?mode=memory&cache=shared"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.AutoMigrate(models...); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return db
+}
+
+func seed(t *testing.T, r *rmodel.Repository[note, *note], n int) []*note {
+	t.Helper()
+	out := make([]*note, n)
+	for i := range out {
+		out[i] = &note{Body: string(rune('a' + i))}
+		if err := r.Create(context.Background(), out[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return out
+}
+
+func TestRepositoryGet(t *testing.T) {
+	ctx := context.Background()
+	r := rmodel.NewRepository[note](openDB(t, &note{}))
+	notes := seed(t, r, 2)
+	if err := r.SoftDelete(ctx, notes[1].ID); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		id      uint
+		want    string
+		wantErr error
+	}{
+		{"existing", notes[0].ID, "a", nil},
+		{"soft deleted", notes[1].ID, "", rmodel.ErrNotFound},
+		{"missing", 999, "", rmodel.ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.Get(ctx, tt.id)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Get(%d) err = %v, want %v", tt.id, err, tt.wantErr)
+			}
+			if err == nil && got.Body != tt.want {
+				t.Fatalf("Get(%d) body = %q, want %q", tt.id, got.Body, tt.want)
+			}
+		})
+	}
+}
+
+func TestRepositoryListCursor(t *testing.T) {
+	ctx := context.Background()
+	r := rmodel.NewRepository[note](openDB(t, &note{}))
+	seed(t, r, 5)
+
+	tests := []struct {
+		name string
+		asc  bool
+		want [][]uint
+	}{
+		{"desc", false, [][]uint{{5, 4}, {3, 2}, {1}}},
+		{"asc", true, [][]uint{{1, 2}, {3, 4}, {5}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cursor string
+			for i, want := range tt.want {
+				items, next, err := r.List(ctx, rmodel.ListOptions{Cursor: cursor, Limit: 2, Asc: tt.asc})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got := ids(items); !slices.Equal(got, want) {
+					t.Fatalf("page %d = %v, want %v", i, got, want)
+				}
+				if last := i == len(tt.want)-1; last != (next == "") {
+					t.Fatalf("page %d next = %q, last page = %v", i, next, last)
+				}
+				cursor = next
+			}
+		})
+	}
+
+	if _, _, err := r.List(ctx, rmodel.ListOptions{Cursor: "!!"}); !errors.Is(err, rmodel.ErrInvalidCursor) {
+		t.Fatalf("invalid cursor err = %v, want ErrInvalidCursor", err)
+	}
+}
+
+func TestRepositorySoftDeleteAndRestore(t *testing.T) {
+	ctx := context.Background()
+	r := rmodel.NewRepository[note](openDB(t, &note{}))
+	notes := seed(t, r, 3)
+
+	if err := r.SoftDelete(ctx, notes[1].ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.SoftDelete(ctx, notes[1].ID); !errors.Is(err, rmodel.ErrNotFound) {
+		t.Fatalf("second SoftDelete err = %v, want ErrNotFound", err)
+	}
+	live, _, _ := r.List(ctx, rmodel.ListOptions{Asc: true})
+	deleted, _, _ := r.ListDeleted(ctx, rmodel.ListOptions{Asc: true})
+	if got := ids(live); !slices.Equal(got, []uint{1, 3}) {
+		t.Fatalf("List after delete = %v", got)
+	}
+	if got := ids(deleted); !slices.Equal(got, []uint{2}) {
+		t.Fatalf("ListDeleted = %v", got)
+	}
+
+	if err := r.Restore(ctx, notes[1].ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Restore(ctx, notes[1].ID); !errors.Is(err, rmodel.ErrNotFound) {
+		t.Fatalf("Restore of live record err = %v, want ErrNotFound", err)
+	}
+	if _, err := r.Get(ctx, notes[1].ID); err != nil {
+		t.Fatalf("Get after Restore: %v", err)
+	}
+
+	if err := r.SoftDelete(ctx, notes[0].ID); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := r.HardDeletePurge(ctx, time.Hour); err != nil || n != 0 {
+		t.Fatalf("purge older than 1h = %d, %v; want 0", n, err)
+	}
+	if n, err := r.HardDeletePurge(ctx, -time.Second); err != nil || n != 1 {
+		t.Fatalf("purge all = %d, %v; want 1", n, err)
+	}
+}
+
+func TestRepositoryUpdateVersion(t *testing.T) {
+	ctx := context.Background()
+	r := rmodel.NewRepository[post](openDB(t, &post{}))
+	p := &post{Title: "v1"}
+	if err := r.Create(ctx, p); err != nil {
+		t.Fatal(err)
+	}
+	if p.Version != 1 {
+		t.Fatalf("created version = %d, want 1", p.Version)
+	}
+
+	a, _ := r.Get(ctx, p.ID)
+	b, _ := r.Get(ctx, p.ID)
+	a.Title = "a"
+	if err := r.Update(ctx, a); err != nil {
+		t.Fatal(err)
+	}
+	if a.Version != 2 {
+		t.Fatalf("version after update = %d, want 2", a.Version)
+	}
+
+	b.Title = "b"
+	if err := r.Update(ctx, b); !errors.Is(err, rmodel.ErrVersionConflict) {
+		t.Fatalf("stale update err = %v, want ErrVersionConflict", err)
+	}
+	if b.Version != 1 {
+		t.Fatalf("stale model version changed to %d", b.Version)
+	}
+	got, _ := r.Get(ctx, p.ID)
+	if got.Title != "a" || got.Version != 2 {
+		t.Fatalf("stored = %q v%d, want \"a\" v2", got.Title, got.Version)
+	}
+
+	if err := r.SoftDelete(ctx, p.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Update(ctx, got); !errors.Is(err, rmodel.ErrNotFound) {
+		t.Fatalf("update of deleted record err = %v, want ErrNotFound", err)
+	}
+}
+
+func ids(items []note) []uint {
+	out := make([]uint, len(items))
+	for i, it := range items {
+		out[i] = it.ID
+	}
+	return out
+}
+
+type uuidItem struct {
+	rmodel.UUIDModel
+	Name string
+}
+
+type snowflakeItem struct {
+	rmodel.SnowflakeModel
+	Name string
+}
+
+func TestRepositoryOfIDTypes(t *testing.T) {
+	t.Run("uuid", func(t *testing.T) {
+		r := rmodel.NewRepositoryOf[rmodel.UUID, uuidItem](openDB(t, &uuidItem{}))
+		checkRepositoryOf(t, r, rmodel.NewUUIDv7())
+		if _, _, err := r.History(context.Background(), rmodel.NewUUIDv7(), rmodel.ListOptions{}); !errors.Is(err, rmodel.ErrAuditUnsupported) {
+			t.Fatalf("History err = %v, want ErrAuditUnsupported", err)
+		}
+	})
+	t.Run("snowflake", func(t *testing.T) {
+		r := rmodel.NewRepositoryOf[rmodel.SnowflakeID, snowflakeItem](openDB(t, &snowflakeItem{}))
+		checkRepositoryOf(t, r, rmodel.NewSnowflakeID())
+	})
+}
+
+// checkRepositoryOf 创建 5 条记录后校验 Get、双向游标分页、软删除和恢复，missing 为不存在的主键
+func checkRepositoryOf[K rmodel.ID, T any, PT interface {
+	*T
+	rmodel.Identifiable[K]
+}](t *testing.T, r *rmodel.RepositoryOf[K, T, PT], missing K) {
+	ctx := context.Background()
+	var ids []K
+	for range 5 {
+		m := new(T)
+		if err := r.Create(ctx, m); err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, PT(m).GetID())
+	}
+
+	if m, err := r.Get(ctx, ids[2]); err != nil || PT(m).GetID() != ids[2] {
+		t.Fatalf("Get = %v, %v", m, err)
+	}
+	if _, err := r.Get(ctx, missing); !errors.Is(err, rmodel.ErrNotFound) {
+		t.Fatalf("Get missing err = %v, want ErrNotFound", err)
+	}
+
+	for _, asc := range []bool{true, false} {
+		var got []K
+		cursor := ""
+		for {
+			items, next, err := r.List(ctx, rmodel.ListOptions{Cursor: cursor, Limit: 2, Asc: asc})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := range items {
+				got = append(got, PT(&items[i]).GetID())
+			}
+			if next == "" {
+				break
+			}
+			cursor = next
+		}
+		want := slices.Clone(ids)
+		if !asc {
+			slices.Reverse(want)
+		}
+		if !slices.Equal(got, want) {
+			t.Fatalf("List(asc=%v) = %v, want %v", asc, got, want)
+		}
+	}
+
+	if err := r.SoftDelete(ctx, ids[0]); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Get(ctx, ids[0]); !errors.Is(err, rmodel.ErrNotFound) {
+		t.Fatalf("Get deleted err = %v, want ErrNotFound", err)
+	}
+	if err := r.SoftDelete(ctx, missing); !errors.Is(err, rmodel.ErrNotFound) {
+		t.Fatalf("SoftDelete missing err = %v, want ErrNotFound", err)
+	}
+	if err := r.Restore(ctx, ids[0]); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Get(ctx, ids[0]); err != nil {
+		t.Fatalf("Get restored err = %v", err)
+	}
+}
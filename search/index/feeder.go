@@ -0,0 +1,208 @@
+package index
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/trancecho/mundo-proto-sdk/forum/event"
+	forum "github.com/trancecho/mundo-proto-sdk/forum_pb"
+	"github.com/trancecho/mundo-proto-sdk/rconst"
+	searchv1 "github.com/trancecho/mundo-proto-sdk/search/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrPostGone PostLoader 在帖子不存在或不可公开搜索时返回，对应文档会从索引中删除
+var ErrPostGone = errors.New("index: post not found")
+
+// PostLoader 按 ID 读取帖子
+type PostLoader func(ctx context.Context, id int64) (*forum.ForumPostResponse, error)
+
+// PostLoaderFromClient 基于 ForumService 客户端读取帖子，NotFound 视为 ErrPostGone
+func PostLoaderFromClient(c forum.ForumServiceClient) PostLoader {
+	return func(ctx context.Context, id int64) (*forum.ForumPostResponse, error) {
+		p, err := c.GetForumPostByID(ctx, &forum.GetForumPostByIDRequest{Id: id})
+		if status.Code(err) == codes.NotFound {
+			return nil, ErrPostGone
+		}
+		return p, err
+	}
+}
+
+// FeederConfig Feeder 配置，零值字段使用默认值
+type FeederConfig struct {
+	// Group 消费者组。为空时不使用消费者组，每次启动从头读取整个 stream，适用于 MemoryIndex 等进程内索引：
+	// 重启后索引被重建，多个副本各自拥有完整的索引。持久化的索引（如 Elasticsearch）可使用 rconst.SearchGroup，
+	// 多个副本分摊消费。stream 超过 event.MaxStreamLen 后最早的事件被裁剪，更早的帖子需另行导入。
+	Group      string
+	Consumer   string        // 消费者名，仅在设置 Group 时使用，默认 "search_consumer_<主机名>_<进程号>"
+	Count      int64         // 单次读取的最大消息数，默认 100
+	Block      time.Duration // 无消息时的阻塞时间，默认 5s
+	RetryDelay time.Duration // 处理失败后首次重试的等待时间，之后逐次翻倍，最长 30s，默认 1s
+}
+
+// maxRetryDelay 处理失败后重试的最长等待时间
+const maxRetryDelay = 30 * time.Second
+
+func (c *FeederConfig) setDefaults() {
+	if c.Group != "" && c.Consumer == "" {
+		host, _ := os.Hostname()
+		c.Consumer = "search_consumer_" + host + "_" + strconv.Itoa(os.Getpid())
+	}
+	if c.Count <= 0 {
+		c.Count = 100
+	}
+	if c.Block <= 0 {
+		c.Block = 5 * time.Second
+	}
+	if c.RetryDelay <= 0 {
+		c.RetryDelay = time.Second
+	}
+}
+
+// Feeder 消费 rconst.StreamPost 上的帖子事件，重新读取帖子后写入索引。
+// 只有已发布的帖子会被索引，其余状态及已删除的帖子从索引中移除。
+type Feeder struct {
+	rdb  redis.Cmdable
+	ix   Indexer
+	load PostLoader
+	cfg  FeederConfig
+}
+
+func NewFeeder(rdb redis.Cmdable, ix Indexer, load PostLoader, cfg FeederConfig) *Feeder {
+	cfg.setDefaults()
+	return &Feeder{rdb: rdb, ix: ix, load: load, cfg: cfg}
+}
+
+// Run 阻塞消费直到 ctx 取消。消息按顺序处理，处理失败时不跳过，等待后从失败的消息开始重试，
+// 保证同一帖子的事件按发布顺序生效。
+func (f *Feeder) Run(ctx context.Context) error {
+	read := f.read
+	if f.cfg.Group != "" {
+		if err := event.EnsureGroup(ctx, f.rdb, rconst.StreamPost, f.cfg.Group); err != nil {
+			return err
+		}
+		read = f.readGroup
+	}
+	// 不使用消费者组时 start 为已处理到的消息 ID；
+	// 使用消费者组时 "0" 读取本消费者的待确认消息，读完后切换为 ">" 读取新消息
+	start := "0"
+	delay := f.cfg.RetryDelay
+	for ctx.Err() == nil {
+		streams, err := read(ctx, start)
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			log.Println("[搜索索引] 读取帖子事件失败:", err)
+			time.Sleep(time.Second)
+			continue
+		}
+		last, err := f.process(ctx, streams)
+		if err != nil {
+			log.Println("[搜索索引] 处理帖子事件失败，稍后重试:", err)
+			// 失败的消息及之后的消息均未确认，使用消费者组时重新读取待确认消息
+			switch {
+			case f.cfg.Group != "":
+				start = "0"
+			case last != "":
+				start = last
+			}
+			sleep(ctx, delay)
+			delay = min(delay*2, maxRetryDelay)
+			continue
+		}
+		delay = f.cfg.RetryDelay
+		switch {
+		case f.cfg.Group == "":
+			if last != "" {
+				start = last
+			}
+		case start != ">":
+			// 待确认消息按 ID 向后翻页，读完后切换为新消息
+			start = last
+			if last == "" {
+				start = ">"
+			}
+		}
+	}
+	return ctx.Err()
+}
+
+func (f *Feeder) read(ctx context.Context, start string) ([]redis.XStream, error) {
+	return f.rdb.XRead(ctx, &redis.XReadArgs{
+		Streams: []string{rconst.StreamPost, start},
+		Count:   f.cfg.Count,
+		Block:   f.cfg.Block,
+	}).Result()
+}
+
+func (f *Feeder) readGroup(ctx context.Context, start string) ([]redis.XStream, error) {
+	return f.rdb.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    f.cfg.Group,
+		Consumer: f.cfg.Consumer,
+		Streams:  []string{rconst.StreamPost, start},
+		Count:    f.cfg.Count,
+		Block:    f.cfg.Block,
+	}).Result()
+}
+
+// process 按顺序处理消息，遇到失败时停止，返回最后一条处理成功的消息 ID
+func (f *Feeder) process(ctx context.Context, streams []redis.XStream) (string, error) {
+	var last string
+	for _, s := range streams {
+		for _, msg := range s.Messages {
+			if err := f.handle(ctx, msg); err != nil {
+				return last, fmt.Errorf("%s: %w", msg.ID, err)
+			}
+			if f.cfg.Group != "" {
+				f.rdb.XAck(ctx, rconst.StreamPost, f.cfg.Group, msg.ID)
+			}
+			last = msg.ID
+		}
+	}
+	return last, nil
+}
+
+func sleep(ctx context.Context, d time.Duration) {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+	case <-t.C:
+	}
+}
+
+func (f *Feeder) handle(ctx context.Context, msg redis.XMessage) error {
+	ev, err := event.DecodePost(msg)
+	if errors.Is(err, event.ErrMalformed) {
+		// 格式错误的消息重试也无法处理，确认后丢弃
+		log.Println("[搜索索引] 丢弃格式错误的消息:", err)
+		return nil
+	}
+	id := strconv.FormatUint(uint64(ev.PostId), 10)
+	if ev.Action == rconst.PostActionDelete {
+		return f.ix.Delete(ctx, searchv1.DocumentType_DOCUMENT_TYPE_FORUM_POST, id)
+	}
+	p, err := f.load(ctx, int64(ev.PostId))
+	if errors.Is(err, ErrPostGone) {
+		return f.ix.Delete(ctx, searchv1.DocumentType_DOCUMENT_TYPE_FORUM_POST, id)
+	}
+	if err != nil {
+		return err
+	}
+	switch p.GetStatus() {
+	case forum.PostStatus_POST_STATUS_UNSPECIFIED, forum.PostStatus_POST_STATUS_PUBLISHED:
+		return f.ix.Index(ctx, FromPost(p))
+	}
+	return f.ix.Delete(ctx, searchv1.DocumentType_DOCUMENT_TYPE_FORUM_POST, id)
+}
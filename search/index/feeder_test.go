@@ -0,0 +1,163 @@
+package index
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/trancecho/mundo-proto-sdk/forum/event"
+	forum "github.com/trancecho/mundo-proto-sdk/forum_pb"
+	"github.com/trancecho/mundo-proto-sdk/rconst"
+	searchv1 "github.com/trancecho/mundo-proto-sdk/search/v1"
+)
+
+// fakeIndex 记录当前被索引的文档 ID
+type fakeIndex struct {
+	mu   sync.Mutex
+	docs map[string]bool
+}
+
+func newFakeIndex() *fakeIndex {
+	return &fakeIndex{docs: map[string]bool{}}
+}
+
+func (f *fakeIndex) Index(_ context.Context, docs ...Document) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, d := range docs {
+		f.docs[d.ID] = true
+	}
+	return nil
+}
+
+func (f *fakeIndex) Delete(_ context.Context, _ searchv1.DocumentType, ids ...string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, id := range ids {
+		delete(f.docs, id)
+	}
+	return nil
+}
+
+func (f *fakeIndex) Search(context.Context, *searchv1.SearchRequest) (*searchv1.SearchResponse, error) {
+	return &searchv1.SearchResponse{}, nil
+}
+
+func (f *fakeIndex) ids() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return slices.Sorted(maps.Keys(f.docs))
+}
+
+// flakyLoader 返回已发布的帖子，fails 中的帖子在前若干次读取时失败
+type flakyLoader struct {
+	mu    sync.Mutex
+	fails map[int64]int
+	calls map[int64]int
+}
+
+func (l *flakyLoader) load(_ context.Context, id int64) (*forum.ForumPostResponse, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.calls[id]++
+	if l.calls[id] <= l.fails[id] {
+		return nil, errors.New("forum unavailable")
+	}
+	return &forum.ForumPostResponse{Id: id, Title: "post", Status: forum.PostStatus_POST_STATUS_PUBLISHED}, nil
+}
+
+func (l *flakyLoader) callsOf(id int64) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.calls[id]
+}
+
+func publish(t *testing.T, rdb redis.Cmdable, evs ...rconst.PostEvent) {
+	t.Helper()
+	p := event.NewPublisher(rdb)
+	for _, ev := range evs {
+		if _, err := p.PublishPost(context.Background(), ev); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// runFeeder 在后台运行 Feeder 直到 ids 与 want 一致，超时则失败
+func runFeeder(t *testing.T, f *Feeder, ix *fakeIndex, want []string) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- f.Run(ctx) }()
+	defer func() {
+		cancel()
+		<-done
+	}()
+	deadline := time.Now().Add(5 * time.Second)
+	for !slices.Equal(ix.ids(), want) {
+		if time.Now().After(deadline) {
+			t.Fatalf("indexed = %v, want %v", ix.ids(), want)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestFeederRebuildsOnRestart(t *testing.T) {
+	rdb := redis.NewClient(&redis.Options{Addr: miniredis.RunT(t).Addr()})
+	publish(t, rdb,
+		rconst.PostEvent{PostId: 3, Action: rconst.PostActionCreate},
+		rconst.PostEvent{PostId: 1, Action: rconst.PostActionCreate},
+		rconst.PostEvent{PostId: 3, Action: rconst.PostActionDelete},
+		rconst.PostEvent{PostId: 2, Action: rconst.PostActionCreate},
+	)
+	l := &flakyLoader{calls: map[int64]int{}}
+	cfg := FeederConfig{Block: 20 * time.Millisecond}
+	// 每次启动及每个副本都从头读取，得到完整的索引
+	for _, name := range []string{"first start", "restart", "replica"} {
+		t.Run(name, func(t *testing.T) {
+			ix := newFakeIndex()
+			runFeeder(t, NewFeeder(rdb, ix, l.load, cfg), ix, []string{"1", "2"})
+		})
+	}
+}
+
+func TestFeederRetriesFailedMessage(t *testing.T) {
+	tests := []struct {
+		name  string
+		group string
+	}{
+		{"stream", ""},
+		{"consumer group", rconst.SearchGroup},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rdb := redis.NewClient(&redis.Options{Addr: miniredis.RunT(t).Addr()})
+			publish(t, rdb,
+				rconst.PostEvent{PostId: 1, Action: rconst.PostActionCreate},
+				rconst.PostEvent{PostId: 2, Action: rconst.PostActionCreate},
+			)
+			l := &flakyLoader{fails: map[int64]int{1: 2}, calls: map[int64]int{}}
+			ix := newFakeIndex()
+			cfg := FeederConfig{Group: tt.group, Block: 20 * time.Millisecond, RetryDelay: time.Millisecond}
+			runFeeder(t, NewFeeder(rdb, ix, l.load, cfg), ix, []string{"1", "2"})
+			if got := l.callsOf(1); got != 3 {
+				t.Errorf("post 1 loaded %d times, want 3", got)
+			}
+			if tt.group == "" {
+				return
+			}
+			pending, err := rdb.XPending(context.Background(), rconst.StreamPost, tt.group).Result()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if pending.Count != 0 {
+				t.Errorf("pending = %d, want 0", pending.Count)
+			}
+		})
+	}
+}
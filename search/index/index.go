@@ -0,0 +1,86 @@
+package index
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	forum "github.com/trancecho/mundo-proto-sdk/forum_pb"
+	searchv1 "github.com/trancecho/mundo-proto-sdk/search/v1"
+	timerme "github.com/trancecho/mundo-proto-sdk/timerme_pb"
+)
+
+// Document 被索引的文档
+type Document struct {
+	Type      searchv1.DocumentType
+	ID        string
+	Title     string
+	Content   string
+	Tags      []string
+	Category  string
+	AuthorUid int64
+	CreatedAt time.Time
+}
+
+// Indexer 可插拔的索引实现：开发环境使用 MemoryIndex，线上可替换为 ES/Meilisearch 等外部实现
+type Indexer interface {
+	// Index 新增或覆盖文档（按 Type+ID 去重）
+	Index(ctx context.Context, docs ...Document) error
+	// Delete 删除文档，不存在时忽略
+	Delete(ctx context.Context, typ searchv1.DocumentType, ids ...string) error
+	// Search 执行搜索
+	Search(ctx context.Context, req *searchv1.SearchRequest) (*searchv1.SearchResponse, error)
+}
+
+// FromPost 由帖子构造文档
+func FromPost(p *forum.ForumPostResponse) Document {
+	return Document{
+		Type:      searchv1.DocumentType_DOCUMENT_TYPE_FORUM_POST,
+		ID:        strconv.FormatInt(p.GetId(), 10),
+		Title:     p.GetTitle(),
+		Content:   p.GetContent(),
+		Tags:      p.GetTags(),
+		Category:  p.GetCategory(),
+		AuthorUid: p.GetUid(),
+		CreatedAt: p.GetCreatedAt().AsTime(),
+	}
+}
+
+// FromQuestion 由问答问题构造文档，uid 超出 int64 时作者置空
+func FromQuestion(id uint64, q *timerme.GetQuestionResponse, createdAt time.Time) Document {
+	author := q.GetUser().GetUid()
+	if author == 0 && q.GetUid() <= 1<<63-1 {
+		author = int64(q.GetUid())
+	}
+	return Document{
+		Type:      searchv1.DocumentType_DOCUMENT_TYPE_QA_QUESTION,
+		ID:        strconv.FormatUint(id, 10),
+		Title:     q.GetTitle(),
+		Content:   q.GetContent(),
+		Tags:      q.GetTags(),
+		AuthorUid: author,
+		CreatedAt: createdAt,
+	}
+}
+
+// Server 将 Indexer 暴露为 SearchService
+type Server struct {
+	searchv1.UnimplementedSearchServiceServer
+	ix Indexer
+}
+
+var _ searchv1.SearchServiceServer = &Server{}
+
+func NewServer(ix Indexer) *Server {
+	return &Server{ix: ix}
+}
+
+func (s *Server) Search(ctx context.Context, req *searchv1.SearchRequest) (*searchv1.SearchResponse, error) {
+	start := time.Now()
+	resp, err := s.ix.Search(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	resp.TookMs = time.Since(start).Milliseconds()
+	return resp, nil
+}
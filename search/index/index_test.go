@@ -0,0 +1,83 @@
+package index
+
+import (
+	"context"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/trancecho/mundo-proto-sdk/common/userref"
+	commonv1 "github.com/trancecho/mundo-proto-sdk/common/v1"
+	forum "github.com/trancecho/mundo-proto-sdk/forum_pb"
+	searchv1 "github.com/trancecho/mundo-proto-sdk/search/v1"
+	timerme "github.com/trancecho/mundo-proto-sdk/timerme_pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestFromPost(t *testing.T) {
+	d := FromPost(&forum.ForumPostResponse{
+		Id: 7, Uid: 3, Title: "t", Content: "c", Tags: []string{"go"}, Category: "tech", CreatedAt: timestamppb.New(t0),
+	})
+	want := Document{Type: postType, ID: "7", Title: "t", Content: "c", Tags: []string{"go"}, Category: "tech", AuthorUid: 3, CreatedAt: t0}
+	if d.Type != want.Type || d.ID != want.ID || d.Title != want.Title || d.Content != want.Content ||
+		!slices.Equal(d.Tags, want.Tags) || d.Category != want.Category || d.AuthorUid != want.AuthorUid || !d.CreatedAt.Equal(want.CreatedAt) {
+		t.Fatalf("FromPost = %+v, want %+v", d, want)
+	}
+}
+
+func TestFromQuestion(t *testing.T) {
+	tests := []struct {
+		name       string
+		uid        uint64
+		user       *commonv1.UserRef
+		wantAuthor int64
+	}{
+		{"legacy uid", 9, nil, 9},
+		{"user wins over uid", 9, userref.FromInt64(5), 5},
+		{"uid beyond int64", math.MaxInt64 + 1, nil, 0},
+		{"anonymous", 0, nil, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := FromQuestion(42, &timerme.GetQuestionResponse{
+				Title: "t", Content: "c", Tags: []string{"go"}, Uid: tt.uid, User: tt.user,
+			}, t0)
+			if d.Type != questionType || d.ID != "42" || d.Title != "t" || d.Content != "c" ||
+				!slices.Equal(d.Tags, []string{"go"}) || !d.CreatedAt.Equal(t0) {
+				t.Fatalf("FromQuestion = %+v", d)
+			}
+			if d.AuthorUid != tt.wantAuthor {
+				t.Fatalf("AuthorUid = %d, want %d", d.AuthorUid, tt.wantAuthor)
+			}
+		})
+	}
+}
+
+func TestServerSearch(t *testing.T) {
+	s := NewServer(seedIndex(t))
+	tests := []struct {
+		name     string
+		req      *searchv1.SearchRequest
+		want     []string
+		wantCode codes.Code
+	}{
+		{"hits", &searchv1.SearchRequest{Query: "go", Types: []searchv1.DocumentType{postType}}, []string{"p1", "p2"}, codes.OK},
+		{"invalid page token", &searchv1.SearchRequest{Pagination: &commonv1.PageRequest{PageToken: "!!"}}, nil, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := s.Search(context.Background(), tt.req)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("code = %v (%v), want %v", code, err, tt.wantCode)
+			}
+			if got := hitKeys(resp); !slices.Equal(got, tt.want) {
+				t.Fatalf("hits = %v, want %v", got, tt.want)
+			}
+			if resp.GetTookMs() < 0 {
+				t.Fatalf("took_ms = %d", resp.GetTookMs())
+			}
+		})
+	}
+}
@@ -0,0 +1,317 @@
+package index
+
+import (
+	"context"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/trancecho/mundo-proto-sdk/common/paging"
+	searchv1 "github.com/trancecho/mundo-proto-sdk/search/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+
+	snippetLength = 120 // 摘要的最大字符数
+	snippetLead   = 20  // 摘要在首个命中位置之前保留的字符数
+
+	titleWeight   = 3
+	tagWeight     = 2
+	contentWeight = 1
+)
+
+type docKey struct {
+	typ searchv1.DocumentType
+	id  string
+}
+
+type indexedDoc struct {
+	Document
+	weights map[string]float64 // 词 -> 加权词频
+}
+
+// MemoryIndex 进程内倒排索引，适合开发和小规模部署，重启后需重新灌入
+type MemoryIndex struct {
+	mu       sync.RWMutex
+	docs     map[docKey]*indexedDoc
+	postings map[string]map[docKey]struct{}
+}
+
+var _ Indexer = &MemoryIndex{}
+
+func NewMemoryIndex() *MemoryIndex {
+	return &MemoryIndex{
+		docs:     make(map[docKey]*indexedDoc),
+		postings: make(map[string]map[docKey]struct{}),
+	}
+}
+
+func (m *MemoryIndex) Index(_ context.Context, docs ...Document) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, d := range docs {
+		key := docKey{d.Type, d.ID}
+		m.remove(key)
+		doc := &indexedDoc{Document: d, weights: make(map[string]float64)}
+		for _, t := range tokenize(d.Title, true) {
+			doc.weights[t.term] += titleWeight
+		}
+		for _, tag := range d.Tags {
+			for _, t := range tokenize(tag, true) {
+				doc.weights[t.term] += tagWeight
+			}
+		}
+		for _, t := range tokenize(d.Content, true) {
+			doc.weights[t.term] += contentWeight
+		}
+		for term := range doc.weights {
+			if m.postings[term] == nil {
+				m.postings[term] = make(map[docKey]struct{})
+			}
+			m.postings[term][key] = struct{}{}
+		}
+		m.docs[key] = doc
+	}
+	return nil
+}
+
+func (m *MemoryIndex) Delete(_ context.Context, typ searchv1.DocumentType, ids ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, id := range ids {
+		m.remove(docKey{typ, id})
+	}
+	return nil
+}
+
+func (m *MemoryIndex) remove(key docKey) {
+	doc, ok := m.docs[key]
+	if !ok {
+		return
+	}
+	for term := range doc.weights {
+		delete(m.postings[term], key)
+		if len(m.postings[term]) == 0 {
+			delete(m.postings, term)
+		}
+	}
+	delete(m.docs, key)
+}
+
+// Len 已索引的文档数
+func (m *MemoryIndex) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.docs)
+}
+
+type scored struct {
+	doc   *indexedDoc
+	score float64
+}
+
+func (m *MemoryIndex) Search(_ context.Context, req *searchv1.SearchRequest) (*searchv1.SearchResponse, error) {
+	offset, err := paging.DecodeOffset(req.GetPagination().GetPageToken())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	pageSize := int(paging.PageSize(req.GetPagination(), DefaultPageSize, MaxPageSize))
+	qterms := queryTerms(req.GetQuery())
+
+	m.mu.RLock()
+	var hits []scored
+	for _, doc := range m.candidates(qterms) {
+		if match(doc, req) {
+			hits = append(hits, scored{doc: doc, score: m.score(doc, qterms)})
+		}
+	}
+	m.mu.RUnlock()
+
+	sort.Slice(hits, func(i, j int) bool {
+		a, b := hits[i], hits[j]
+		if a.score != b.score {
+			return a.score > b.score
+		}
+		if !a.doc.CreatedAt.Equal(b.doc.CreatedAt) {
+			return a.doc.CreatedAt.After(b.doc.CreatedAt)
+		}
+		return a.doc.ID > b.doc.ID
+	})
+
+	page := hits[min(offset, len(hits)):min(offset+pageSize, len(hits))]
+	resp := &searchv1.SearchResponse{
+		Pagination: paging.OffsetResponse(offset, pageSize, len(page), int64(len(hits))),
+	}
+	termSet := make(map[string]bool, len(qterms))
+	for _, t := range qterms {
+		termSet[t] = true
+	}
+	pre, post := req.GetHighlightPreTag(), req.GetHighlightPostTag()
+	if pre == "" && post == "" {
+		pre, post = "<em>", "</em>"
+	}
+	for _, h := range page {
+		resp.Hits = append(resp.Hits, toHit(h, termSet, req.GetHighlight(), pre, post))
+	}
+	return resp, nil
+}
+
+// candidates 包含所有查询词的文档，查询为空时返回全部文档；调用方需持有读锁
+func (m *MemoryIndex) candidates(qterms []string) map[docKey]*indexedDoc {
+	out := make(map[docKey]*indexedDoc)
+	if len(qterms) == 0 {
+		for k, d := range m.docs {
+			out[k] = d
+		}
+		return out
+	}
+	// 从最短的倒排表开始求交集
+	lists := make([]map[docKey]struct{}, 0, len(qterms))
+	for _, t := range qterms {
+		p, ok := m.postings[t]
+		if !ok {
+			return out
+		}
+		lists = append(lists, p)
+	}
+	sort.Slice(lists, func(i, j int) bool { return len(lists[i]) < len(lists[j]) })
+next:
+	for k := range lists[0] {
+		for _, p := range lists[1:] {
+			if _, ok := p[k]; !ok {
+				continue next
+			}
+		}
+		out[k] = m.docs[k]
+	}
+	return out
+}
+
+// score 加权词频 × 逆文档频率；调用方需持有读锁
+func (m *MemoryIndex) score(doc *indexedDoc, qterms []string) float64 {
+	var s float64
+	n := float64(len(m.docs))
+	for _, t := range qterms {
+		df := float64(len(m.postings[t]))
+		s += doc.weights[t] * math.Log(1+n/df)
+	}
+	return s
+}
+
+func match(doc *indexedDoc, req *searchv1.SearchRequest) bool {
+	if types := req.GetTypes(); len(types) > 0 && !slices.Contains(types, doc.Type) {
+		return false
+	}
+	for _, tag := range req.GetTags() {
+		if !slices.ContainsFunc(doc.Tags, func(t string) bool { return strings.EqualFold(t, tag) }) {
+			return false
+		}
+	}
+	if c := req.GetCategory(); c != "" && c != doc.Category {
+		return false
+	}
+	if a := req.GetAuthorUid(); a != 0 && a != doc.AuthorUid {
+		return false
+	}
+	if req.GetStartTime() != nil && doc.CreatedAt.Before(req.GetStartTime().AsTime()) {
+		return false
+	}
+	if req.GetEndTime() != nil && !doc.CreatedAt.Before(req.GetEndTime().AsTime()) {
+		return false
+	}
+	return true
+}
+
+func toHit(h scored, terms map[string]bool, highlight bool, pre, post string) *searchv1.SearchHit {
+	d := h.doc
+	hit := &searchv1.SearchHit{
+		Type:      d.Type,
+		Id:        d.ID,
+		Score:     h.score,
+		Title:     d.Title,
+		Snippet:   fragment(d.Content, terms, "", "", snippetLength),
+		Tags:      d.Tags,
+		Category:  d.Category,
+		AuthorUid: d.AuthorUid,
+	}
+	if !d.CreatedAt.IsZero() {
+		hit.CreatedAt = timestamppb.New(d.CreatedAt)
+	}
+	if !highlight || len(terms) == 0 {
+		return hit
+	}
+	if f := fragment(d.Title, terms, pre, post, 0); f != "" && f != d.Title {
+		hit.Highlights = append(hit.Highlights, &searchv1.Highlight{Field: "title", Fragments: []string{f}})
+	}
+	if f := fragment(d.Content, terms, pre, post, snippetLength); f != "" && hasMatch(d.Content, terms) {
+		hit.Highlights = append(hit.Highlights, &searchv1.Highlight{Field: "content", Fragments: []string{f}})
+	}
+	return hit
+}
+
+func hasMatch(text string, terms map[string]bool) bool {
+	for _, t := range tokenize(text, true) {
+		if terms[t.term] {
+			return true
+		}
+	}
+	return false
+}
+
+// fragment 截取 text 中首个命中位置附近最多 limit 个字符（limit 为 0 表示不截取），
+// 并用 pre/post 包裹命中的词；没有命中时返回开头部分
+func fragment(text string, terms map[string]bool, pre, post string, limit int) string {
+	rs := []rune(text)
+	var matched [][2]int
+	for _, t := range tokenize(text, true) {
+		if terms[t.term] {
+			matched = append(matched, [2]int{t.start, t.end})
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i][0] < matched[j][0] })
+	// 合并重叠的命中区间
+	var spans [][2]int
+	for _, sp := range matched {
+		if n := len(spans); n > 0 && sp[0] <= spans[n-1][1] {
+			spans[n-1][1] = max(spans[n-1][1], sp[1])
+			continue
+		}
+		spans = append(spans, sp)
+	}
+	start, end := 0, len(rs)
+	if limit > 0 && len(rs) > limit {
+		if len(spans) > 0 {
+			start = max(0, spans[0][0]-snippetLead)
+		}
+		start = min(start, len(rs)-limit)
+		end = start + limit
+	}
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("…")
+	}
+	pos := start
+	for _, sp := range spans {
+		s, e := max(sp[0], start), min(sp[1], end)
+		if s >= e {
+			continue
+		}
+		b.WriteString(string(rs[pos:s]))
+		b.WriteString(pre)
+		b.WriteString(string(rs[s:e]))
+		b.WriteString(post)
+		pos = e
+	}
+	b.WriteString(string(rs[pos:end]))
+	if end < len(rs) {
+		b.WriteString("…")
+	}
+	return b.String()
+}
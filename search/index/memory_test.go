@@ -0,0 +1,229 @@
+package index
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	commonv1 "github.com/trancecho/mundo-proto-sdk/common/v1"
+	searchv1 "github.com/trancecho/mundo-proto-sdk/search/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	postType     = searchv1.DocumentType_DOCUMENT_TYPE_FORUM_POST
+	questionType = searchv1.DocumentType_DOCUMENT_TYPE_QA_QUESTION
+)
+
+var t0 = time.Date(2025, 10, 19, 12, 0, 0, 0, time.UTC)
+
+// seedIndex 返回包含三篇帖子和一个问题的索引，创建时间依次递增一小时
+func seedIndex(t *testing.T) *MemoryIndex {
+	t.Helper()
+	m := NewMemoryIndex()
+	err := m.Index(context.Background(),
+		Document{Type: postType, ID: "1", Title: "Go 并发编程", Content: "goroutine 和 channel", Tags: []string{"go"}, Category: "tech", AuthorUid: 1, CreatedAt: t0},
+		Document{Type: postType, ID: "2", Title: "Rust 入门", Content: "学完 go 再学 rust", Tags: []string{"rust"}, Category: "tech", AuthorUid: 2, CreatedAt: t0.Add(time.Hour)},
+		Document{Type: postType, ID: "3", Title: "美食分享", Content: "今天吃了火锅", Tags: []string{"life"}, Category: "life", AuthorUid: 1, CreatedAt: t0.Add(2 * time.Hour)},
+		Document{Type: questionType, ID: "1", Title: "Go 的 channel 怎么关闭", Content: "关闭已经关闭的 channel 会 panic", Tags: []string{"Go"}, AuthorUid: 3, CreatedAt: t0.Add(3 * time.Hour)},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+// hitKeys 以 "类型首字母+ID" 表示命中，如 p1、q1
+func hitKeys(resp *searchv1.SearchResponse) []string {
+	var out []string
+	for _, h := range resp.GetHits() {
+		prefix := "p"
+		if h.GetType() == questionType {
+			prefix = "q"
+		}
+		out = append(out, prefix+h.GetId())
+	}
+	return out
+}
+
+func TestMemoryIndexSearch(t *testing.T) {
+	m := seedIndex(t)
+	tests := []struct {
+		name string
+		req  *searchv1.SearchRequest
+		want []string
+	}{
+		{"ranking with tie broken by recency", &searchv1.SearchRequest{Query: "go"}, []string{"q1", "p1", "p2"}},
+		{"all terms required", &searchv1.SearchRequest{Query: "go channel"}, []string{"q1", "p1"}},
+		{"case insensitive", &searchv1.SearchRequest{Query: "RUST"}, []string{"p2"}},
+		{"cjk bigram", &searchv1.SearchRequest{Query: "火锅"}, []string{"p3"}},
+		{"cjk unigram", &searchv1.SearchRequest{Query: "锅"}, []string{"p3"}},
+		{"no match", &searchv1.SearchRequest{Query: "java"}, nil},
+		{"empty query lists newest first", &searchv1.SearchRequest{}, []string{"q1", "p3", "p2", "p1"}},
+		{"type filter", &searchv1.SearchRequest{Query: "go", Types: []searchv1.DocumentType{questionType}}, []string{"q1"}},
+		{"tag filter ignores case", &searchv1.SearchRequest{Query: "go", Tags: []string{"GO"}}, []string{"q1", "p1"}},
+		{"category filter", &searchv1.SearchRequest{Query: "go", Category: "tech"}, []string{"p1", "p2"}},
+		{"author filter", &searchv1.SearchRequest{AuthorUid: 1}, []string{"p3", "p1"}},
+		{"time range end exclusive", &searchv1.SearchRequest{
+			StartTime: timestamppb.New(t0.Add(30 * time.Minute)),
+			EndTime:   timestamppb.New(t0.Add(3 * time.Hour)),
+		}, []string{"p3", "p2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := m.Search(context.Background(), tt.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := hitKeys(resp); !slices.Equal(got, tt.want) {
+				t.Fatalf("hits = %v, want %v", got, tt.want)
+			}
+			if resp.GetPagination().GetTotal() != int64(len(tt.want)) {
+				t.Fatalf("total = %d, want %d", resp.GetPagination().GetTotal(), len(tt.want))
+			}
+		})
+	}
+}
+
+func TestMemoryIndexPaging(t *testing.T) {
+	m := seedIndex(t)
+	req := &searchv1.SearchRequest{Query: "go", Pagination: &commonv1.PageRequest{PageSize: 2}}
+	var pages [][]string
+	for {
+		resp, err := m.Search(context.Background(), req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.GetPagination().GetTotal() != 3 {
+			t.Fatalf("total = %d, want 3", resp.GetPagination().GetTotal())
+		}
+		pages = append(pages, hitKeys(resp))
+		next := resp.GetPagination().GetNextPageToken()
+		if next == "" {
+			break
+		}
+		req.Pagination.PageToken = next
+	}
+	if want := [][]string{{"q1", "p1"}, {"p2"}}; !slices.EqualFunc(pages, want, slices.Equal) {
+		t.Fatalf("pages = %v, want %v", pages, want)
+	}
+
+	_, err := m.Search(context.Background(), &searchv1.SearchRequest{Pagination: &commonv1.PageRequest{PageToken: "!!"}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("bad token err = %v, want InvalidArgument", err)
+	}
+}
+
+func TestMemoryIndexHighlight(t *testing.T) {
+	m := seedIndex(t)
+	tests := []struct {
+		name    string
+		req     *searchv1.SearchRequest
+		snippet string
+		want    map[string]string // 字段 -> 高亮片段
+	}{
+		{"content only", &searchv1.SearchRequest{Query: "火锅", Highlight: true},
+			"今天吃了火锅", map[string]string{"content": "今天吃了<em>火锅</em>"}},
+		{"title and content", &searchv1.SearchRequest{Query: "rust", Highlight: true},
+			"学完 go 再学 rust", map[string]string{"title": "<em>Rust</em> 入门", "content": "学完 go 再学 <em>rust</em>"}},
+		{"custom tags", &searchv1.SearchRequest{Query: "火锅", Highlight: true, HighlightPreTag: "[", HighlightPostTag: "]"},
+			"今天吃了火锅", map[string]string{"content": "今天吃了[火锅]"}},
+		{"highlight disabled", &searchv1.SearchRequest{Query: "火锅"}, "今天吃了火锅", map[string]string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := m.Search(context.Background(), tt.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(resp.GetHits()) != 1 {
+				t.Fatalf("hits = %v", hitKeys(resp))
+			}
+			hit := resp.GetHits()[0]
+			if hit.GetSnippet() != tt.snippet {
+				t.Fatalf("snippet = %q, want %q", hit.GetSnippet(), tt.snippet)
+			}
+			got := make(map[string]string)
+			for _, h := range hit.GetHighlights() {
+				got[h.GetField()] = strings.Join(h.GetFragments(), "|")
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("highlights = %v, want %v", got, tt.want)
+			}
+			for field, f := range tt.want {
+				if got[field] != f {
+					t.Fatalf("highlight %s = %q, want %q", field, got[field], f)
+				}
+			}
+		})
+	}
+}
+
+func TestMemoryIndexUpdate(t *testing.T) {
+	m := seedIndex(t)
+	ctx := context.Background()
+	// 覆盖后旧内容的词不再命中，同 ID 不同类型的文档互不影响
+	if err := m.Index(ctx, Document{Type: postType, ID: "1", Title: "Python 入门", CreatedAt: t0}); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Delete(ctx, postType, "2", "404"); err != nil {
+		t.Fatal(err)
+	}
+	if m.Len() != 3 {
+		t.Fatalf("Len = %d, want 3", m.Len())
+	}
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"并发", nil},
+		{"python", []string{"p1"}},
+		{"rust", nil},
+		{"go", []string{"q1"}},
+	}
+	for _, tt := range tests {
+		resp, err := m.Search(ctx, &searchv1.SearchRequest{Query: tt.query})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := hitKeys(resp); !slices.Equal(got, tt.want) {
+			t.Errorf("Search(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestFragment(t *testing.T) {
+	long := strings.Repeat("a ", 50) + "target" + strings.Repeat(" b", 50)
+	tests := []struct {
+		name  string
+		text  string
+		terms []string
+		limit int
+		want  string
+	}{
+		{"every match", "I love Go and go", []string{"go"}, 0, "I love <em>Go</em> and <em>go</em>"},
+		{"overlapping bigrams merged", "搜索引擎", []string{"搜索", "索引"}, 0, "<em>搜索引</em>擎"},
+		{"adjacent spans merged", "搜索引擎", []string{"搜索", "引擎"}, 0, "<em>搜索引擎</em>"},
+		{"window around first match", long, []string{"target"}, 30,
+			"…" + strings.Repeat("a ", 10) + "<em>target</em> b b…"},
+		{"window clamped to end", strings.Repeat("x", 40) + " yy zz", []string{"zz"}, 30,
+			"…" + strings.Repeat("x", 24) + " yy <em>zz</em>"},
+		{"no match keeps head", "abcdefgh", []string{"zz"}, 5, "abcde…"},
+		{"short text untouched", "火锅", nil, 120, "火锅"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			terms := make(map[string]bool)
+			for _, term := range tt.terms {
+				terms[term] = true
+			}
+			if got := fragment(tt.text, terms, "<em>", "</em>", tt.limit); got != tt.want {
+				t.Fatalf("fragment = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package index
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	searchv1 "github.com/trancecho/mundo-proto-sdk/search/v1"
+	timerme "github.com/trancecho/mundo-proto-sdk/timerme_pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrQuestionGone QuestionLoader 在问题不存在时返回，对应文档会从索引中删除
+var ErrQuestionGone = errors.New("index: question not found")
+
+// QuestionLoader 按 ID 读取问答问题及其创建时间（未知时为零值）
+type QuestionLoader func(ctx context.Context, id uint64) (*timerme.GetQuestionResponse, time.Time, error)
+
+// QuestionLoaderFromClient 基于 QAService 客户端读取问题，NotFound 视为 ErrQuestionGone。
+// GetQuestion 不返回创建时间，文档的创建时间为零值，按时间过滤的搜索不会命中这些问题
+func QuestionLoaderFromClient(c timerme.QAServiceClient) QuestionLoader {
+	return func(ctx context.Context, id uint64) (*timerme.GetQuestionResponse, time.Time, error) {
+		q, err := c.GetQuestion(ctx, &timerme.GetQuestionRequest{QuestionId: id})
+		if status.Code(err) == codes.NotFound {
+			return nil, time.Time{}, ErrQuestionGone
+		}
+		return q, time.Time{}, err
+	}
+}
+
+// IndexQuestions 读取问题并写入索引，不存在的问题从索引中删除，遇到其他错误时停止并返回。
+// 问答服务没有事件流，由问答服务在创建或修改问题后调用，全量导入时配合 ListQuestionIds 使用
+func IndexQuestions(ctx context.Context, ix Indexer, load QuestionLoader, ids ...uint64) error {
+	for _, id := range ids {
+		q, createdAt, err := load(ctx, id)
+		if errors.Is(err, ErrQuestionGone) {
+			if err := ix.Delete(ctx, searchv1.DocumentType_DOCUMENT_TYPE_QA_QUESTION, strconv.FormatUint(id, 10)); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if err := ix.Index(ctx, FromQuestion(id, q, createdAt)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
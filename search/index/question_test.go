@@ -0,0 +1,92 @@
+package index
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	searchv1 "github.com/trancecho/mundo-proto-sdk/search/v1"
+	timerme "github.com/trancecho/mundo-proto-sdk/timerme_pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// qaRPC 模拟问答服务，questions 中不存在的问题返回 NotFound，errs 中的问题返回对应错误
+type qaRPC struct {
+	timerme.QAServiceClient
+	questions map[uint64]*timerme.GetQuestionResponse
+	errs      map[uint64]error
+}
+
+func (r *qaRPC) GetQuestion(_ context.Context, req *timerme.GetQuestionRequest, _ ...grpc.CallOption) (*timerme.GetQuestionResponse, error) {
+	if err := r.errs[req.GetQuestionId()]; err != nil {
+		return nil, err
+	}
+	q, ok := r.questions[req.GetQuestionId()]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "question not found")
+	}
+	return q, nil
+}
+
+func TestIndexQuestions(t *testing.T) {
+	errDown := status.Error(codes.Unavailable, "qa down")
+	rpc := &qaRPC{
+		questions: map[uint64]*timerme.GetQuestionResponse{
+			1: {Title: "Go 的 channel 怎么关闭", Uid: 3},
+			2: {Title: "goroutine 泄漏排查", Uid: 4},
+		},
+		errs: map[uint64]error{5: errDown},
+	}
+	load := QuestionLoaderFromClient(rpc)
+	tests := []struct {
+		name    string
+		ids     []uint64
+		want    []string // 之后索引中的全部问题
+		wantErr error
+	}{
+		{"index existing", []uint64{1, 2}, []string{"q1", "q2", "q3"}, nil},
+		{"missing question removed", []uint64{3}, []string{"q1"}, nil},
+		{"error stops", []uint64{5, 2}, []string{"q1", "q3"}, errDown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := seedIndex(t)
+			// seedIndex 中已有问题 1，再放入一个问答服务中已不存在的问题 3
+			if err := m.Index(context.Background(), Document{Type: questionType, ID: "3", Title: "已删除的问题"}); err != nil {
+				t.Fatal(err)
+			}
+			if err := IndexQuestions(context.Background(), m, load, tt.ids...); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			resp, err := m.Search(context.Background(), &searchv1.SearchRequest{Types: []searchv1.DocumentType{questionType}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := slices.Sorted(slices.Values(hitKeys(resp))); !slices.Equal(got, tt.want) {
+				t.Fatalf("indexed questions = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIndexQuestionsFromLoader(t *testing.T) {
+	created := t0.Add(-time.Hour)
+	load := func(context.Context, uint64) (*timerme.GetQuestionResponse, time.Time, error) {
+		return &timerme.GetQuestionResponse{Title: "goroutine 泄漏排查"}, created, nil
+	}
+	m := NewMemoryIndex()
+	if err := IndexQuestions(context.Background(), m, load, 8); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := m.Search(context.Background(), &searchv1.SearchRequest{Query: "泄漏"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := hitKeys(resp); !slices.Equal(got, []string{"q8"}) || !resp.GetHits()[0].GetCreatedAt().AsTime().Equal(created) {
+		t.Fatalf("hits = %v", resp.GetHits())
+	}
+}
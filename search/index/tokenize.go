@@ -0,0 +1,63 @@
+package index
+
+import (
+	"unicode"
+)
+
+// token 分词结果，start/end 为在原文中的 rune 下标
+type token struct {
+	term       string
+	start, end int
+}
+
+// tokenize 简单的中英文混合分词：拉丁字母和数字按连续串切词并转小写，
+// 汉字按相邻两字切分（bigram），单独的汉字作为一个词。
+// 建索引时（index 为 true）额外输出每个汉字，使单字查询也能命中。
+func tokenize(text string, index bool) []token {
+	rs := []rune(text)
+	var out []token
+	for i := 0; i < len(rs); {
+		r := rs[i]
+		switch {
+		case unicode.Is(unicode.Han, r):
+			j := i
+			for j < len(rs) && unicode.Is(unicode.Han, rs[j]) {
+				j++
+			}
+			for k := i; k < j; k++ {
+				if index || j-i == 1 {
+					out = append(out, token{term: string(rs[k]), start: k, end: k + 1})
+				}
+			}
+			for k := i; k+1 < j; k++ {
+				out = append(out, token{term: string(rs[k : k+2]), start: k, end: k + 2})
+			}
+			i = j
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			j := i
+			word := make([]rune, 0, 8)
+			for j < len(rs) && (unicode.IsLetter(rs[j]) || unicode.IsDigit(rs[j])) && !unicode.Is(unicode.Han, rs[j]) {
+				word = append(word, unicode.ToLower(rs[j]))
+				j++
+			}
+			out = append(out, token{term: string(word), start: i, end: j})
+			i = j
+		default:
+			i++
+		}
+	}
+	return out
+}
+
+// queryTerms 查询的去重词列表
+func queryTerms(text string) []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, t := range tokenize(text, false) {
+		if !seen[t.term] {
+			seen[t.term] = true
+			out = append(out, t.term)
+		}
+	}
+	return out
+}
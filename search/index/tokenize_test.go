@@ -0,0 +1,48 @@
+package index
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		index bool
+		want  []token
+	}{
+		{"ascii lowercased", "Hello, World", false, []token{{"hello", 0, 5}, {"world", 7, 12}}},
+		{"digits", "v2.0 release", false, []token{{"v2", 0, 2}, {"0", 3, 4}, {"release", 5, 12}}},
+		{"cjk bigrams for query", "搜索引擎", false, []token{{"搜索", 0, 2}, {"索引", 1, 3}, {"引擎", 2, 4}}},
+		{"cjk unigrams for index", "搜索", true, []token{{"搜", 0, 1}, {"索", 1, 2}, {"搜索", 0, 2}}},
+		{"single han", "的", false, []token{{"的", 0, 1}}},
+		{"mixed", "Go语言", false, []token{{"go", 0, 2}, {"语言", 2, 4}}},
+		{"mixed index", "Go语言", true, []token{{"go", 0, 2}, {"语", 2, 3}, {"言", 3, 4}, {"语言", 2, 4}}},
+		{"punctuation only", "…，!?", false, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tokenize(tt.text, tt.index); !slices.Equal(got, tt.want) {
+				t.Fatalf("tokenize(%q, %v) = %v, want %v", tt.text, tt.index, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryTerms(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"", nil},
+		{"Go go GO", []string{"go"}},
+		{"go 语言 语言", []string{"go", "语言"}},
+		{"火锅", []string{"火锅"}},
+	}
+	for _, tt := range tests {
+		if got := queryTerms(tt.query); !slices.Equal(got, tt.want) {
+			t.Errorf("queryTerms(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
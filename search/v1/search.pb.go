@@ -0,0 +1,555 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: search/v1/search.proto
+
+package searchv1
+
+import (
+	v1 "github.com/trancecho/mundo-proto-sdk/common/v1"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// DocumentType 被搜索的文档类型
+type DocumentType int32
+
+const (
+	DocumentType_DOCUMENT_TYPE_UNSPECIFIED DocumentType = 0
+	DocumentType_DOCUMENT_TYPE_FORUM_POST  DocumentType = 1 // 论坛帖子
+	DocumentType_DOCUMENT_TYPE_QA_QUESTION DocumentType = 2 // 问答问题
+)
+
+// Enum value maps for DocumentType.
+var (
+	DocumentType_name = map[int32]string{
+		0: "DOCUMENT_TYPE_UNSPECIFIED",
+		1: "DOCUMENT_TYPE_FORUM_POST",
+		2: "DOCUMENT_TYPE_QA_QUESTION",
+	}
+	DocumentType_value = map[string]int32{
+		"DOCUMENT_TYPE_UNSPECIFIED": 0,
+		"DOCUMENT_TYPE_FORUM_POST":  1,
+		"DOCUMENT_TYPE_QA_QUESTION": 2,
+	}
+)
+
+func (x DocumentType) Enum() *DocumentType {
+	p := new(DocumentType)
+	*p = x
+	return p
+}
+
+func (x DocumentType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DocumentType) Descriptor() protoreflect.EnumDescriptor {
+	return file_search_v1_search_proto_enumTypes[0].Descriptor()
+}
+
+func (DocumentType) Type() protoreflect.EnumType {
+	return &file_search_v1_search_proto_enumTypes[0]
+}
+
+func (x DocumentType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DocumentType.Descriptor instead.
+func (DocumentType) EnumDescriptor() ([]byte, []int) {
+	return file_search_v1_search_proto_rawDescGZIP(), []int{0}
+}
+
+// SearchRequest 搜索请求
+type SearchRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Query            string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`                                                  // 关键词，多个词之间为且关系；为空时仅按过滤条件返回，按时间倒序
+	Types            []DocumentType         `protobuf:"varint,2,rep,packed,name=types,proto3,enum=search.v1.DocumentType" json:"types,omitempty"`              // 文档类型，为空表示全部
+	Tags             []string               `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`                                                    // 需同时包含的标签
+	Category         string                 `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`                                            // 分类
+	AuthorUid        int64                  `protobuf:"varint,5,opt,name=author_uid,json=authorUid,proto3" json:"author_uid,omitempty"`                        // 作者
+	StartTime        *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`                         // 创建时间起点（含），为空表示不限
+	EndTime          *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`                               // 创建时间终点（不含），为空表示不限
+	Highlight        bool                   `protobuf:"varint,8,opt,name=highlight,proto3" json:"highlight,omitempty"`                                         // 是否返回高亮片段
+	HighlightPreTag  string                 `protobuf:"bytes,9,opt,name=highlight_pre_tag,json=highlightPreTag,proto3" json:"highlight_pre_tag,omitempty"`     // 高亮前缀，默认 "<em>"
+	HighlightPostTag string                 `protobuf:"bytes,10,opt,name=highlight_post_tag,json=highlightPostTag,proto3" json:"highlight_post_tag,omitempty"` // 高亮后缀，默认 "</em>"
+	Pagination       *v1.PageRequest        `protobuf:"bytes,11,opt,name=pagination,proto3" json:"pagination,omitempty"`                                       // 每页默认 20，最大 100
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *SearchRequest) Reset() {
+	*x = SearchRequest{}
+	mi := &file_search_v1_search_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchRequest) ProtoMessage() {}
+
+func (x *SearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_search_v1_search_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchRequest.ProtoReflect.Descriptor instead.
+func (*SearchRequest) Descriptor() ([]byte, []int) {
+	return file_search_v1_search_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SearchRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetTypes() []DocumentType {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *SearchRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *SearchRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetAuthorUid() int64 {
+	if x != nil {
+		return x.AuthorUid
+	}
+	return 0
+}
+
+func (x *SearchRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *SearchRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *SearchRequest) GetHighlight() bool {
+	if x != nil {
+		return x.Highlight
+	}
+	return false
+}
+
+func (x *SearchRequest) GetHighlightPreTag() string {
+	if x != nil {
+		return x.HighlightPreTag
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetHighlightPostTag() string {
+	if x != nil {
+		return x.HighlightPostTag
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetPagination() *v1.PageRequest {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+// Highlight 某个字段的高亮片段
+type Highlight struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`         // 字段名：title 或 content
+	Fragments     []string               `protobuf:"bytes,2,rep,name=fragments,proto3" json:"fragments,omitempty"` // 含高亮标签的片段
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Highlight) Reset() {
+	*x = Highlight{}
+	mi := &file_search_v1_search_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Highlight) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Highlight) ProtoMessage() {}
+
+func (x *Highlight) ProtoReflect() protoreflect.Message {
+	mi := &file_search_v1_search_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Highlight.ProtoReflect.Descriptor instead.
+func (*Highlight) Descriptor() ([]byte, []int) {
+	return file_search_v1_search_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Highlight) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *Highlight) GetFragments() []string {
+	if x != nil {
+		return x.Fragments
+	}
+	return nil
+}
+
+// SearchHit 单条搜索结果
+type SearchHit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          DocumentType           `protobuf:"varint,1,opt,name=type,proto3,enum=search.v1.DocumentType" json:"type,omitempty"`
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`         // 文档 ID（帖子 ID 或问题 ID 的十进制字符串）
+	Score         float64                `protobuf:"fixed64,3,opt,name=score,proto3" json:"score,omitempty"` // 相关度，仅用于排序
+	Title         string                 `protobuf:"bytes,4,opt,name=title,proto3" json:"title,omitempty"`
+	Snippet       string                 `protobuf:"bytes,5,opt,name=snippet,proto3" json:"snippet,omitempty"`       // 内容摘要
+	Highlights    []*Highlight           `protobuf:"bytes,6,rep,name=highlights,proto3" json:"highlights,omitempty"` // request.highlight 为 true 时返回
+	Tags          []string               `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty"`
+	Category      string                 `protobuf:"bytes,8,opt,name=category,proto3" json:"category,omitempty"`
+	AuthorUid     int64                  `protobuf:"varint,9,opt,name=author_uid,json=authorUid,proto3" json:"author_uid,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchHit) Reset() {
+	*x = SearchHit{}
+	mi := &file_search_v1_search_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchHit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchHit) ProtoMessage() {}
+
+func (x *SearchHit) ProtoReflect() protoreflect.Message {
+	mi := &file_search_v1_search_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchHit.ProtoReflect.Descriptor instead.
+func (*SearchHit) Descriptor() ([]byte, []int) {
+	return file_search_v1_search_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SearchHit) GetType() DocumentType {
+	if x != nil {
+		return x.Type
+	}
+	return DocumentType_DOCUMENT_TYPE_UNSPECIFIED
+}
+
+func (x *SearchHit) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SearchHit) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *SearchHit) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *SearchHit) GetSnippet() string {
+	if x != nil {
+		return x.Snippet
+	}
+	return ""
+}
+
+func (x *SearchHit) GetHighlights() []*Highlight {
+	if x != nil {
+		return x.Highlights
+	}
+	return nil
+}
+
+func (x *SearchHit) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *SearchHit) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *SearchHit) GetAuthorUid() int64 {
+	if x != nil {
+		return x.AuthorUid
+	}
+	return 0
+}
+
+func (x *SearchHit) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// SearchResponse 搜索响应
+type SearchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hits          []*SearchHit           `protobuf:"bytes,1,rep,name=hits,proto3" json:"hits,omitempty"`                    // 按相关度降序
+	Pagination    *v1.PageResponse       `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`        // total 为命中总数
+	TookMs        int64                  `protobuf:"varint,3,opt,name=took_ms,json=tookMs,proto3" json:"took_ms,omitempty"` // 服务端耗时（毫秒）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchResponse) Reset() {
+	*x = SearchResponse{}
+	mi := &file_search_v1_search_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResponse) ProtoMessage() {}
+
+func (x *SearchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_search_v1_search_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResponse.ProtoReflect.Descriptor instead.
+func (*SearchResponse) Descriptor() ([]byte, []int) {
+	return file_search_v1_search_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SearchResponse) GetHits() []*SearchHit {
+	if x != nil {
+		return x.Hits
+	}
+	return nil
+}
+
+func (x *SearchResponse) GetPagination() *v1.PageResponse {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+func (x *SearchResponse) GetTookMs() int64 {
+	if x != nil {
+		return x.TookMs
+	}
+	return 0
+}
+
+var File_search_v1_search_proto protoreflect.FileDescriptor
+
+const file_search_v1_search_proto_rawDesc = "" +
+	"\n" +
+	"\x16search/v1/search.proto\x12\tsearch.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x16common/v1/common.proto\"\xc5\x03\n" +
+	"\rSearchRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12-\n" +
+	"\x05types\x18\x02 \x03(\x0e2\x17.search.v1.DocumentTypeR\x05types\x12\x12\n" +
+	"\x04tags\x18\x03 \x03(\tR\x04tags\x12\x1a\n" +
+	"\bcategory\x18\x04 \x01(\tR\bcategory\x12\x1d\n" +
+	"\n" +
+	"author_uid\x18\x05 \x01(\x03R\tauthorUid\x129\n" +
+	"\n" +
+	"start_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12\x1c\n" +
+	"\thighlight\x18\b \x01(\bR\thighlight\x12*\n" +
+	"\x11highlight_pre_tag\x18\t \x01(\tR\x0fhighlightPreTag\x12,\n" +
+	"\x12highlight_post_tag\x18\n" +
+	" \x01(\tR\x10highlightPostTag\x126\n" +
+	"\n" +
+	"pagination\x18\v \x01(\v2\x16.common.v1.PageRequestR\n" +
+	"pagination\"?\n" +
+	"\tHighlight\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x1c\n" +
+	"\tfragments\x18\x02 \x03(\tR\tfragments\"\xce\x02\n" +
+	"\tSearchHit\x12+\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x17.search.v1.DocumentTypeR\x04type\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12\x14\n" +
+	"\x05score\x18\x03 \x01(\x01R\x05score\x12\x14\n" +
+	"\x05title\x18\x04 \x01(\tR\x05title\x12\x18\n" +
+	"\asnippet\x18\x05 \x01(\tR\asnippet\x124\n" +
+	"\n" +
+	"highlights\x18\x06 \x03(\v2\x14.search.v1.HighlightR\n" +
+	"highlights\x12\x12\n" +
+	"\x04tags\x18\a \x03(\tR\x04tags\x12\x1a\n" +
+	"\bcategory\x18\b \x01(\tR\bcategory\x12\x1d\n" +
+	"\n" +
+	"author_uid\x18\t \x01(\x03R\tauthorUid\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x8c\x01\n" +
+	"\x0eSearchResponse\x12(\n" +
+	"\x04hits\x18\x01 \x03(\v2\x14.search.v1.SearchHitR\x04hits\x127\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2\x17.common.v1.PageResponseR\n" +
+	"pagination\x12\x17\n" +
+	"\atook_ms\x18\x03 \x01(\x03R\x06tookMs*j\n" +
+	"\fDocumentType\x12\x1d\n" +
+	"\x19DOCUMENT_TYPE_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18DOCUMENT_TYPE_FORUM_POST\x10\x01\x12\x1d\n" +
+	"\x19DOCUMENT_TYPE_QA_QUESTION\x10\x022b\n" +
+	"\rSearchService\x12Q\n" +
+	"\x06Search\x12\x18.search.v1.SearchRequest\x1a\x19.search.v1.SearchResponse\"\x12\x82\xd3\xe4\x93\x02\fb\x01*\x12\a/searchB\x9a\x01\n" +
+	"\rcom.search.v1B\vSearchProtoP\x01Z7github.com/trancecho/mundo-proto-sdk/search/v1;searchv1\xa2\x02\x03SXX\xaa\x02\tSearch.V1\xca\x02\tSearch\\V1\xe2\x02\x15Search\\V1\\GPBMetadata\xea\x02\n" +
+	"Search::V1b\x06proto3"
+
+var (
+	file_search_v1_search_proto_rawDescOnce sync.Once
+	file_search_v1_search_proto_rawDescData []byte
+)
+
+func file_search_v1_search_proto_rawDescGZIP() []byte {
+	file_search_v1_search_proto_rawDescOnce.Do(func() {
+		file_search_v1_search_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_search_v1_search_proto_rawDesc), len(file_search_v1_search_proto_rawDesc)))
+	})
+	return file_search_v1_search_proto_rawDescData
+}
+
+var file_search_v1_search_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_search_v1_search_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_search_v1_search_proto_goTypes = []any{
+	(DocumentType)(0),             // 0: search.v1.DocumentType
+	(*SearchRequest)(nil),         // 1: search.v1.SearchRequest
+	(*Highlight)(nil),             // 2: search.v1.Highlight
+	(*SearchHit)(nil),             // 3: search.v1.SearchHit
+	(*SearchResponse)(nil),        // 4: search.v1.SearchResponse
+	(*timestamppb.Timestamp)(nil), // 5: google.protobuf.Timestamp
+	(*v1.PageRequest)(nil),        // 6: common.v1.PageRequest
+	(*v1.PageResponse)(nil),       // 7: common.v1.PageResponse
+}
+var file_search_v1_search_proto_depIdxs = []int32{
+	0,  // 0: search.v1.SearchRequest.types:type_name -> search.v1.DocumentType
+	5,  // 1: search.v1.SearchRequest.start_time:type_name -> google.protobuf.Timestamp
+	5,  // 2: search.v1.SearchRequest.end_time:type_name -> google.protobuf.Timestamp
+	6,  // 3: search.v1.SearchRequest.pagination:type_name -> common.v1.PageRequest
+	0,  // 4: search.v1.SearchHit.type:type_name -> search.v1.DocumentType
+	2,  // 5: search.v1.SearchHit.highlights:type_name -> search.v1.Highlight
+	5,  // 6: search.v1.SearchHit.created_at:type_name -> google.protobuf.Timestamp
+	3,  // 7: search.v1.SearchResponse.hits:type_name -> search.v1.SearchHit
+	7,  // 8: search.v1.SearchResponse.pagination:type_name -> common.v1.PageResponse
+	1,  // 9: search.v1.SearchService.Search:input_type -> search.v1.SearchRequest
+	4,  // 10: search.v1.SearchService.Search:output_type -> search.v1.SearchResponse
+	10, // [10:11] is the sub-list for method output_type
+	9,  // [9:10] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_search_v1_search_proto_init() }
+func file_search_v1_search_proto_init() {
+	if File_search_v1_search_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_search_v1_search_proto_rawDesc), len(file_search_v1_search_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_search_v1_search_proto_goTypes,
+		DependencyIndexes: file_search_v1_search_proto_depIdxs,
+		EnumInfos:         file_search_v1_search_proto_enumTypes,
+		MessageInfos:      file_search_v1_search_proto_msgTypes,
+	}.Build()
+	File_search_v1_search_proto = out.File
+	file_search_v1_search_proto_goTypes = nil
+	file_search_v1_search_proto_depIdxs = nil
+}
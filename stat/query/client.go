@@ -0,0 +1,150 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	statv1 "github.com/trancecho/mundo-proto-sdk/stat/v1"
+)
+
+var ErrInvalidRange = errors.New("query: invalid time range")
+
+// Client StatService 查询接口的便捷封装
+type Client struct {
+	rpc statv1.StatServiceClient
+	loc *time.Location
+}
+
+// NewClient 创建查询客户端，按天分桶默认使用 UTC
+func NewClient(rpc statv1.StatServiceClient) *Client {
+	return &Client{rpc: rpc, loc: time.UTC}
+}
+
+// WithLocation 设置按天分桶使用的时区
+func (c *Client) WithLocation(loc *time.Location) *Client {
+	return &Client{rpc: c.rpc, loc: loc}
+}
+
+// Point 时间序列中的一个点
+type Point struct {
+	Time        time.Time
+	Count       int64
+	UniqueUsers int64
+}
+
+// Counts 统计 [from, to) 内各事件类型的次数与独立用户数
+func (c *Client) Counts(ctx context.Context, eventTypes []string, from, to time.Time, g statv1.Granularity) ([]*statv1.CountBucket, error) {
+	if !from.Before(to) {
+		return nil, ErrInvalidRange
+	}
+	resp, err := c.rpc.CountEvents(ctx, &statv1.CountEventsRequest{
+		EventTypes:  eventTypes,
+		StartTime:   from.UnixMilli(),
+		EndTime:     to.UnixMilli(),
+		Granularity: g,
+		Timezone:    c.loc.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetBuckets(), nil
+}
+
+// Series 返回单个事件类型在 [from, to) 内的完整时间序列，没有数据的桶补 0，便于直接画图
+func (c *Client) Series(ctx context.Context, eventType string, from, to time.Time, g statv1.Granularity) ([]Point, error) {
+	buckets, err := c.Counts(ctx, []string{eventType}, from, to, g)
+	if err != nil {
+		return nil, err
+	}
+	byStart := make(map[int64]*statv1.CountBucket, len(buckets))
+	for _, b := range buckets {
+		if b.GetEventType() == eventType {
+			byStart[b.GetBucketStart()] = b
+		}
+	}
+
+	var points []Point
+	for t := c.truncate(from, g); t.Before(to); t = c.next(t, g) {
+		p := Point{Time: t}
+		if b, ok := byStart[t.UnixMilli()]; ok {
+			p.Count = b.GetCount()
+			p.UniqueUsers = b.GetUniqueUsers()
+		}
+		points = append(points, p)
+		if g == statv1.Granularity_GRANULARITY_UNSPECIFIED {
+			break
+		}
+	}
+	return points, nil
+}
+
+// TopN 统计某事件属性值出现次数的前 n 名
+func (c *Client) TopN(ctx context.Context, eventType, property string, n int, from, to time.Time) ([]*statv1.PropertyCount, error) {
+	if !from.Before(to) {
+		return nil, ErrInvalidRange
+	}
+	resp, err := c.rpc.TopProperties(ctx, &statv1.TopPropertiesRequest{
+		EventType: eventType,
+		Property:  property,
+		StartTime: from.UnixMilli(),
+		EndTime:   to.UnixMilli(),
+		Limit:     int32(n),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetItems(), nil
+}
+
+// Timeline 按时间倒序遍历用户在 [from, to) 内的事件，fn 返回 false 时停止；
+// max > 0 时最多返回 max 条
+func (c *Client) Timeline(ctx context.Context, userID uint32, eventTypes []string, from, to time.Time, max int, fn func(*statv1.TimelineEvent) bool) error {
+	if !from.Before(to) {
+		return ErrInvalidRange
+	}
+	req := &statv1.GetUserTimelineRequest{
+		UserId:     userID,
+		EventTypes: eventTypes,
+		StartTime:  from.UnixMilli(),
+		EndTime:    to.UnixMilli(),
+	}
+	seen := 0
+	for {
+		resp, err := c.rpc.GetUserTimeline(ctx, req)
+		if err != nil {
+			return err
+		}
+		for _, ev := range resp.GetEvents() {
+			if !fn(ev) {
+				return nil
+			}
+			seen++
+			if max > 0 && seen >= max {
+				return nil
+			}
+		}
+		if resp.GetNextPageToken() == "" || len(resp.GetEvents()) == 0 {
+			return nil
+		}
+		req.PageToken = resp.GetNextPageToken()
+	}
+}
+
+func (c *Client) truncate(t time.Time, g statv1.Granularity) time.Time {
+	t = t.In(c.loc)
+	switch g {
+	case statv1.Granularity_GRANULARITY_HOUR:
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, c.loc)
+	case statv1.Granularity_GRANULARITY_DAY:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, c.loc)
+	}
+	return t
+}
+
+func (c *Client) next(t time.Time, g statv1.Granularity) time.Time {
+	if g == statv1.Granularity_GRANULARITY_DAY {
+		return t.AddDate(0, 0, 1)
+	}
+	return t.Add(time.Hour)
+}
@@ -0,0 +1,139 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	statv1 "github.com/trancecho/mundo-proto-sdk/stat/v1"
+	"google.golang.org/grpc"
+)
+
+// fakeStat 返回固定的计数桶，时间线按游标 "" -> "p2" 分两页
+type fakeStat struct {
+	statv1.StatServiceClient
+	buckets  []*statv1.CountBucket
+	timezone string
+	calls    int
+}
+
+func (f *fakeStat) CountEvents(_ context.Context, in *statv1.CountEventsRequest, _ ...grpc.CallOption) (*statv1.CountEventsResponse, error) {
+	f.timezone = in.GetTimezone()
+	return &statv1.CountEventsResponse{Buckets: f.buckets}, nil
+}
+
+func (f *fakeStat) GetUserTimeline(_ context.Context, in *statv1.GetUserTimelineRequest, _ ...grpc.CallOption) (*statv1.GetUserTimelineResponse, error) {
+	f.calls++
+	if in.GetPageToken() == "" {
+		return &statv1.GetUserTimelineResponse{Events: events("a", "b"), NextPageToken: "p2"}, nil
+	}
+	return &statv1.GetUserTimelineResponse{Events: events("c")}, nil
+}
+
+func events(ids ...string) []*statv1.TimelineEvent {
+	out := make([]*statv1.TimelineEvent, len(ids))
+	for i, id := range ids {
+		out[i] = &statv1.TimelineEvent{EventId: id}
+	}
+	return out
+}
+
+func TestSeries(t *testing.T) {
+	shanghai := time.FixedZone("Asia/Shanghai", 8*3600)
+	day := func(loc *time.Location, d int) time.Time { return time.Date(2026, 10, d, 0, 0, 0, 0, loc) }
+	tests := []struct {
+		name    string
+		loc     *time.Location
+		from    time.Time
+		to      time.Time
+		g       statv1.Granularity
+		buckets []*statv1.CountBucket
+		want    []int64
+	}{
+		{"daily gaps filled", time.UTC, day(time.UTC, 1).Add(5 * time.Hour), day(time.UTC, 4), statv1.Granularity_GRANULARITY_DAY,
+			[]*statv1.CountBucket{
+				{EventType: "login", BucketStart: day(time.UTC, 2).UnixMilli(), Count: 3},
+				{EventType: "other", BucketStart: day(time.UTC, 1).UnixMilli(), Count: 9},
+			}, []int64{0, 3, 0}},
+		{"timezone buckets", shanghai, day(shanghai, 1), day(shanghai, 3), statv1.Granularity_GRANULARITY_DAY,
+			[]*statv1.CountBucket{{EventType: "login", BucketStart: day(shanghai, 1).UnixMilli(), Count: 1}}, []int64{1, 0}},
+		{"hourly", time.UTC, day(time.UTC, 1), day(time.UTC, 1).Add(3 * time.Hour), statv1.Granularity_GRANULARITY_HOUR,
+			[]*statv1.CountBucket{{EventType: "login", BucketStart: day(time.UTC, 1).Add(time.Hour).UnixMilli(), Count: 2}}, []int64{0, 2, 0}},
+		{"total", time.UTC, day(time.UTC, 1), day(time.UTC, 5), statv1.Granularity_GRANULARITY_UNSPECIFIED,
+			[]*statv1.CountBucket{{EventType: "login", BucketStart: day(time.UTC, 1).UnixMilli(), Count: 7}}, []int64{7}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rpc := &fakeStat{buckets: tt.buckets}
+			points, err := NewClient(rpc).WithLocation(tt.loc).Series(context.Background(), "login", tt.from, tt.to, tt.g)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []int64
+			for _, p := range points {
+				got = append(got, p.Count)
+			}
+			if !slices.Equal(got, tt.want) || rpc.timezone != tt.loc.String() {
+				t.Fatalf("counts = %v, timezone = %q; want %v, %q", got, rpc.timezone, tt.want, tt.loc)
+			}
+		})
+	}
+}
+
+func TestTimeline(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name      string
+		max       int
+		stopAt    string
+		want      []string
+		wantCalls int
+	}{
+		{"all pages", 0, "", []string{"a", "b", "c"}, 2},
+		{"max", 2, "", []string{"a", "b"}, 1},
+		{"fn stops", 0, "b", []string{"a"}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rpc := &fakeStat{}
+			var got []string
+			err := NewClient(rpc).Timeline(context.Background(), 1, nil, now.Add(-time.Hour), now, tt.max,
+				func(ev *statv1.TimelineEvent) bool {
+					if ev.GetEventId() == tt.stopAt {
+						return false
+					}
+					got = append(got, ev.GetEventId())
+					return true
+				})
+			if err != nil || !slices.Equal(got, tt.want) || rpc.calls != tt.wantCalls {
+				t.Fatalf("Timeline = %v, %v (%d calls); want %v (%d calls)", got, err, rpc.calls, tt.want, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestInvalidRange(t *testing.T) {
+	c := NewClient(&fakeStat{})
+	now := time.Now()
+	ctx := context.Background()
+	tests := []struct {
+		name string
+		run  func() error
+	}{
+		{"counts", func() error { _, err := c.Counts(ctx, nil, now, now, 0); return err }},
+		{"series", func() error { _, err := c.Series(ctx, "x", now, now.Add(-time.Hour), 0); return err }},
+		{"top", func() error { _, err := c.TopN(ctx, "x", "p", 10, now, now); return err }},
+		{"timeline", func() error {
+			return c.Timeline(ctx, 1, nil, now, now, 0, func(*statv1.TimelineEvent) bool { return true })
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.run(); !errors.Is(err, ErrInvalidRange) {
+				t.Fatalf("err = %v, want ErrInvalidRange", err)
+			}
+		})
+	}
+}
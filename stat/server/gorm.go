@@ -0,0 +1,132 @@
+package server
+
+import (
+	"context"
+	"errors"
+
+	"github.com/trancecho/mundo-proto-sdk/rmodel"
+	"gorm.io/gorm"
+)
+
+// EventModel 统计事件表
+type EventModel struct {
+	rmodel.BaseModel
+	EventID       string  `gorm:"uniqueIndex;size:64" json:"event_id"`
+	ClientEventID *string `gorm:"uniqueIndex;size:64" json:"client_event_id"` // 为空时存 NULL，不参与唯一约束
+	EventType     string  `gorm:"index:idx_stat_event_type_ts;size:64" json:"event_type"`
+	Timestamp     int64   `gorm:"index:idx_stat_event_type_ts;index:idx_stat_event_user_ts" json:"timestamp"`
+	UserID        uint32  `gorm:"index:idx_stat_event_user_ts" json:"user_id"`
+	IP            string  `gorm:"size:64" json:"ip"`
+	Source        string  `gorm:"size:512" json:"source"`
+	Properties    string  `gorm:"type:text" json:"properties"`
+}
+
+func (EventModel) TableName() string {
+	return "stat_events"
+}
+
+// GormStorage 基于 GORM 的存储
+type GormStorage struct {
+	db *gorm.DB
+}
+
+var _ Storage = &GormStorage{}
+
+func NewGormStorage(db *gorm.DB) *GormStorage {
+	return &GormStorage{db: db}
+}
+
+// AutoMigrate 创建或更新事件表
+func (s *GormStorage) AutoMigrate() error {
+	return s.db.AutoMigrate(&EventModel{})
+}
+
+func (s *GormStorage) Save(ctx context.Context, ev *Event) (string, error) {
+	db := s.db.WithContext(ctx)
+	m := &EventModel{
+		EventID:    ev.ID,
+		EventType:  ev.EventType,
+		Timestamp:  ev.Timestamp,
+		UserID:     ev.UserID,
+		IP:         ev.IP,
+		Source:     ev.Source,
+		Properties: ev.Properties,
+	}
+	if ev.ClientEventID != "" {
+		clientID := ev.ClientEventID
+		m.ClientEventID = &clientID
+		if id, err := s.findByClientID(db, clientID); err != nil {
+			return "", err
+		} else if id != "" {
+			return id, ErrDuplicate
+		}
+	}
+	if err := db.Create(m).Error; err != nil {
+		// 并发写入同一 client_event_id 时唯一索引冲突，按重复处理
+		if m.ClientEventID != nil {
+			if id, ferr := s.findByClientID(db, *m.ClientEventID); ferr == nil && id != "" {
+				return id, ErrDuplicate
+			}
+		}
+		return "", err
+	}
+	return ev.ID, nil
+}
+
+func (s *GormStorage) findByClientID(db *gorm.DB, clientID string) (string, error) {
+	var m EventModel
+	err := db.Select("event_id").Where("client_event_id = ?", clientID).Take(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return m.EventID, nil
+}
+
+func (s *GormStorage) Query(ctx context.Context, f Filter) ([]*Event, error) {
+	q := s.db.WithContext(ctx).Model(&EventModel{})
+	if len(f.EventTypes) > 0 {
+		q = q.Where("event_type IN ?", f.EventTypes)
+	}
+	if f.UserID != 0 {
+		q = q.Where("user_id = ?", f.UserID)
+	}
+	if f.Start != 0 {
+		q = q.Where("timestamp >= ?", f.Start)
+	}
+	if f.End != 0 {
+		q = q.Where("timestamp < ?", f.End)
+	}
+	if f.Before != nil {
+		q = q.Where("timestamp < ? OR (timestamp = ? AND event_id < ?)",
+			f.Before.Timestamp, f.Before.Timestamp, f.Before.ID)
+	}
+	q = q.Order("timestamp DESC").Order("event_id DESC")
+	if f.Limit > 0 {
+		q = q.Limit(f.Limit)
+	}
+
+	var rows []EventModel
+	if err := q.Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	out := make([]*Event, len(rows))
+	for i, m := range rows {
+		ev := &Event{
+			ID:         m.EventID,
+			EventType:  m.EventType,
+			Timestamp:  m.Timestamp,
+			UserID:     m.UserID,
+			IP:         m.IP,
+			Source:     m.Source,
+			Properties: m.Properties,
+		}
+		if m.ClientEventID != nil {
+			ev.ClientEventID = *m.ClientEventID
+		}
+		out[i] = ev
+	}
+	return out, nil
+}
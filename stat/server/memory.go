@@ -0,0 +1,80 @@
+package server
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// MemoryStorage 内存存储，适用于测试和单机小规模部署
+type MemoryStorage struct {
+	mu       sync.RWMutex
+	events   []*Event
+	byClient map[string]string // client_event_id -> event id
+}
+
+var _ Storage = &MemoryStorage{}
+
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{byClient: make(map[string]string)}
+}
+
+func (m *MemoryStorage) Save(ctx context.Context, ev *Event) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if ev.ClientEventID != "" {
+		if id, ok := m.byClient[ev.ClientEventID]; ok {
+			return id, ErrDuplicate
+		}
+		m.byClient[ev.ClientEventID] = ev.ID
+	}
+	cp := *ev
+	m.events = append(m.events, &cp)
+	return ev.ID, nil
+}
+
+func (m *MemoryStorage) Query(ctx context.Context, f Filter) ([]*Event, error) {
+	types := make(map[string]bool, len(f.EventTypes))
+	for _, t := range f.EventTypes {
+		types[t] = true
+	}
+
+	m.mu.RLock()
+	var out []*Event
+	for _, ev := range m.events {
+		if len(types) > 0 && !types[ev.EventType] {
+			continue
+		}
+		if f.UserID != 0 && ev.UserID != f.UserID {
+			continue
+		}
+		if (f.Start != 0 && ev.Timestamp < f.Start) || (f.End != 0 && ev.Timestamp >= f.End) {
+			continue
+		}
+		if f.Before != nil && !less(ev, f.Before) {
+			continue
+		}
+		cp := *ev
+		out = append(out, &cp)
+	}
+	m.mu.RUnlock()
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Timestamp != out[j].Timestamp {
+			return out[i].Timestamp > out[j].Timestamp
+		}
+		return out[i].ID > out[j].ID
+	})
+	if f.Limit > 0 && len(out) > f.Limit {
+		out = out[:f.Limit]
+	}
+	return out, nil
+}
+
+// less 判断事件是否排在游标之后（倒序下更旧）
+func less(ev *Event, c *Cursor) bool {
+	if ev.Timestamp != c.Timestamp {
+		return ev.Timestamp < c.Timestamp
+	}
+	return ev.ID < c.ID
+}
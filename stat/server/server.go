@@ -0,0 +1,403 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/trancecho/mundo-proto-sdk/common/userref"
+	"github.com/trancecho/mundo-proto-sdk/rconst"
+	statv1 "github.com/trancecho/mundo-proto-sdk/stat/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Config StatService 参考实现配置，零值字段使用默认值
+type Config struct {
+	ExtraEventTypes []string      // 在 rconst 内置类型之外允许的事件类型
+	MaxBatchSize    int           // 单次批量上报上限，默认 1000
+	MaxFutureSkew   time.Duration // 允许事件时间超前服务器时间的最大值，默认 1h
+	Now             func() time.Time
+}
+
+func (c *Config) setDefaults() {
+	if c.MaxBatchSize <= 0 {
+		c.MaxBatchSize = 1000
+	}
+	if c.MaxFutureSkew <= 0 {
+		c.MaxFutureSkew = time.Hour
+	}
+	if c.Now == nil {
+		c.Now = time.Now
+	}
+}
+
+// Server StatService 的参考实现：校验事件、分配事件 ID、按 client_event_id 去重，
+// 写入 Storage；查询接口在 Storage 返回的明细上做聚合，适合小规模部署和测试。
+type Server struct {
+	statv1.UnimplementedStatServiceServer
+	store Storage
+	cfg   Config
+	extra map[string]bool
+}
+
+var _ statv1.StatServiceServer = &Server{}
+
+func NewServer(store Storage, cfg Config) *Server {
+	cfg.setDefaults()
+	extra := make(map[string]bool, len(cfg.ExtraEventTypes))
+	for _, t := range cfg.ExtraEventTypes {
+		extra[t] = true
+	}
+	return &Server{store: store, cfg: cfg, extra: extra}
+}
+
+// errInvalid 事件校验失败，不可重试
+type errInvalid struct{ msg string }
+
+func (e *errInvalid) Error() string { return e.msg }
+
+func (s *Server) TrackEvent(ctx context.Context, req *statv1.TrackEventRequest) (*statv1.TrackEventResponse, error) {
+	id, err := s.track(ctx, req)
+	var invalid *errInvalid
+	if errors.As(err, &invalid) {
+		return &statv1.TrackEventResponse{Success: false, Message: invalid.msg}, nil
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "save event: %v", err)
+	}
+	return &statv1.TrackEventResponse{EventId: id, Success: true}, nil
+}
+
+func (s *Server) TrackEventBatch(ctx context.Context, req *statv1.TrackEventBatchRequest) (*statv1.TrackEventBatchResponse, error) {
+	if len(req.GetEvents()) > s.cfg.MaxBatchSize {
+		return nil, status.Errorf(codes.InvalidArgument, "batch size %d exceeds limit %d", len(req.GetEvents()), s.cfg.MaxBatchSize)
+	}
+	resp := &statv1.TrackEventBatchResponse{
+		EventIds: make([]string, len(req.GetEvents())),
+		Results:  make([]*statv1.TrackEventResult, len(req.GetEvents())),
+	}
+	for i, ev := range req.GetEvents() {
+		r := &statv1.TrackEventResult{Index: int32(i)}
+		id, err := s.track(ctx, ev)
+		var invalid *errInvalid
+		switch {
+		case err == nil:
+			r.Success = true
+			r.EventId = id
+			resp.EventIds[i] = id
+			resp.SuccessCount++
+		case errors.As(err, &invalid):
+			r.Message = invalid.msg
+			resp.FailedCount++
+		default:
+			r.Retryable = true
+			r.Message = err.Error()
+			resp.FailedCount++
+		}
+		resp.Results[i] = r
+	}
+	if resp.FailedCount > 0 {
+		resp.Message = fmt.Sprintf("%d events failed", resp.FailedCount)
+	}
+	return resp, nil
+}
+
+// track 校验并保存单个事件，重复的 client_event_id 视为成功并返回原事件 ID
+func (s *Server) track(ctx context.Context, req *statv1.TrackEventRequest) (string, error) {
+	if err := s.validate(req); err != nil {
+		return "", err
+	}
+	// 未设置用户的事件（如匿名访问）user_id 为 0
+	uid, err := userref.Uint32Of(req.GetUser(), req.GetUserId())
+	if err != nil && !errors.Is(err, userref.ErrEmpty) {
+		return "", &errInvalid{"user uid out of range"}
+	}
+	now := s.cfg.Now()
+	ts := req.GetTimestamp()
+	if ts == 0 {
+		ts = now.UnixMilli()
+	}
+	ev := &Event{
+		ID:            newEventID(now),
+		ClientEventID: req.GetClientEventId(),
+		EventType:     req.GetEventType(),
+		Timestamp:     ts,
+		UserID:        uid,
+		IP:            req.GetIp(),
+		Source:        req.GetSource(),
+		Properties:    req.GetProperties(),
+	}
+	id, err := s.store.Save(ctx, ev)
+	if errors.Is(err, ErrDuplicate) {
+		return id, nil
+	}
+	return id, err
+}
+
+func (s *Server) validate(req *statv1.TrackEventRequest) error {
+	if req == nil {
+		return &errInvalid{"empty event"}
+	}
+	if !rconst.IsStatEventType(req.GetEventType()) && !s.extra[req.GetEventType()] {
+		return &errInvalid{fmt.Sprintf("unknown event type %q", req.GetEventType())}
+	}
+	if req.GetTimestamp() < 0 {
+		return &errInvalid{"negative timestamp"}
+	}
+	if limit := s.cfg.Now().Add(s.cfg.MaxFutureSkew).UnixMilli(); req.GetTimestamp() > limit {
+		return &errInvalid{"timestamp too far in the future"}
+	}
+	if p := req.GetProperties(); p != "" && !json.Valid([]byte(p)) {
+		return &errInvalid{"properties is not valid JSON"}
+	}
+	if len(req.GetClientEventId()) > 64 {
+		return &errInvalid{"client_event_id too long"}
+	}
+	return nil
+}
+
+func (s *Server) CountEvents(ctx context.Context, req *statv1.CountEventsRequest) (*statv1.CountEventsResponse, error) {
+	if err := checkRange(req.GetStartTime(), req.GetEndTime()); err != nil {
+		return nil, err
+	}
+	loc := time.UTC
+	if tz := req.GetTimezone(); tz != "" {
+		l, err := time.LoadLocation(tz)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid timezone %q", tz)
+		}
+		loc = l
+	}
+	events, err := s.store.Query(ctx, Filter{
+		EventTypes: req.GetEventTypes(),
+		Start:      req.GetStartTime(),
+		End:        req.GetEndTime(),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "query events: %v", err)
+	}
+
+	type key struct {
+		start     int64
+		eventType string
+	}
+	type agg struct {
+		count int64
+		users map[uint32]bool
+	}
+	buckets := make(map[key]*agg)
+	for _, ev := range events {
+		k := key{bucketStart(ev.Timestamp, req.GetStartTime(), req.GetGranularity(), loc), ev.EventType}
+		a, ok := buckets[k]
+		if !ok {
+			a = &agg{users: make(map[uint32]bool)}
+			buckets[k] = a
+		}
+		a.count++
+		if ev.UserID != 0 {
+			a.users[ev.UserID] = true
+		}
+	}
+
+	resp := &statv1.CountEventsResponse{}
+	for k, a := range buckets {
+		resp.Buckets = append(resp.Buckets, &statv1.CountBucket{
+			BucketStart: k.start,
+			EventType:   k.eventType,
+			Count:       a.count,
+			UniqueUsers: int64(len(a.users)),
+		})
+	}
+	sort.Slice(resp.Buckets, func(i, j int) bool {
+		a, b := resp.Buckets[i], resp.Buckets[j]
+		if a.BucketStart != b.BucketStart {
+			return a.BucketStart < b.BucketStart
+		}
+		return a.EventType < b.EventType
+	})
+	return resp, nil
+}
+
+func (s *Server) TopProperties(ctx context.Context, req *statv1.TopPropertiesRequest) (*statv1.TopPropertiesResponse, error) {
+	if err := checkRange(req.GetStartTime(), req.GetEndTime()); err != nil {
+		return nil, err
+	}
+	if req.GetEventType() == "" || req.GetProperty() == "" {
+		return nil, status.Error(codes.InvalidArgument, "event_type and property are required")
+	}
+	limit := int(req.GetLimit())
+	if limit <= 0 {
+		limit = 10
+	}
+	events, err := s.store.Query(ctx, Filter{
+		EventTypes: []string{req.GetEventType()},
+		Start:      req.GetStartTime(),
+		End:        req.GetEndTime(),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "query events: %v", err)
+	}
+
+	counts := make(map[string]*statv1.PropertyCount)
+	users := make(map[string]map[uint32]bool)
+	for _, ev := range events {
+		v, ok := propertyValue(ev.Properties, req.GetProperty())
+		if !ok {
+			continue
+		}
+		pc, ok := counts[v]
+		if !ok {
+			pc = &statv1.PropertyCount{Value: v}
+			counts[v] = pc
+			users[v] = make(map[uint32]bool)
+		}
+		pc.Count++
+		if ev.UserID != 0 {
+			users[v][ev.UserID] = true
+		}
+	}
+
+	resp := &statv1.TopPropertiesResponse{}
+	for v, pc := range counts {
+		pc.UniqueUsers = int64(len(users[v]))
+		resp.Items = append(resp.Items, pc)
+	}
+	sort.Slice(resp.Items, func(i, j int) bool {
+		a, b := resp.Items[i], resp.Items[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.Value < b.Value
+	})
+	if len(resp.Items) > limit {
+		resp.Items = resp.Items[:limit]
+	}
+	return resp, nil
+}
+
+func (s *Server) GetUserTimeline(ctx context.Context, req *statv1.GetUserTimelineRequest) (*statv1.GetUserTimelineResponse, error) {
+	uid, err := userref.Uint32Of(req.GetUser(), req.GetUserId())
+	if errors.Is(err, userref.ErrEmpty) {
+		return nil, status.Error(codes.InvalidArgument, "user or user_id is required")
+	}
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "user uid out of range")
+	}
+	if req.GetEndTime() != 0 && req.GetStartTime() >= req.GetEndTime() {
+		return nil, status.Error(codes.InvalidArgument, "start_time must be before end_time")
+	}
+	limit := int(req.GetLimit())
+	if limit <= 0 || limit > 500 {
+		limit = 50
+	}
+	f := Filter{
+		EventTypes: req.GetEventTypes(),
+		UserID:     uid,
+		Start:      req.GetStartTime(),
+		End:        req.GetEndTime(),
+		Limit:      limit + 1,
+	}
+	if tok := req.GetPageToken(); tok != "" {
+		c, err := decodeCursor(tok)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid page_token")
+		}
+		f.Before = c
+	}
+	events, err := s.store.Query(ctx, f)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "query events: %v", err)
+	}
+
+	resp := &statv1.GetUserTimelineResponse{}
+	if len(events) > limit {
+		events = events[:limit]
+		last := events[len(events)-1]
+		resp.NextPageToken = encodeCursor(&Cursor{Timestamp: last.Timestamp, ID: last.ID})
+	}
+	for _, ev := range events {
+		resp.Events = append(resp.Events, &statv1.TimelineEvent{
+			EventId:    ev.ID,
+			EventType:  ev.EventType,
+			Timestamp:  ev.Timestamp,
+			Ip:         ev.IP,
+			Source:     ev.Source,
+			Properties: ev.Properties,
+		})
+	}
+	return resp, nil
+}
+
+func checkRange(start, end int64) error {
+	if start <= 0 || end <= 0 || start >= end {
+		return status.Error(codes.InvalidArgument, "start_time and end_time are required and start_time must be before end_time")
+	}
+	return nil
+}
+
+// bucketStart 计算事件所在时间桶的起始时间；不分桶时所有事件归入 start
+func bucketStart(ts, start int64, g statv1.Granularity, loc *time.Location) int64 {
+	t := time.UnixMilli(ts).In(loc)
+	switch g {
+	case statv1.Granularity_GRANULARITY_HOUR:
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, loc).UnixMilli()
+	case statv1.Granularity_GRANULARITY_DAY:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc).UnixMilli()
+	}
+	return start
+}
+
+// propertyValue 取 properties JSON 顶层键的值，非字符串值按 JSON 文本返回
+func propertyValue(properties, key string) (string, bool) {
+	if properties == "" {
+		return "", false
+	}
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(properties), &m); err != nil {
+		return "", false
+	}
+	raw, ok := m[key]
+	if !ok {
+		return "", false
+	}
+	var str string
+	if err := json.Unmarshal(raw, &str); err == nil {
+		return str, true
+	}
+	return string(raw), true
+}
+
+func encodeCursor(c *Cursor) string {
+	return strconv.FormatInt(c.Timestamp, 10) + "_" + c.ID
+}
+
+func decodeCursor(tok string) (*Cursor, error) {
+	ts, id, ok := strings.Cut(tok, "_")
+	if !ok {
+		return nil, errors.New("malformed cursor")
+	}
+	n, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &Cursor{Timestamp: n, ID: id}, nil
+}
+
+// newEventID 生成按时间有序的事件 ID：6 字节毫秒时间戳 + 10 字节随机数，hex 编码
+func newEventID(now time.Time) string {
+	var b [16]byte
+	var ts [8]byte
+	binary.BigEndian.PutUint64(ts[:], uint64(now.UnixMilli()))
+	copy(b[:6], ts[2:])
+	_, _ = rand.Read(b[6:])
+	return hex.EncodeToString(b[:])
+}
@@ -0,0 +1,91 @@
+package server
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/trancecho/mundo-proto-sdk/common/userref"
+	commonv1 "github.com/trancecho/mundo-proto-sdk/common/v1"
+	"github.com/trancecho/mundo-proto-sdk/rconst"
+	statv1 "github.com/trancecho/mundo-proto-sdk/stat/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestTrackUserPrecedence(t *testing.T) {
+	now := time.Date(2025, 10, 19, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		userID  uint32
+		user    *commonv1.UserRef
+		wantUID uint32
+		wantOK  bool
+	}{
+		{"anonymous", 0, nil, 0, true},
+		{"legacy user_id", 7, nil, 7, true},
+		{"user only", 0, userref.FromInt64(8), 8, true},
+		{"user wins over user_id", 7, userref.FromInt64(8), 8, true},
+		{"user out of range", 7, userref.FromInt64(math.MaxUint32 + 1), 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewMemoryStorage()
+			s := NewServer(store, Config{Now: func() time.Time { return now }})
+			resp, err := s.TrackEvent(context.Background(), &statv1.TrackEventRequest{
+				EventType: rconst.EventChatSend, UserId: tt.userID, User: tt.user,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.GetSuccess() != tt.wantOK {
+				t.Fatalf("success = %v (%s), want %v", resp.GetSuccess(), resp.GetMessage(), tt.wantOK)
+			}
+			if !tt.wantOK {
+				return
+			}
+			events, err := store.Query(context.Background(), Filter{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(events) != 1 || events[0].UserID != tt.wantUID {
+				t.Fatalf("events = %+v, want user %d", events, tt.wantUID)
+			}
+		})
+	}
+}
+
+func TestGetUserTimelineUser(t *testing.T) {
+	now := time.Date(2025, 10, 19, 12, 0, 0, 0, time.UTC)
+	s := NewServer(NewMemoryStorage(), Config{Now: func() time.Time { return now }})
+	for _, uid := range []uint32{7, 8, 8} {
+		if _, err := s.TrackEvent(context.Background(), &statv1.TrackEventRequest{EventType: rconst.EventChatSend, UserId: uid}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tests := []struct {
+		name     string
+		userID   uint32
+		user     *commonv1.UserRef
+		wantLen  int
+		wantCode codes.Code
+	}{
+		{"legacy user_id", 7, nil, 1, codes.OK},
+		{"user only", 0, userref.FromInt64(8), 2, codes.OK},
+		{"user wins over user_id", 7, userref.FromInt64(8), 2, codes.OK},
+		{"missing user", 0, nil, 0, codes.InvalidArgument},
+		{"user out of range", 0, userref.FromInt64(-1), 0, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := s.GetUserTimeline(context.Background(), &statv1.GetUserTimelineRequest{UserId: tt.userID, User: tt.user})
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("code = %v (%v), want %v", code, err, tt.wantCode)
+			}
+			if len(resp.GetEvents()) != tt.wantLen {
+				t.Fatalf("events = %d, want %d", len(resp.GetEvents()), tt.wantLen)
+			}
+		})
+	}
+}
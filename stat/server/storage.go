@@ -0,0 +1,46 @@
+package server
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrDuplicate = errors.New("stat: duplicate client event id")
+
+// Event 存储层的事件
+type Event struct {
+	ID            string
+	ClientEventID string
+	EventType     string
+	Timestamp     int64 // 毫秒
+	UserID        uint32
+	IP            string
+	Source        string
+	Properties    string
+}
+
+// Filter 事件查询条件
+type Filter struct {
+	EventTypes []string // 为空表示全部
+	UserID     uint32   // 0 表示不限
+	Start      int64    // 起始时间戳（毫秒，含），0 表示不限
+	End        int64    // 结束时间戳（毫秒，不含），0 表示不限
+
+	// Before 游标：只返回 (Timestamp, ID) 严格小于该值的事件，用于倒序分页
+	Before *Cursor
+	Limit  int // 0 表示不限
+}
+
+// Cursor 倒序分页游标
+type Cursor struct {
+	Timestamp int64
+	ID        string
+}
+
+// Storage 事件存储接口
+type Storage interface {
+	// Save 写入事件。ClientEventID 非空且已存在时不写入，返回已存在事件的 ID 和 ErrDuplicate
+	Save(ctx context.Context, ev *Event) (string, error)
+	// Query 按条件查询事件，结果按 (Timestamp, ID) 倒序
+	Query(ctx context.Context, f Filter) ([]*Event, error)
+}
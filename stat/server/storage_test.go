@@ -0,0 +1,321 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/trancecho/mundo-proto-sdk/rconst"
+	statv1 "github.com/trancecho/mundo-proto-sdk/stat/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+var base = time.Date(2025, 10, 19, 12, 0, 0, 0, time.UTC)
+
+// storages 返回参与同一组用例的存储实现，每次调用创建独立实例
+func storages(t *testing.T) map[string]func() Storage {
+	return map[string]func() Storage{
+		"memory": func() Storage { return NewMemoryStorage() },
+		"gorm": func() Storage {
+			db, err := gorm.Open(sqlite.Open("file:"+t.Name()+"?mode=memory&cache=shared"), &gorm.Config{
+				Logger: logger.Default.LogMode(logger.Silent),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() {
+				if sqlDB, err := db.DB(); err == nil {
+					sqlDB.Close()
+				}
+			})
+			s := NewGormStorage(db)
+			if err := s.AutoMigrate(); err != nil {
+				t.Fatal(err)
+			}
+			return s
+		},
+	}
+}
+
+func eventIDs(events []*Event) []string {
+	ids := make([]string, len(events))
+	for i, ev := range events {
+		ids[i] = ev.ID
+	}
+	return ids
+}
+
+func TestStorage(t *testing.T) {
+	ms := base.UnixMilli()
+	events := []*Event{
+		{ID: "a", ClientEventID: "c1", EventType: "login", Timestamp: ms, UserID: 1},
+		{ID: "b", EventType: "click", Timestamp: ms + 1000, UserID: 2},
+		{ID: "c", EventType: "login", Timestamp: ms + 1000, UserID: 2},
+		{ID: "d", EventType: "login", Timestamp: ms + 2000, UserID: 1},
+	}
+	tests := []struct {
+		name   string
+		filter Filter
+		want   []string
+	}{
+		{"all newest first", Filter{}, []string{"d", "c", "b", "a"}},
+		{"event types", Filter{EventTypes: []string{"login"}}, []string{"d", "c", "a"}},
+		{"user", Filter{UserID: 2}, []string{"c", "b"}},
+		{"range end exclusive", Filter{Start: ms, End: ms + 1000}, []string{"a"}},
+		{"limit", Filter{Limit: 2}, []string{"d", "c"}},
+		{"before cursor breaks timestamp ties by id", Filter{Before: &Cursor{Timestamp: ms + 1000, ID: "c"}}, []string{"b", "a"}},
+	}
+	for name, open := range storages(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			s := open()
+			for _, ev := range events {
+				if _, err := s.Save(ctx, ev); err != nil {
+					t.Fatal(err)
+				}
+			}
+			id, err := s.Save(ctx, &Event{ID: "e", ClientEventID: "c1", EventType: "login", Timestamp: ms})
+			if !errors.Is(err, ErrDuplicate) || id != "a" {
+				t.Fatalf("duplicate Save = %q, %v; want a, ErrDuplicate", id, err)
+			}
+			for _, tt := range tests {
+				t.Run(tt.name, func(t *testing.T) {
+					got, err := s.Query(ctx, tt.filter)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if ids := eventIDs(got); !slices.Equal(ids, tt.want) {
+						t.Fatalf("Query = %v, want %v", ids, tt.want)
+					}
+				})
+			}
+			got, _ := s.Query(ctx, Filter{UserID: 1, Limit: 1, EventTypes: []string{"login"}, Start: ms, End: ms + 1})
+			if len(got) != 1 || got[0].ClientEventID != "c1" {
+				t.Fatalf("client_event_id not stored: %+v", got)
+			}
+		})
+	}
+}
+
+func TestTrackEventBatch(t *testing.T) {
+	tests := []struct {
+		name          string
+		ev            *statv1.TrackEventRequest
+		wantOK        bool
+		wantRetryable bool
+		wantMsg       string
+	}{
+		{"valid", &statv1.TrackEventRequest{EventType: rconst.EventLogin, UserId: 1, Properties: `{"k":"v"}`}, true, false, ""},
+		{"extra type", &statv1.TrackEventRequest{EventType: "custom"}, true, false, ""},
+		{"unknown type", &statv1.TrackEventRequest{EventType: "nope"}, false, false, "unknown event type"},
+		{"negative timestamp", &statv1.TrackEventRequest{EventType: rconst.EventLogin, Timestamp: -1}, false, false, "negative timestamp"},
+		{"future timestamp", &statv1.TrackEventRequest{EventType: rconst.EventLogin, Timestamp: base.Add(2 * time.Hour).UnixMilli()}, false, false, "future"},
+		{"bad properties", &statv1.TrackEventRequest{EventType: rconst.EventLogin, Properties: "{"}, false, false, "JSON"},
+		{"long client id", &statv1.TrackEventRequest{EventType: rconst.EventLogin, ClientEventId: strings.Repeat("x", 65)}, false, false, "too long"},
+	}
+	for name, open := range storages(t) {
+		t.Run(name, func(t *testing.T) {
+			s := NewServer(open(), Config{ExtraEventTypes: []string{"custom"}, MaxBatchSize: 10, Now: func() time.Time { return base }})
+			req := &statv1.TrackEventBatchRequest{}
+			for _, tt := range tests {
+				req.Events = append(req.Events, tt.ev)
+			}
+			resp, err := s.TrackEventBatch(context.Background(), req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i, tt := range tests {
+				r := resp.GetResults()[i]
+				if r.GetSuccess() != tt.wantOK || r.GetRetryable() != tt.wantRetryable || !strings.Contains(r.GetMessage(), tt.wantMsg) {
+					t.Errorf("%s: result = %v", tt.name, r)
+				}
+				if tt.wantOK != (resp.GetEventIds()[i] != "") {
+					t.Errorf("%s: event_id = %q", tt.name, resp.GetEventIds()[i])
+				}
+			}
+			if resp.GetSuccessCount() != 2 || resp.GetFailedCount() != 5 {
+				t.Fatalf("counts = %d/%d", resp.GetSuccessCount(), resp.GetFailedCount())
+			}
+
+			// 重发同一 client_event_id 视为成功并返回原事件 ID
+			ev := &statv1.TrackEventRequest{EventType: rconst.EventClick, ClientEventId: "retry-1"}
+			first, _ := s.TrackEvent(context.Background(), ev)
+			again, _ := s.TrackEvent(context.Background(), ev)
+			if !again.GetSuccess() || again.GetEventId() != first.GetEventId() {
+				t.Fatalf("retry = %v, want event %s", again, first.GetEventId())
+			}
+			events, _ := s.store.Query(context.Background(), Filter{EventTypes: []string{rconst.EventClick}})
+			if len(events) != 1 {
+				t.Fatalf("stored %d click events, want 1", len(events))
+			}
+
+			req.Events = make([]*statv1.TrackEventRequest, 11)
+			if _, err := s.TrackEventBatch(context.Background(), req); status.Code(err) != codes.InvalidArgument {
+				t.Fatalf("oversized batch err = %v", err)
+			}
+		})
+	}
+}
+
+// seedServer 写入固定事件：user 1 两次 login（第一天 0 点、1 点），user 2 一次 login（第二天），
+// 一次带属性的 click
+func seedServer(t *testing.T, open func() Storage) *Server {
+	t.Helper()
+	s := NewServer(open(), Config{Now: func() time.Time { return base.Add(72 * time.Hour) }})
+	events := []*statv1.TrackEventRequest{
+		{EventType: rconst.EventLogin, UserId: 1, Timestamp: base.UnixMilli()},
+		{EventType: rconst.EventLogin, UserId: 1, Timestamp: base.Add(time.Hour).UnixMilli()},
+		{EventType: rconst.EventLogin, UserId: 2, Timestamp: base.Add(24 * time.Hour).UnixMilli()},
+		{EventType: rconst.EventClick, UserId: 1, Timestamp: base.UnixMilli(), Properties: `{"button":"buy","n":1}`},
+		{EventType: rconst.EventClick, UserId: 2, Timestamp: base.UnixMilli(), Properties: `{"button":"buy","n":2}`},
+		{EventType: rconst.EventClick, UserId: 2, Timestamp: base.UnixMilli(), Properties: `{"button":"help"}`},
+	}
+	for _, ev := range events {
+		if resp, err := s.TrackEvent(context.Background(), ev); err != nil || !resp.GetSuccess() {
+			t.Fatalf("TrackEvent = %v, %v", resp, err)
+		}
+	}
+	return s
+}
+
+func TestCountEvents(t *testing.T) {
+	start, end := base.UnixMilli(), base.Add(48*time.Hour).UnixMilli()
+	type bucket struct {
+		start        int64
+		count, users int64
+	}
+	tests := []struct {
+		name     string
+		req      *statv1.CountEventsRequest
+		want     []bucket
+		wantCode codes.Code
+	}{
+		{"total", &statv1.CountEventsRequest{EventTypes: []string{rconst.EventLogin}, StartTime: start, EndTime: end},
+			[]bucket{{start, 3, 2}}, codes.OK},
+		{"hourly", &statv1.CountEventsRequest{EventTypes: []string{rconst.EventLogin}, StartTime: start, EndTime: end, Granularity: statv1.Granularity_GRANULARITY_HOUR},
+			[]bucket{{start, 1, 1}, {base.Add(time.Hour).UnixMilli(), 1, 1}, {base.Add(24 * time.Hour).UnixMilli(), 1, 1}}, codes.OK},
+		{"daily", &statv1.CountEventsRequest{EventTypes: []string{rconst.EventLogin}, StartTime: start, EndTime: end, Granularity: statv1.Granularity_GRANULARITY_DAY},
+			[]bucket{{time.Date(2025, 10, 19, 0, 0, 0, 0, time.UTC).UnixMilli(), 2, 1}, {time.Date(2025, 10, 20, 0, 0, 0, 0, time.UTC).UnixMilli(), 1, 1}}, codes.OK},
+		{"daily in timezone", &statv1.CountEventsRequest{EventTypes: []string{rconst.EventLogin}, StartTime: start, EndTime: end,
+			Granularity: statv1.Granularity_GRANULARITY_DAY, Timezone: "Asia/Tokyo"},
+			// 东京 21:00 与 22:00 同属 19 日，次日 21:00 属 20 日
+			[]bucket{{time.Date(2025, 10, 19, 15, 0, 0, 0, time.UTC).Add(-24 * time.Hour).UnixMilli(), 2, 1}, {time.Date(2025, 10, 19, 15, 0, 0, 0, time.UTC).UnixMilli(), 1, 1}}, codes.OK},
+		{"missing range", &statv1.CountEventsRequest{StartTime: start}, nil, codes.InvalidArgument},
+		{"reversed range", &statv1.CountEventsRequest{StartTime: end, EndTime: start}, nil, codes.InvalidArgument},
+		{"bad timezone", &statv1.CountEventsRequest{StartTime: start, EndTime: end, Timezone: "Mars/Base"}, nil, codes.InvalidArgument},
+	}
+	for name, open := range storages(t) {
+		t.Run(name, func(t *testing.T) {
+			s := seedServer(t, open)
+			for _, tt := range tests {
+				t.Run(tt.name, func(t *testing.T) {
+					resp, err := s.CountEvents(context.Background(), tt.req)
+					if status.Code(err) != tt.wantCode {
+						t.Fatalf("err = %v, want %v", err, tt.wantCode)
+					}
+					var got []bucket
+					for _, b := range resp.GetBuckets() {
+						got = append(got, bucket{b.GetBucketStart(), b.GetCount(), b.GetUniqueUsers()})
+					}
+					if !slices.Equal(got, tt.want) {
+						t.Fatalf("buckets = %v, want %v", got, tt.want)
+					}
+				})
+			}
+		})
+	}
+}
+
+func TestTopProperties(t *testing.T) {
+	start, end := base.UnixMilli(), base.Add(time.Hour).UnixMilli()
+	type item struct {
+		value        string
+		count, users int64
+	}
+	tests := []struct {
+		name     string
+		req      *statv1.TopPropertiesRequest
+		want     []item
+		wantCode codes.Code
+	}{
+		{"string values", &statv1.TopPropertiesRequest{EventType: rconst.EventClick, Property: "button", StartTime: start, EndTime: end},
+			[]item{{"buy", 2, 2}, {"help", 1, 1}}, codes.OK},
+		{"limit", &statv1.TopPropertiesRequest{EventType: rconst.EventClick, Property: "button", StartTime: start, EndTime: end, Limit: 1},
+			[]item{{"buy", 2, 2}}, codes.OK},
+		{"non-string values as JSON", &statv1.TopPropertiesRequest{EventType: rconst.EventClick, Property: "n", StartTime: start, EndTime: end},
+			[]item{{"1", 1, 1}, {"2", 1, 1}}, codes.OK},
+		{"missing property", &statv1.TopPropertiesRequest{EventType: rconst.EventClick, StartTime: start, EndTime: end}, nil, codes.InvalidArgument},
+		{"missing range", &statv1.TopPropertiesRequest{EventType: rconst.EventClick, Property: "button"}, nil, codes.InvalidArgument},
+	}
+	for name, open := range storages(t) {
+		t.Run(name, func(t *testing.T) {
+			s := seedServer(t, open)
+			for _, tt := range tests {
+				t.Run(tt.name, func(t *testing.T) {
+					resp, err := s.TopProperties(context.Background(), tt.req)
+					if status.Code(err) != tt.wantCode {
+						t.Fatalf("err = %v, want %v", err, tt.wantCode)
+					}
+					var got []item
+					for _, it := range resp.GetItems() {
+						got = append(got, item{it.GetValue(), it.GetCount(), it.GetUniqueUsers()})
+					}
+					if !slices.Equal(got, tt.want) {
+						t.Fatalf("items = %v, want %v", got, tt.want)
+					}
+				})
+			}
+		})
+	}
+}
+
+func TestGetUserTimelinePages(t *testing.T) {
+	for name, open := range storages(t) {
+		t.Run(name, func(t *testing.T) {
+			s := seedServer(t, open)
+			req := &statv1.GetUserTimelineRequest{UserId: 1, Limit: 2}
+			var pages [][]int64
+			for {
+				resp, err := s.GetUserTimeline(context.Background(), req)
+				if err != nil {
+					t.Fatal(err)
+				}
+				var page []int64
+				for _, ev := range resp.GetEvents() {
+					page = append(page, ev.GetTimestamp())
+				}
+				pages = append(pages, page)
+				if resp.GetNextPageToken() == "" {
+					break
+				}
+				req.PageToken = resp.GetNextPageToken()
+			}
+			// user 1: 1 点的 login，0 点的 login 和 click（同一毫秒按事件 ID 倒序）
+			if len(pages) != 2 || len(pages[0]) != 2 || len(pages[1]) != 1 ||
+				pages[0][0] != base.Add(time.Hour).UnixMilli() || pages[1][0] != base.UnixMilli() {
+				t.Fatalf("pages = %v", pages)
+			}
+
+			tests := []struct {
+				name string
+				req  *statv1.GetUserTimelineRequest
+			}{
+				{"bad token", &statv1.GetUserTimelineRequest{UserId: 1, PageToken: "garbage"}},
+				{"bad token timestamp", &statv1.GetUserTimelineRequest{UserId: 1, PageToken: "x_1"}},
+				{"reversed range", &statv1.GetUserTimelineRequest{UserId: 1, StartTime: 2, EndTime: 1}},
+			}
+			for _, tt := range tests {
+				if _, err := s.GetUserTimeline(context.Background(), tt.req); status.Code(err) != codes.InvalidArgument {
+					t.Errorf("%s: err = %v, want InvalidArgument", tt.name, err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package tracker
+
+import (
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// ClientIP 获取客户端 IP。只有连接的对端地址属于 trusted 时才读取代理头：
+// X-Forwarded-For 从右向左跳过可信代理，第一个不可信的地址即客户端（左侧的内容可由客户端伪造）；
+// 没有 X-Forwarded-For 时使用 X-Real-IP。trusted 为空时直接返回对端地址。
+func ClientIP(r *http.Request, trusted []netip.Prefix) string {
+	host, _, err := net.SplitHostPort(strings.TrimSpace(r.RemoteAddr))
+	if err != nil {
+		host = strings.TrimSpace(r.RemoteAddr)
+	}
+	remote, err := netip.ParseAddr(host)
+	if err != nil {
+		return host
+	}
+	client := remote.Unmap()
+	if !isTrusted(client, trusted) {
+		return client.String()
+	}
+
+	var hops []string
+	for _, v := range r.Header.Values("X-Forwarded-For") {
+		hops = append(hops, strings.Split(v, ",")...)
+	}
+	if len(hops) == 0 {
+		if ip, err := netip.ParseAddr(strings.TrimSpace(r.Header.Get("X-Real-IP"))); err == nil {
+			return ip.Unmap().String()
+		}
+		return client.String()
+	}
+	for i := len(hops) - 1; i >= 0; i-- {
+		ip, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
+		if err != nil {
+			// 格式错误的地址及其左侧的内容无法信任，使用最后一个可信代理记录的地址
+			break
+		}
+		client = ip.Unmap()
+		if !isTrusted(client, trusted) {
+			break
+		}
+	}
+	return client.String()
+}
+
+func isTrusted(ip netip.Addr, trusted []netip.Prefix) bool {
+	for _, p := range trusted {
+		if p.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+package tracker
+
+import (
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+)
+
+func TestClientIP(t *testing.T) {
+	proxies := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8"), netip.MustParsePrefix("::1/128")}
+	tests := []struct {
+		name    string
+		remote  string
+		xff     []string
+		realIP  string
+		trusted []netip.Prefix
+		want    string
+	}{
+		{"no trusted proxies ignores headers", "203.0.113.9:1234", []string{"1.1.1.1"}, "2.2.2.2", nil, "203.0.113.9"},
+		{"untrusted peer ignores headers", "203.0.113.9:1234", []string{"1.1.1.1"}, "", proxies, "203.0.113.9"},
+		{"single proxy", "10.0.0.1:1234", []string{"198.51.100.7"}, "", proxies, "198.51.100.7"},
+		{"spoofed left entry", "10.0.0.1:1234", []string{"1.1.1.1, 198.51.100.7"}, "", proxies, "198.51.100.7"},
+		{"proxy chain", "10.0.0.1:1234", []string{"198.51.100.7, 10.0.0.2", "10.0.0.3"}, "", proxies, "198.51.100.7"},
+		{"all hops trusted", "10.0.0.1:1234", []string{"10.0.0.5, 10.0.0.2"}, "", proxies, "10.0.0.5"},
+		{"malformed hop", "10.0.0.1:1234", []string{"198.51.100.7, bogus, 10.0.0.2"}, "", proxies, "10.0.0.2"},
+		{"x-real-ip from trusted peer", "10.0.0.1:1234", nil, "198.51.100.8", proxies, "198.51.100.8"},
+		{"ipv6 loopback proxy", "[::1]:1234", []string{"2001:db8::1"}, "", proxies, "2001:db8::1"},
+		{"ipv4-mapped peer", "[::ffff:10.0.0.1]:1234", []string{"198.51.100.7"}, "", proxies, "198.51.100.7"},
+		{"unparsable remote", "@", []string{"1.1.1.1"}, "", proxies, "@"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/", nil)
+			r.RemoteAddr = tt.remote
+			for _, v := range tt.xff {
+				r.Header.Add("X-Forwarded-For", v)
+			}
+			if tt.realIP != "" {
+				r.Header.Set("X-Real-IP", tt.realIP)
+			}
+			if got := ClientIP(r, tt.trusted); got != tt.want {
+				t.Fatalf("ClientIP = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
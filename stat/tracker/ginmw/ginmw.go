@@ -0,0 +1,189 @@
+// Package ginmw 自动上报 page_view/page_stay 事件的 Gin 中间件，
+// 单独成包使 stat/tracker 的其他使用方不依赖 gin。
+package ginmw
+
+import (
+	"encoding/json"
+	"math"
+	"math/rand/v2"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/trancecho/mundo-proto-sdk/common/userref"
+	commonv1 "github.com/trancecho/mundo-proto-sdk/common/v1"
+	"github.com/trancecho/mundo-proto-sdk/rauth"
+	"github.com/trancecho/mundo-proto-sdk/rconst"
+	"github.com/trancecho/mundo-proto-sdk/stat/tracker"
+	statv1 "github.com/trancecho/mundo-proto-sdk/stat/v1"
+)
+
+// Config Gin 统计中间件配置，零值字段使用默认值
+type Config struct {
+	SampleRate float64 // 采样率 (0, 1]，默认 1 即全量
+
+	// TrustedProxies 可信的反向代理（如网关、负载均衡）地址段，只有来自这些地址的请求才读取
+	// X-Forwarded-For / X-Real-IP，为空时使用连接的对端地址，见 tracker.ClientIP
+	TrustedProxies []netip.Prefix
+
+	// ExcludePaths 不上报的路由，支持路由模板（如 /api/:id）或以 * 结尾的前缀（如 /debug/*）
+	ExcludePaths []string
+	// Skip 自定义跳过条件（可为空）
+	Skip func(c *gin.Context) bool
+
+	// UserIDKey gin.Context 中已认证用户 ID 的键，默认 "user_id"；
+	// 该键不存在时使用请求 context 中的 rauth.Principal
+	UserIDKey string
+	// UserID 自定义用户 ID 获取方式（可为空），优先于 UserIDKey
+	UserID func(c *gin.Context) uint32
+
+	// StayHeader 前端上报页面停留时长（毫秒）的请求头，默认 "X-Page-Stay-Ms"，
+	// 请求携带该头时额外上报一条 page_stay 事件
+	StayHeader string
+}
+
+func (c *Config) setDefaults() {
+	if c.SampleRate <= 0 || c.SampleRate > 1 {
+		c.SampleRate = 1
+	}
+	if c.UserIDKey == "" {
+		c.UserIDKey = "user_id"
+	}
+	if c.StayHeader == "" {
+		c.StayHeader = "X-Page-Stay-Ms"
+	}
+}
+
+// Middleware 请求结束后自动上报 page_view（以及可选的 page_stay）事件。
+// 事件通过 sink 异步发送，不会阻塞请求处理。
+func Middleware(sink tracker.Sink, cfg Config) gin.HandlerFunc {
+	cfg.setDefaults()
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if cfg.excluded(route, c.Request.URL.Path) || (cfg.Skip != nil && cfg.Skip(c)) {
+			return
+		}
+		if cfg.SampleRate < 1 && rand.Float64() >= cfg.SampleRate {
+			return
+		}
+
+		user := cfg.user(c)
+		// 旧字段 user_id 为 uint32，放不下时只填 user
+		uid, _ := userref.Uint32Of(user, 0)
+		props := map[string]any{
+			"route":      route,
+			"path":       c.Request.URL.Path,
+			"method":     c.Request.Method,
+			"status":     c.Writer.Status(),
+			"latency_ms": time.Since(start).Milliseconds(),
+		}
+		if cfg.SampleRate < 1 {
+			props["sample_rate"] = cfg.SampleRate
+		}
+		ip := tracker.ClientIP(c.Request, cfg.TrustedProxies)
+		ua := c.Request.UserAgent()
+		sink.Track(&statv1.TrackEventRequest{
+			EventType:  rconst.EventPageView,
+			Timestamp:  start.UnixMilli(),
+			UserId:     uid,
+			User:       user,
+			Ip:         ip,
+			Source:     ua,
+			Properties: marshalProps(props),
+		})
+
+		if stay, err := strconv.ParseInt(c.GetHeader(cfg.StayHeader), 10, 64); err == nil && stay > 0 {
+			sink.Track(&statv1.TrackEventRequest{
+				EventType: rconst.EventPageStay,
+				Timestamp: start.UnixMilli(),
+				UserId:    uid,
+				User:      user,
+				Ip:        ip,
+				Source:    ua,
+				Properties: marshalProps(map[string]any{
+					"route":   route,
+					"path":    c.Request.URL.Path,
+					"stay_ms": stay,
+				}),
+			})
+		}
+	}
+}
+
+func (c *Config) excluded(route, path string) bool {
+	for _, p := range c.ExcludePaths {
+		if prefix, ok := strings.CutSuffix(p, "*"); ok {
+			if strings.HasPrefix(path, prefix) || (route != "" && strings.HasPrefix(route, prefix)) {
+				return true
+			}
+		} else if p == route || p == path {
+			return true
+		}
+	}
+	return false
+}
+
+// user 解析当前请求的已认证用户，未认证时返回 nil
+func (c *Config) user(ctx *gin.Context) *commonv1.UserRef {
+	if c.UserID != nil {
+		if uid := c.UserID(ctx); uid != 0 {
+			return userref.FromUint32(uid)
+		}
+		return nil
+	}
+	if v, ok := ctx.Get(c.UserIDKey); ok {
+		if uid := toInt64(v); uid > 0 {
+			return userref.FromInt64(uid)
+		}
+		return nil
+	}
+	if p, ok := rauth.FromContext(ctx.Request.Context()); ok && p.Uid > 0 {
+		return userref.WithMuid(p.Uid, p.Muid)
+	}
+	return nil
+}
+
+// toInt64 将上下文中常见类型的用户 ID 转为 int64，越界、为负或无法解析时返回 0
+func toInt64(v any) int64 {
+	switch id := v.(type) {
+	case int64:
+		return max(id, 0)
+	case int:
+		return int64(max(id, 0))
+	case int32:
+		return int64(max(id, 0))
+	case uint32:
+		return int64(id)
+	case uint:
+		if uint64(id) > math.MaxInt64 {
+			return 0
+		}
+		return int64(id)
+	case uint64:
+		if id > math.MaxInt64 {
+			return 0
+		}
+		return int64(id)
+	case string:
+		n, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			return 0
+		}
+		return max(n, 0)
+	default:
+		return 0
+	}
+}
+
+func marshalProps(props map[string]any) string {
+	b, err := json.Marshal(props)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
@@ -0,0 +1,117 @@
+package ginmw
+
+import (
+	"math"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/trancecho/mundo-proto-sdk/rauth"
+	"github.com/trancecho/mundo-proto-sdk/rconst"
+	statv1 "github.com/trancecho/mundo-proto-sdk/stat/v1"
+)
+
+type sinkFunc func(ev *statv1.TrackEventRequest) bool
+
+func (f sinkFunc) Track(ev *statv1.TrackEventRequest) bool { return f(ev) }
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name      string
+		path      string
+		stay      string
+		wantTypes []string
+		wantIP    string
+	}{
+		{"page view", "/posts/1", "", []string{rconst.EventPageView}, "198.51.100.7"},
+		{"page stay", "/posts/1", "1500", []string{rconst.EventPageView, rconst.EventPageStay}, "198.51.100.7"},
+		{"excluded prefix", "/debug/pprof", "", nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []*statv1.TrackEventRequest
+			r := gin.New()
+			r.Use(Middleware(sinkFunc(func(ev *statv1.TrackEventRequest) bool {
+				got = append(got, ev)
+				return true
+			}), Config{
+				ExcludePaths:   []string{"/debug/*"},
+				TrustedProxies: []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")},
+			}))
+			r.GET("/posts/:id", func(c *gin.Context) { c.Set("user_id", uint(42)) })
+			r.GET("/debug/pprof", func(*gin.Context) {})
+
+			req := httptest.NewRequest("GET", tt.path, nil)
+			req.RemoteAddr = "10.0.0.1:1234"
+			req.Header.Set("X-Forwarded-For", "1.1.1.1, 198.51.100.7")
+			if tt.stay != "" {
+				req.Header.Set("X-Page-Stay-Ms", tt.stay)
+			}
+			r.ServeHTTP(httptest.NewRecorder(), req)
+
+			if len(got) != len(tt.wantTypes) {
+				t.Fatalf("got %d events, want %d", len(got), len(tt.wantTypes))
+			}
+			for i, ev := range got {
+				if ev.GetEventType() != tt.wantTypes[i] || ev.GetIp() != tt.wantIP || ev.GetUserId() != 42 || ev.GetUser().GetUid() != 42 {
+					t.Fatalf("event %d = %v", i, ev)
+				}
+			}
+		})
+	}
+}
+
+func TestMiddlewareUser(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name      string
+		cfg       Config
+		set       any // 写入 gin.Context 的 user_id，nil 表示不写入
+		principal *rauth.Principal
+		wantUID   uint32
+		wantUser  int64
+		wantMuid  string
+	}{
+		{"anonymous", Config{}, nil, nil, 0, 0, ""},
+		{"context key", Config{}, "42", nil, 42, 42, ""},
+		{"context key wider than user_id", Config{}, int64(math.MaxUint32 + 1), nil, 0, math.MaxUint32 + 1, ""},
+		{"negative context key", Config{}, -1, nil, 0, 0, ""},
+		{"principal", Config{}, nil, &rauth.Principal{Uid: 7, Muid: "m-7"}, 7, 7, "m-7"},
+		{"context key wins over principal", Config{}, 42, &rauth.Principal{Uid: 7}, 42, 42, ""},
+		{"custom UserID", Config{UserID: func(*gin.Context) uint32 { return 9 }}, 42, nil, 9, 9, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []*statv1.TrackEventRequest
+			r := gin.New()
+			r.Use(func(c *gin.Context) {
+				if tt.principal != nil {
+					c.Request = c.Request.WithContext(rauth.NewContext(c.Request.Context(), tt.principal))
+				}
+				if tt.set != nil {
+					c.Set("user_id", tt.set)
+				}
+			})
+			r.Use(Middleware(sinkFunc(func(ev *statv1.TrackEventRequest) bool {
+				got = append(got, ev)
+				return true
+			}), tt.cfg))
+			r.GET("/posts/:id", func(*gin.Context) {})
+
+			req := httptest.NewRequest("GET", "/posts/1", nil)
+			req.Header.Set("X-Page-Stay-Ms", "100")
+			r.ServeHTTP(httptest.NewRecorder(), req)
+
+			if len(got) != 2 {
+				t.Fatalf("got %d events, want 2", len(got))
+			}
+			for _, ev := range got {
+				if ev.GetUserId() != tt.wantUID || ev.GetUser().GetUid() != tt.wantUser || ev.GetUser().GetMuid() != tt.wantMuid {
+					t.Fatalf("%s: user_id = %d, user = %v", ev.GetEventType(), ev.GetUserId(), ev.GetUser())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,311 @@
+package tracker
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"slices"
+	"sync"
+
+	statv1 "github.com/trancecho/mundo-proto-sdk/stat/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+var ErrSpoolFull = errors.New("tracker: spool full")
+
+// maxRecordSize 单条记录上限，超过视为文件损坏
+const maxRecordSize = 64 << 20
+
+// compactMinBytes 已回放部分达到该大小且超过文件一半时压缩文件
+const compactMinBytes = 1 << 20
+
+// Spool 本地追加写的事件落盘文件。
+// 每条记录为 varint 长度前缀 + TrackEventBatchRequest 的 protobuf 编码（与 protodelim 格式兼容），
+// 回放进度保存在 <path>.offset 中，全部回放完成后文件被截断；
+// 回放持续失败时，已回放部分超过文件一半会把剩余记录重写到新文件，避免文件只增不减。
+type Spool struct {
+	path       string
+	maxBytes   int64 // 未回放记录的容量上限
+	compactMin int64
+
+	mu      sync.Mutex
+	f       *os.File
+	size    int64 // 文件有效长度
+	readOff int64 // 已回放到的位置
+}
+
+// OpenSpool 打开（或创建）落盘文件，maxBytes <= 0 时默认 64MB
+func OpenSpool(path string, maxBytes int64) (*Spool, error) {
+	if maxBytes <= 0 {
+		maxBytes = 64 << 20
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	s := &Spool{
+		path:       path,
+		maxBytes:   maxBytes,
+		compactMin: compactMinBytes,
+		f:          f,
+	}
+	// 进程崩溃可能在文件尾部留下残缺的记录，截断到最后一条完整记录，
+	// 否则之后追加的记录会排在损坏内容之后，回放时被一并清空
+	bounds, valid := scanRecords(f, info.Size())
+	if valid < info.Size() {
+		log.Printf("[统计上报] spool 文件 %s 尾部 %d 字节不完整，已截断", path, info.Size()-valid)
+		if err := f.Truncate(valid); err != nil {
+			f.Close()
+			return nil, err
+		}
+	}
+	s.size = valid
+	if b, err := os.ReadFile(s.offsetPath()); err == nil && len(b) == 8 {
+		s.readOff = int64(binary.BigEndian.Uint64(b))
+	}
+	// 回放进度必须落在记录边界上，否则退回到之前最近的边界（重复的事件由服务端按 client_event_id 去重）
+	i, _ := slices.BinarySearch(bounds, s.readOff)
+	if i == len(bounds) || bounds[i] != s.readOff {
+		s.readOff = 0
+		if i > 0 {
+			s.readOff = bounds[i-1]
+		}
+	}
+	return s, nil
+}
+
+// scanRecords 从头校验记录，返回每条记录的起始位置（含结尾位置）及最后一条完整记录的结束位置
+func scanRecords(f *os.File, size int64) ([]int64, int64) {
+	r := bufio.NewReader(io.NewSectionReader(f, 0, size))
+	bounds := []int64{0}
+	var off int64
+	for off < size {
+		n, data, err := readRecord(r)
+		if err != nil {
+			break
+		}
+		if err := proto.Unmarshal(data, &statv1.TrackEventBatchRequest{}); err != nil {
+			break
+		}
+		off += int64(uvarintLen(n)) + int64(n)
+		bounds = append(bounds, off)
+	}
+	return bounds, off
+}
+
+// readRecord 读取一条记录的长度前缀和内容
+func readRecord(r *bufio.Reader) (uint64, []byte, error) {
+	n, err := binary.ReadUvarint(r)
+	if err != nil {
+		return 0, nil, err
+	}
+	if n > maxRecordSize {
+		return 0, nil, fmt.Errorf("record size %d exceeds limit", n)
+	}
+	data := make([]byte, n)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return 0, nil, err
+	}
+	return n, data, nil
+}
+
+func (s *Spool) offsetPath() string {
+	return s.path + ".offset"
+}
+
+// Append 追加一批事件，未回放的记录超过容量上限时返回 ErrSpoolFull
+func (s *Spool) Append(events []*statv1.TrackEventRequest) error {
+	if len(events) == 0 {
+		return nil
+	}
+	data, err := proto.Marshal(&statv1.TrackEventBatchRequest{Events: events})
+	if err != nil {
+		return err
+	}
+	record := binary.AppendUvarint(make([]byte, 0, len(data)+binary.MaxVarintLen64), uint64(len(data)))
+	record = append(record, data...)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.f == nil {
+		return os.ErrClosed
+	}
+	if s.size-s.readOff+int64(len(record)) > s.maxBytes {
+		return ErrSpoolFull
+	}
+	if _, err := s.f.WriteAt(record, s.size); err != nil {
+		return err
+	}
+	if err := s.f.Sync(); err != nil {
+		return err
+	}
+	s.size += int64(len(record))
+	return nil
+}
+
+// Pending 尚未回放的字节数
+func (s *Spool) Pending() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.size - s.readOff
+}
+
+// Replay 按写入顺序逐条回放，fn 返回错误时停止并保留该条记录，下次从该条继续。
+// fn 在不持有锁的情况下调用，回放期间仍可 Append。
+func (s *Spool) Replay(fn func(*statv1.TrackEventBatchRequest) error) (int, error) {
+	replayed := 0
+	for {
+		req, next, err := s.next()
+		if err != nil {
+			return replayed, err
+		}
+		if req == nil {
+			return replayed, nil
+		}
+		if err := fn(req); err != nil {
+			return replayed, err
+		}
+		if err := s.commit(next); err != nil {
+			return replayed, err
+		}
+		replayed++
+	}
+}
+
+// next 读取 readOff 处的一条记录，返回记录及其结束位置；没有记录时返回 nil
+func (s *Spool) next() (*statv1.TrackEventBatchRequest, int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.f == nil {
+		return nil, 0, os.ErrClosed
+	}
+	if s.readOff >= s.size {
+		return nil, 0, nil
+	}
+	r := bufio.NewReader(io.NewSectionReader(s.f, s.readOff, s.size-s.readOff))
+	n, data, err := readRecord(r)
+	req := &statv1.TrackEventBatchRequest{}
+	if err == nil {
+		err = proto.Unmarshal(data, req)
+	}
+	if err != nil {
+		// OpenSpool 已截断残缺的尾部，这里只会是运行期间文件被外部破坏；readOff 之前已回放，之后的内容无法解析，直接清空
+		log.Printf("[统计上报] spool 文件 %s 在 %d 处损坏，已清空: %v", s.path, s.readOff, err)
+		return nil, 0, s.reset()
+	}
+	return req, s.readOff + int64(uvarintLen(n)) + int64(n), nil
+}
+
+// commit 记录回放进度，全部回放完成后截断文件，已回放部分过大时压缩文件
+func (s *Spool) commit(off int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.f == nil {
+		return os.ErrClosed
+	}
+	s.readOff = off
+	if s.readOff >= s.size {
+		return s.reset()
+	}
+	if s.readOff >= s.compactMin && s.readOff*2 >= s.size {
+		return s.compact()
+	}
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], uint64(s.readOff))
+	return writeFileAtomic(s.offsetPath(), b[:])
+}
+
+// writeFileAtomic 先写临时文件再 rename，避免崩溃时留下写了一半的进度文件
+func writeFileAtomic(path string, data []byte) error {
+	tmp := path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// compact 把未回放的记录重写到新文件并替换原文件，需持有锁。
+// 先删除进度文件再替换：中途崩溃最多导致已回放的记录被重复上报（服务端按 client_event_id 去重），不会丢失记录
+func (s *Spool) compact() error {
+	tmp := s.path + ".compact"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_RDWR|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	n, err := io.Copy(f, io.NewSectionReader(s.f, s.readOff, s.size-s.readOff))
+	if err == nil {
+		err = f.Sync()
+	}
+	if err == nil {
+		err = os.Remove(s.offsetPath())
+		if os.IsNotExist(err) {
+			err = nil
+		}
+	}
+	if err == nil {
+		err = os.Rename(tmp, s.path)
+	}
+	if err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	s.f.Close()
+	s.f = f
+	s.size, s.readOff = n, 0
+	return nil
+}
+
+// reset 清空文件和回放进度，需持有锁
+func (s *Spool) reset() error {
+	s.size, s.readOff = 0, 0
+	if err := s.f.Truncate(0); err != nil {
+		return err
+	}
+	if err := os.Remove(s.offsetPath()); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// Close 关闭文件，未回放的记录保留到下次 OpenSpool
+func (s *Spool) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.f == nil {
+		return nil
+	}
+	err := s.f.Close()
+	s.f = nil
+	return err
+}
+
+func uvarintLen(x uint64) int {
+	n := 1
+	for x >= 0x80 {
+		x >>= 7
+		n++
+	}
+	return n
+}
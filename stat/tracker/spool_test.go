@@ -0,0 +1,239 @@
+package tracker
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	statv1 "github.com/trancecho/mundo-proto-sdk/stat/v1"
+)
+
+func events(ids ...string) []*statv1.TrackEventRequest {
+	out := make([]*statv1.TrackEventRequest, len(ids))
+	for i, id := range ids {
+		out[i] = &statv1.TrackEventRequest{EventType: "click", ClientEventId: id}
+	}
+	return out
+}
+
+// replayAll 回放全部记录，返回按顺序出现的 client_event_id
+func replayAll(t *testing.T, s *Spool) []string {
+	t.Helper()
+	var got []string
+	if _, err := s.Replay(func(req *statv1.TrackEventBatchRequest) error {
+		for _, ev := range req.GetEvents() {
+			got = append(got, ev.GetClientEventId())
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return got
+}
+
+func openSpool(t *testing.T, path string) *Spool {
+	t.Helper()
+	s, err := OpenSpool(path, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func TestSpoolTornTail(t *testing.T) {
+	tests := []struct {
+		name string
+		tail []byte
+	}{
+		{"partial length prefix", []byte{0x80}},
+		{"short record body", []byte{0x10, 0x0a, 0x02}},
+		{"undecodable record body", []byte{0x03, 0x0a, 0x05, 0x01}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "spool")
+			s := openSpool(t, path)
+			if err := s.Append(events("a", "b")); err != nil {
+				t.Fatal(err)
+			}
+			valid := s.Pending()
+			s.Close()
+
+			// 模拟崩溃时写了一半的记录
+			f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+			if err != nil {
+				t.Fatal(err)
+			}
+			f.Write(tt.tail)
+			f.Close()
+
+			s = openSpool(t, path)
+			if got := s.Pending(); got != valid {
+				t.Fatalf("Pending after reopen = %d, want %d", got, valid)
+			}
+			if err := s.Append(events("c")); err != nil {
+				t.Fatal(err)
+			}
+			got := replayAll(t, s)
+			if want := []string{"a", "b", "c"}; !slices.Equal(got, want) {
+				t.Fatalf("replayed %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestSpoolResumeAfterFailedReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spool")
+	s := openSpool(t, path)
+	for _, id := range []string{"a", "b", "c"} {
+		if err := s.Append(events(id)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	fail := errors.New("unavailable")
+	n, err := s.Replay(func(req *statv1.TrackEventBatchRequest) error {
+		if req.GetEvents()[0].GetClientEventId() == "b" {
+			return fail
+		}
+		return nil
+	})
+	if n != 1 || !errors.Is(err, fail) {
+		t.Fatalf("Replay = %d, %v; want 1, %v", n, err, fail)
+	}
+	s.Close()
+
+	if _, err := os.Stat(path + ".offset.tmp"); !os.IsNotExist(err) {
+		t.Fatalf("temporary offset file left behind: %v", err)
+	}
+	s = openSpool(t, path)
+	if got, want := replayAll(t, s), []string{"b", "c"}; !slices.Equal(got, want) {
+		t.Fatalf("replayed after reopen %v, want %v", got, want)
+	}
+	if s.Pending() != 0 {
+		t.Fatalf("Pending after full replay = %d", s.Pending())
+	}
+}
+
+func TestSpoolOffsetRecovery(t *testing.T) {
+	tests := []struct {
+		name   string
+		offset []byte
+		want   []string
+	}{
+		{"missing offset", nil, []string{"a", "b"}},
+		{"truncated offset file", []byte{0, 0, 1}, []string{"a", "b"}},
+		{"offset inside a record", []byte{0, 0, 0, 0, 0, 0, 0, 3}, []string{"a", "b"}},
+		{"offset past the end", []byte{0, 0, 0, 0, 0, 0, 1, 0}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "spool")
+			s := openSpool(t, path)
+			s.Append(events("a"))
+			s.Append(events("b"))
+			s.Close()
+			if tt.offset != nil {
+				if err := os.WriteFile(path+".offset", tt.offset, 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			s = openSpool(t, path)
+			if got := replayAll(t, s); !slices.Equal(got, tt.want) {
+				t.Fatalf("replayed %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSpoolFull(t *testing.T) {
+	s, err := OpenSpool(filepath.Join(t.TempDir(), "spool"), 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	if err := s.Append(events("a")); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Append(events("bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")); !errors.Is(err, ErrSpoolFull) {
+		t.Fatalf("Append over limit err = %v, want ErrSpoolFull", err)
+	}
+	s.Close()
+	if err := s.Append(events("c")); !errors.Is(err, os.ErrClosed) {
+		t.Fatalf("Append after Close err = %v, want os.ErrClosed", err)
+	}
+}
+
+func TestSpoolCompact(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spool")
+	s := openSpool(t, path)
+	s.compactMin = 0
+	for _, id := range []string{"a", "b", "c", "d"} {
+		if err := s.Append(events(id)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	total := s.Pending()
+	fail := errors.New("unavailable")
+	var got []string
+	n, err := s.Replay(func(req *statv1.TrackEventBatchRequest) error {
+		id := req.GetEvents()[0].GetClientEventId()
+		if id == "c" {
+			return fail
+		}
+		got = append(got, id)
+		return nil
+	})
+	if n != 2 || !errors.Is(err, fail) {
+		t.Fatalf("Replay = %d, %v; want 2, %v", n, err, fail)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Size() != s.Pending() || info.Size() >= total {
+		t.Fatalf("file size after compaction = %d, pending %d, before %d", info.Size(), s.Pending(), total)
+	}
+	if _, err := os.Stat(path + ".offset"); !os.IsNotExist(err) {
+		t.Fatalf("offset file left after compaction: %v", err)
+	}
+	if err := s.Append(events("e")); err != nil {
+		t.Fatal(err)
+	}
+	s.Close()
+
+	s = openSpool(t, path)
+	got = append(got, replayAll(t, s)...)
+	if want := []string{"a", "b", "c", "d", "e"}; !slices.Equal(got, want) {
+		t.Fatalf("replayed %v, want %v", got, want)
+	}
+}
+
+func TestSpoolFullCountsPending(t *testing.T) {
+	s, err := OpenSpool(filepath.Join(t.TempDir(), "spool"), 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	for _, id := range []string{"a", "b"} {
+		if err := s.Append(events(id)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.Append(events("c")); !errors.Is(err, ErrSpoolFull) {
+		t.Fatalf("Append over limit err = %v, want ErrSpoolFull", err)
+	}
+	// 回放一条但未回放完，文件不会截断，已回放的字节不应占用容量
+	fail := errors.New("unavailable")
+	s.Replay(func(req *statv1.TrackEventBatchRequest) error {
+		if req.GetEvents()[0].GetClientEventId() == "b" {
+			return fail
+		}
+		return nil
+	})
+	if err := s.Append(events("c")); err != nil {
+		t.Fatalf("Append after partial replay err = %v", err)
+	}
+}
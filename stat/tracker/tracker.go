@@ -0,0 +1,559 @@
+package tracker
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	statv1 "github.com/trancecho/mundo-proto-sdk/stat/v1"
+)
+
+// DropPolicy 队列满时的丢弃策略
+type DropPolicy int
+
+const (
+	DropNewest DropPolicy = iota // 丢弃新到达的事件
+	DropOldest                   // 丢弃队列中最早的事件
+)
+
+var (
+	ErrQueueFull = errors.New("tracker: queue full")
+	ErrClosed    = errors.New("tracker: closed")
+	ErrRetries   = errors.New("tracker: retries exhausted")
+	ErrRejected  = errors.New("tracker: rejected by server")
+)
+
+// Sink 接收统计事件，实现方必须非阻塞（*Tracker 即满足）
+type Sink interface {
+	Track(ev *statv1.TrackEventRequest) bool
+}
+
+var _ Sink = &Tracker{}
+
+// Config Tracker 配置，零值字段使用默认值
+type Config struct {
+	BatchSize     int           // 单次 TrackEventBatch 的最大事件数，默认 100
+	FlushInterval time.Duration // 定时刷新间隔，默认 5s
+	QueueSize     int           // 内存队列上限，默认 10000
+	MaxRetries    int           // 单个事件最大重试次数，默认 3，负数表示不重试
+	RPCTimeout    time.Duration // 单次 RPC 超时，默认 3s
+	DropPolicy    DropPolicy    // 队列满时的丢弃策略，默认 DropNewest
+
+	// Spool 可选的落盘文件：重试耗尽、队列溢出或关闭时未发送的事件写入其中，
+	// 服务恢复后按顺序回放。Tracker.Close 时一并关闭。
+	Spool *Spool
+
+	// OnDrop 事件被丢弃时回调（可为空）。会在调用 Track、Flush、Close 的 goroutine 或内部 goroutine 中同步调用
+	// （如 Track 遇到已关闭或队列溢出时），需并发安全且不要阻塞
+	OnDrop func(ev *statv1.TrackEventRequest, reason error)
+}
+
+func (c *Config) setDefaults() {
+	if c.BatchSize <= 0 {
+		c.BatchSize = 100
+	}
+	if c.FlushInterval <= 0 {
+		c.FlushInterval = 5 * time.Second
+	}
+	if c.QueueSize <= 0 {
+		c.QueueSize = 10000
+	}
+	if c.QueueSize < c.BatchSize {
+		c.QueueSize = c.BatchSize
+	}
+	if c.MaxRetries < 0 {
+		c.MaxRetries = 0
+	} else if c.MaxRetries == 0 {
+		c.MaxRetries = 3
+	}
+	if c.RPCTimeout <= 0 {
+		c.RPCTimeout = 3 * time.Second
+	}
+}
+
+// Stats Tracker 运行统计
+type Stats struct {
+	Enqueued   int64 // 进入内存队列的数量
+	Overflowed int64 // 队列满时转入落盘缓冲的数量（DropNewest 为新事件，DropOldest 为被挤出的事件）
+	Sent       int64 // 上报成功数量
+	Retried    int64 // 重试次数
+	Dropped    int64 // 丢弃数量
+	Spooled    int64 // 落盘数量
+	Replayed   int64 // 从落盘文件回放成功的数量
+	Queued     int   // 当前排队数量
+}
+
+type item struct {
+	ev       *statv1.TrackEventRequest
+	attempts int
+}
+
+// Tracker 异步批量上报统计事件：事件先进入内存队列，
+// 按 BatchSize 或 FlushInterval 通过 TrackEventBatch 刷新，失败的事件按逐条结果重试。
+type Tracker struct {
+	client statv1.StatServiceClient
+	cfg    Config
+
+	mu       sync.Mutex
+	queue    []*item
+	overflow []*item // 等待落盘的溢出事件，由后台 goroutine 写入 Spool，避免在 Track 中做磁盘 IO
+	closed   bool
+
+	sendMu  sync.Mutex // 保证同一时刻只有一个批次在发送
+	notify  chan struct{}
+	stop    chan struct{}
+	stopped chan struct{}
+
+	enqueued   atomic.Int64
+	overflowed atomic.Int64
+	sent       atomic.Int64
+	retried    atomic.Int64
+	dropped    atomic.Int64
+	spooled    atomic.Int64
+	replayed   atomic.Int64
+}
+
+// New 创建 Tracker 并启动后台刷新 goroutine，使用完毕后必须调用 Close
+func New(client statv1.StatServiceClient, cfg Config) *Tracker {
+	cfg.setDefaults()
+	t := &Tracker{
+		client:  client,
+		cfg:     cfg,
+		notify:  make(chan struct{}, 1),
+		stop:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	go t.loop()
+	return t
+}
+
+// Track 将事件放入队列，不会阻塞调用方；返回 false 表示事件被丢弃
+func (t *Tracker) Track(ev *statv1.TrackEventRequest) bool {
+	if ev == nil {
+		return false
+	}
+	if ev.Timestamp == 0 {
+		ev.Timestamp = time.Now().UnixMilli()
+	}
+	// 重试时服务端按 client_event_id 去重，避免超时重发导致重复计数
+	if ev.ClientEventId == "" {
+		ev.ClientEventId = newClientEventID()
+	}
+
+	t.mu.Lock()
+	if t.closed {
+		t.mu.Unlock()
+		t.drop(ev, ErrClosed)
+		return false
+	}
+	it := &item{ev: ev}
+	var evicted *item
+	queued := true
+	if len(t.queue) >= t.cfg.QueueSize {
+		if t.cfg.DropPolicy == DropOldest {
+			evicted = t.queue[0]
+			t.queue[0] = nil
+			t.queue = append(t.queue[1:], it)
+		} else {
+			evicted = it
+			queued = false
+		}
+		if t.cfg.Spool != nil && len(t.overflow) < t.cfg.QueueSize {
+			t.overflow = append(t.overflow, evicted)
+			evicted = nil
+			t.overflowed.Add(1)
+		}
+	} else {
+		t.queue = append(t.queue, it)
+	}
+	full := len(t.queue) >= t.cfg.BatchSize
+	t.mu.Unlock()
+
+	if evicted != nil {
+		t.drop(evicted.ev, ErrQueueFull)
+	}
+	if queued {
+		t.enqueued.Add(1)
+	}
+	if full {
+		t.wake()
+	}
+	// 转入落盘的事件不算丢弃
+	return queued || evicted == nil
+}
+
+// TrackEvent 便捷方法，按事件类型和用户构造事件后入队
+func (t *Tracker) TrackEvent(eventType string, userID uint32, properties string) bool {
+	return t.Track(&statv1.TrackEventRequest{
+		EventType:  eventType,
+		UserId:     userID,
+		Properties: properties,
+	})
+}
+
+// Flush 同步发送当前队列中的所有事件（失败项会按重试次数重新入队）
+func (t *Tracker) Flush(ctx context.Context) error {
+	n := t.Len()
+	for n > 0 {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		sent := t.flushOnce(ctx)
+		if sent == 0 {
+			break
+		}
+		n -= sent
+	}
+	return nil
+}
+
+// Close 停止后台刷新，并在 ctx 截止前尽量把剩余事件发送出去。
+// 截止时仍未发送的事件会通过 OnDrop 回调丢弃。
+func (t *Tracker) Close(ctx context.Context) error {
+	t.mu.Lock()
+	if t.closed {
+		t.mu.Unlock()
+		return nil
+	}
+	t.closed = true
+	t.mu.Unlock()
+
+	close(t.stop)
+	<-t.stopped
+
+	var err error
+	for t.Len() > 0 {
+		if err = ctx.Err(); err != nil {
+			break
+		}
+		t.flushOnce(ctx)
+	}
+	t.discard(t.takeAll(), ErrClosed)
+	t.spillOverflow()
+	if t.cfg.Spool != nil {
+		if cerr := t.cfg.Spool.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+// Len 当前队列中的事件数量
+func (t *Tracker) Len() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.queue)
+}
+
+// Stats 返回运行统计
+func (t *Tracker) Stats() Stats {
+	return Stats{
+		Enqueued:   t.enqueued.Load(),
+		Overflowed: t.overflowed.Load(),
+		Sent:       t.sent.Load(),
+		Retried:    t.retried.Load(),
+		Dropped:    t.dropped.Load(),
+		Spooled:    t.spooled.Load(),
+		Replayed:   t.replayed.Load(),
+		Queued:     t.Len(),
+	}
+}
+
+func (t *Tracker) wake() {
+	select {
+	case t.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (t *Tracker) loop() {
+	defer close(t.stopped)
+	ticker := time.NewTicker(t.cfg.FlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-t.stop:
+			return
+		case <-ticker.C:
+			t.spillOverflow()
+			// 落盘的事件更早，先回放完再发送内存队列，保证顺序
+			if t.replaySpool() {
+				t.flushAvailable(false)
+			}
+		case <-t.notify:
+			t.spillOverflow()
+			t.flushAvailable(true)
+		}
+	}
+}
+
+// flushAvailable 刷新队列；onlyFull 为 true 时只发送满批次
+func (t *Tracker) flushAvailable(onlyFull bool) {
+	for {
+		select {
+		case <-t.stop:
+			return
+		default:
+		}
+		n := t.Len()
+		if n == 0 || (onlyFull && n < t.cfg.BatchSize) {
+			return
+		}
+		if t.flushOnce(context.Background()) == 0 {
+			return
+		}
+		// 整批失败时不在本轮继续重试，等待下一个刷新周期
+		if t.Len() >= n {
+			return
+		}
+	}
+}
+
+// flushOnce 取出一个批次发送，返回取出的事件数
+func (t *Tracker) flushOnce(ctx context.Context) int {
+	t.sendMu.Lock()
+	defer t.sendMu.Unlock()
+
+	batch := t.take(t.cfg.BatchSize)
+	if len(batch) == 0 {
+		return 0
+	}
+	events := make([]*statv1.TrackEventRequest, len(batch))
+	for i, it := range batch {
+		events[i] = it.ev
+	}
+
+	rpcCtx, cancel := context.WithTimeout(ctx, t.cfg.RPCTimeout)
+	resp, err := t.client.TrackEventBatch(rpcCtx, &statv1.TrackEventBatchRequest{Events: events})
+	cancel()
+	if err != nil {
+		log.Printf("[统计上报] TrackEventBatch 失败 (%d 条): %v", len(batch), err)
+		t.retry(batch, err)
+		return len(batch)
+	}
+
+	var failed []*item
+	var rejected int
+	for i, ok := range batchResults(resp, len(batch)) {
+		switch ok {
+		case resultOK:
+			t.sent.Add(1)
+		case resultRetry:
+			failed = append(failed, batch[i])
+		default:
+			rejected++
+			t.drop(batch[i].ev, ErrRejected)
+		}
+	}
+	if len(failed) > 0 || rejected > 0 {
+		log.Printf("[统计上报] 批量上报部分失败: 成功 %d, 重试 %d, 拒绝 %d, message: %s",
+			len(batch)-len(failed)-rejected, len(failed), rejected, resp.GetMessage())
+	}
+	t.retry(failed, ErrRetries)
+	return len(batch)
+}
+
+type result int
+
+const (
+	resultOK result = iota
+	resultRetry
+	resultReject
+)
+
+// batchResults 根据 TrackEventBatchResponse 推断每条事件的结果。
+// 优先使用逐条 results；老版本服务端只返回计数和 event_ids 时尽量兼容。
+func batchResults(resp *statv1.TrackEventBatchResponse, n int) []result {
+	out := make([]result, n)
+	if rs := resp.GetResults(); len(rs) > 0 {
+		for i := range out {
+			out[i] = resultRetry
+		}
+		for _, r := range rs {
+			idx := int(r.GetIndex())
+			if idx < 0 || idx >= n {
+				continue
+			}
+			switch {
+			case r.GetSuccess():
+				out[idx] = resultOK
+			case r.GetRetryable():
+				out[idx] = resultRetry
+			default:
+				out[idx] = resultReject
+			}
+		}
+		return out
+	}
+
+	if resp.GetFailedCount() == 0 {
+		return out
+	}
+	if ids := resp.GetEventIds(); len(ids) == n {
+		for i, id := range ids {
+			if id == "" {
+				out[i] = resultRetry
+			}
+		}
+		return out
+	}
+	if resp.GetSuccessCount() == 0 {
+		for i := range out {
+			out[i] = resultRetry
+		}
+		return out
+	}
+	// 无法定位失败项，避免重复上报，全部视为完成
+	return out
+}
+
+// take 从队首取出最多 n 个事件
+func (t *Tracker) take(n int) []*item {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if n > len(t.queue) {
+		n = len(t.queue)
+	}
+	batch := make([]*item, n)
+	copy(batch, t.queue[:n])
+	t.queue = t.queue[n:]
+	return batch
+}
+
+func (t *Tracker) takeAll() []*item {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	all := t.queue
+	t.queue = nil
+	return all
+}
+
+// retry 把失败事件放回队首，超过最大重试次数或队列溢出的事件被丢弃
+func (t *Tracker) retry(items []*item, reason error) {
+	if len(items) == 0 {
+		return
+	}
+	var keep, exhausted []*item
+	for _, it := range items {
+		it.attempts++
+		if it.attempts > t.cfg.MaxRetries {
+			exhausted = append(exhausted, it)
+			continue
+		}
+		keep = append(keep, it)
+	}
+
+	var overflow []*item
+	t.mu.Lock()
+	queue := make([]*item, 0, len(keep)+len(t.queue))
+	queue = append(queue, keep...)
+	queue = append(queue, t.queue...)
+	if extra := len(queue) - t.cfg.QueueSize; extra > 0 {
+		if t.cfg.DropPolicy == DropOldest {
+			overflow = queue[:extra]
+			queue = queue[extra:]
+		} else {
+			overflow = queue[len(queue)-extra:]
+			queue = queue[:len(queue)-extra]
+		}
+	}
+	t.queue = queue
+	t.mu.Unlock()
+
+	t.retried.Add(int64(len(keep)))
+	t.discard(exhausted, reason)
+	t.discard(overflow, ErrQueueFull)
+}
+
+// discard 处理无法继续留在内存中的事件：配置了 Spool 时落盘，否则丢弃
+func (t *Tracker) discard(items []*item, reason error) {
+	if len(items) == 0 {
+		return
+	}
+	if t.cfg.Spool != nil {
+		events := make([]*statv1.TrackEventRequest, len(items))
+		for i, it := range items {
+			events[i] = it.ev
+		}
+		err := t.cfg.Spool.Append(events)
+		if err == nil {
+			t.spooled.Add(int64(len(events)))
+			return
+		}
+		log.Printf("[统计上报] 写入 spool 失败 (%d 条): %v", len(events), err)
+		reason = err
+	}
+	for _, it := range items {
+		t.drop(it.ev, reason)
+	}
+}
+
+func (t *Tracker) spillOverflow() {
+	t.mu.Lock()
+	overflow := t.overflow
+	t.overflow = nil
+	t.mu.Unlock()
+	t.discard(overflow, ErrQueueFull)
+}
+
+// replaySpool 回放落盘事件，全部回放完成（或未配置 Spool）时返回 true
+func (t *Tracker) replaySpool() bool {
+	sp := t.cfg.Spool
+	if sp == nil || sp.Pending() == 0 {
+		return true
+	}
+	t.sendMu.Lock()
+	defer t.sendMu.Unlock()
+
+	_, err := sp.Replay(func(req *statv1.TrackEventBatchRequest) error {
+		select {
+		case <-t.stop:
+			return ErrClosed
+		default:
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), t.cfg.RPCTimeout)
+		resp, err := t.client.TrackEventBatch(ctx, req)
+		cancel()
+		if err != nil {
+			return err
+		}
+		var failed []*item
+		for i, r := range batchResults(resp, len(req.Events)) {
+			switch r {
+			case resultOK:
+				t.replayed.Add(1)
+			case resultRetry:
+				failed = append(failed, &item{ev: req.Events[i]})
+			default:
+				t.drop(req.Events[i], ErrRejected)
+			}
+		}
+		t.retry(failed, ErrRetries)
+		return nil
+	})
+	if err != nil {
+		if !errors.Is(err, ErrClosed) {
+			log.Printf("[统计上报] spool 回放中断，剩余 %d 字节: %v", sp.Pending(), err)
+		}
+		return false
+	}
+	return true
+}
+
+func (t *Tracker) drop(ev *statv1.TrackEventRequest, reason error) {
+	t.dropped.Add(1)
+	if t.cfg.OnDrop != nil {
+		t.cfg.OnDrop(ev, reason)
+	}
+}
+
+func newClientEventID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
@@ -0,0 +1,300 @@
+package tracker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	statv1 "github.com/trancecho/mundo-proto-sdk/stat/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// newIdleTracker 创建不启动后台 goroutine 的 Tracker，队列内容只随 Track 和显式 Flush 变化
+func newIdleTracker(client statv1.StatServiceClient, cfg Config) *Tracker {
+	cfg.setDefaults()
+	return &Tracker{client: client, cfg: cfg}
+}
+
+func TestTrackQueueFull(t *testing.T) {
+	tests := []struct {
+		name        string
+		policy      DropPolicy
+		spool       bool
+		n           int
+		wantOK      []bool
+		want        Stats
+		wantSpooled []string
+	}{
+		{"drop newest", DropNewest, false, 3, []bool{true, true, false},
+			Stats{Enqueued: 2, Dropped: 1, Queued: 2}, nil},
+		{"drop oldest", DropOldest, false, 3, []bool{true, true, true},
+			Stats{Enqueued: 3, Dropped: 1, Queued: 2}, nil},
+		{"drop newest to spool", DropNewest, true, 3, []bool{true, true, true},
+			Stats{Enqueued: 2, Overflowed: 1, Spooled: 1, Queued: 2}, []string{"2"}},
+		{"drop oldest to spool", DropOldest, true, 3, []bool{true, true, true},
+			Stats{Enqueued: 3, Overflowed: 1, Spooled: 1, Queued: 2}, []string{"0"}},
+		{"overflow buffer full", DropNewest, true, 5, []bool{true, true, true, true, false},
+			Stats{Enqueued: 2, Overflowed: 2, Spooled: 2, Dropped: 1, Queued: 2}, []string{"2", "3"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Config{BatchSize: 2, QueueSize: 2, DropPolicy: tt.policy}
+			if tt.spool {
+				cfg.Spool = openSpool(t, filepath.Join(t.TempDir(), "events.spool"))
+			}
+			tr := newIdleTracker(nil, cfg)
+			var ok []bool
+			for _, ev := range events(idsOf(tt.n)...) {
+				ok = append(ok, tr.Track(ev))
+			}
+			tr.spillOverflow()
+			if !slices.Equal(ok, tt.wantOK) {
+				t.Fatalf("Track = %v, want %v", ok, tt.wantOK)
+			}
+			if got := tr.Stats(); got != tt.want {
+				t.Fatalf("Stats = %+v, want %+v", got, tt.want)
+			}
+			if tt.spool {
+				if got := replayAll(t, cfg.Spool); !slices.Equal(got, tt.wantSpooled) {
+					t.Fatalf("spooled = %v, want %v", got, tt.wantSpooled)
+				}
+			}
+		})
+	}
+}
+
+func idsOf(n int) []string {
+	ids := make([]string, n)
+	for i := range ids {
+		ids[i] = fmt.Sprint(i)
+	}
+	return ids
+}
+
+// fakeStat 记录每个批次的 client_event_id，respond 为空时全部成功
+type fakeStat struct {
+	statv1.StatServiceClient
+	mu      sync.Mutex
+	batches [][]string
+	respond func(ctx context.Context, call int, req *statv1.TrackEventBatchRequest) (*statv1.TrackEventBatchResponse, error)
+}
+
+func (f *fakeStat) TrackEventBatch(ctx context.Context, req *statv1.TrackEventBatchRequest, _ ...grpc.CallOption) (*statv1.TrackEventBatchResponse, error) {
+	f.mu.Lock()
+	call := len(f.batches)
+	var ids []string
+	for _, ev := range req.GetEvents() {
+		ids = append(ids, ev.GetClientEventId())
+	}
+	f.batches = append(f.batches, ids)
+	f.mu.Unlock()
+	if f.respond != nil {
+		return f.respond(ctx, call, req)
+	}
+	return &statv1.TrackEventBatchResponse{SuccessCount: int32(len(ids))}, nil
+}
+
+func (f *fakeStat) sent() [][]string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return slices.Clone(f.batches)
+}
+
+// drops 收集 OnDrop 回调
+type drops struct {
+	mu      sync.Mutex
+	reasons map[string]error
+}
+
+func (d *drops) onDrop(ev *statv1.TrackEventRequest, reason error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.reasons == nil {
+		d.reasons = make(map[string]error)
+	}
+	d.reasons[ev.GetClientEventId()] = reason
+}
+
+func (d *drops) get(id string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.reasons[id]
+}
+
+func TestTrackAssignsClientEventID(t *testing.T) {
+	tr := newIdleTracker(nil, Config{})
+	ev := &statv1.TrackEventRequest{EventType: "click"}
+	tr.Track(ev)
+	if len(ev.GetClientEventId()) != 32 || ev.GetTimestamp() == 0 {
+		t.Fatalf("event = %v", ev)
+	}
+	kept := &statv1.TrackEventRequest{EventType: "click", ClientEventId: "mine"}
+	tr.Track(kept)
+	if kept.GetClientEventId() != "mine" {
+		t.Fatalf("client_event_id overwritten: %q", kept.GetClientEventId())
+	}
+}
+
+func TestFlushBatches(t *testing.T) {
+	client := &fakeStat{}
+	tr := newIdleTracker(client, Config{BatchSize: 2})
+	for _, ev := range events(idsOf(5)...) {
+		tr.Track(ev)
+	}
+	if err := tr.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{{"0", "1"}, {"2", "3"}, {"4"}}
+	if got := client.sent(); !slices.EqualFunc(got, want, slices.Equal) {
+		t.Fatalf("batches = %v, want %v", got, want)
+	}
+	if st := tr.Stats(); st.Sent != 5 || st.Queued != 0 {
+		t.Fatalf("Stats = %+v", st)
+	}
+}
+
+func TestFlushRetries(t *testing.T) {
+	errDown := status.Error(codes.Unavailable, "down")
+	tests := []struct {
+		name        string
+		maxRetries  int
+		respond     func(ctx context.Context, call int, req *statv1.TrackEventBatchRequest) (*statv1.TrackEventBatchResponse, error)
+		flushes     int
+		wantBatches [][]string
+		wantStats   Stats
+		wantDrops   map[string]error
+	}{
+		{"per-item results", 3, func(_ context.Context, call int, req *statv1.TrackEventBatchRequest) (*statv1.TrackEventBatchResponse, error) {
+			if call > 0 {
+				return &statv1.TrackEventBatchResponse{SuccessCount: int32(len(req.Events))}, nil
+			}
+			return &statv1.TrackEventBatchResponse{SuccessCount: 1, FailedCount: 2, Results: []*statv1.TrackEventResult{
+				{Index: 0, Success: true},
+				{Index: 1, Retryable: true},
+				{Index: 2, Message: "unknown event type"},
+			}}, nil
+		}, 2, [][]string{{"0", "1", "2"}, {"1"}}, Stats{Enqueued: 3, Sent: 2, Retried: 1, Dropped: 1},
+			map[string]error{"2": ErrRejected}},
+		{"rpc error retried until exhausted", 1, func(context.Context, int, *statv1.TrackEventBatchRequest) (*statv1.TrackEventBatchResponse, error) {
+			return nil, errDown
+		}, 3, [][]string{{"0", "1", "2"}, {"0", "1", "2"}}, Stats{Enqueued: 3, Retried: 3, Dropped: 3},
+			map[string]error{"0": errDown, "1": errDown, "2": errDown}},
+		{"legacy event_ids", 3, func(_ context.Context, call int, req *statv1.TrackEventBatchRequest) (*statv1.TrackEventBatchResponse, error) {
+			if call > 0 {
+				return &statv1.TrackEventBatchResponse{SuccessCount: int32(len(req.Events))}, nil
+			}
+			return &statv1.TrackEventBatchResponse{SuccessCount: 2, FailedCount: 1, EventIds: []string{"e0", "", "e2"}}, nil
+		}, 2, [][]string{{"0", "1", "2"}, {"1"}}, Stats{Enqueued: 3, Sent: 3, Retried: 1}, nil},
+		{"legacy all failed", 3, func(_ context.Context, call int, req *statv1.TrackEventBatchRequest) (*statv1.TrackEventBatchResponse, error) {
+			if call > 0 {
+				return &statv1.TrackEventBatchResponse{SuccessCount: int32(len(req.Events))}, nil
+			}
+			return &statv1.TrackEventBatchResponse{FailedCount: 3}, nil
+		}, 2, [][]string{{"0", "1", "2"}, {"0", "1", "2"}}, Stats{Enqueued: 3, Sent: 3, Retried: 3}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d drops
+			client := &fakeStat{respond: tt.respond}
+			tr := newIdleTracker(client, Config{BatchSize: 10, MaxRetries: tt.maxRetries, OnDrop: d.onDrop})
+			for _, ev := range events(idsOf(3)...) {
+				tr.Track(ev)
+			}
+			for range tt.flushes {
+				tr.flushOnce(context.Background())
+			}
+			if got := client.sent(); !slices.EqualFunc(got, tt.wantBatches, slices.Equal) {
+				t.Fatalf("batches = %v, want %v", got, tt.wantBatches)
+			}
+			if got := tr.Stats(); got != tt.wantStats {
+				t.Fatalf("Stats = %+v, want %+v", got, tt.wantStats)
+			}
+			for id, want := range tt.wantDrops {
+				if got := d.get(id); !errors.Is(got, want) {
+					t.Fatalf("drop %s reason = %v, want %v", id, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestBatchResults(t *testing.T) {
+	const (
+		ok    = resultOK
+		retry = resultRetry
+		rej   = resultReject
+	)
+	tests := []struct {
+		name string
+		resp *statv1.TrackEventBatchResponse
+		want []result
+	}{
+		{"all ok", &statv1.TrackEventBatchResponse{SuccessCount: 3}, []result{ok, ok, ok}},
+		{"per-item", &statv1.TrackEventBatchResponse{Results: []*statv1.TrackEventResult{
+			{Index: 0, Success: true}, {Index: 2}, {Index: 7, Success: true},
+		}}, []result{ok, retry, rej}},
+		{"legacy event_ids", &statv1.TrackEventBatchResponse{SuccessCount: 2, FailedCount: 1, EventIds: []string{"a", "", "c"}},
+			[]result{ok, retry, ok}},
+		{"legacy nothing succeeded", &statv1.TrackEventBatchResponse{FailedCount: 3}, []result{retry, retry, retry}},
+		{"legacy unlocatable partial failure", &statv1.TrackEventBatchResponse{SuccessCount: 2, FailedCount: 1},
+			[]result{ok, ok, ok}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := batchResults(tt.resp, 3); !slices.Equal(got, tt.want) {
+				t.Fatalf("batchResults = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClose(t *testing.T) {
+	tests := []struct {
+		name      string
+		block     bool
+		wantSent  int64
+		wantDrops int64
+	}{
+		{"flushes remaining events", false, 3, 0},
+		{"drops events after deadline", true, 0, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeStat{}
+			if tt.block {
+				client.respond = func(ctx context.Context, _ int, _ *statv1.TrackEventBatchRequest) (*statv1.TrackEventBatchResponse, error) {
+					<-ctx.Done()
+					return nil, ctx.Err()
+				}
+			}
+			var d drops
+			tr := New(client, Config{BatchSize: 10, FlushInterval: time.Hour, OnDrop: d.onDrop})
+			for _, ev := range events(idsOf(3)...) {
+				tr.Track(ev)
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			err := tr.Close(ctx)
+			if tt.block != (err != nil) {
+				t.Fatalf("Close err = %v", err)
+			}
+			st := tr.Stats()
+			if st.Sent != tt.wantSent || st.Dropped != tt.wantDrops || st.Queued != 0 {
+				t.Fatalf("Stats = %+v", st)
+			}
+			if tt.block && !errors.Is(d.get("0"), ErrClosed) {
+				t.Fatalf("drop reason = %v, want ErrClosed", d.get("0"))
+			}
+			if tr.Track(events("late")[0]) || !errors.Is(d.get("late"), ErrClosed) {
+				t.Fatal("Track after Close should drop with ErrClosed")
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@
 package statv1
 
 import (
+	v1 "github.com/trancecho/mundo-proto-sdk/common/v1"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
@@ -21,15 +22,67 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Granularity 时间桶粒度
+type Granularity int32
+
+const (
+	Granularity_GRANULARITY_UNSPECIFIED Granularity = 0 // 不分桶，整个时间范围汇总为一个桶
+	Granularity_GRANULARITY_HOUR        Granularity = 1 // 按小时
+	Granularity_GRANULARITY_DAY         Granularity = 2 // 按天
+)
+
+// Enum value maps for Granularity.
+var (
+	Granularity_name = map[int32]string{
+		0: "GRANULARITY_UNSPECIFIED",
+		1: "GRANULARITY_HOUR",
+		2: "GRANULARITY_DAY",
+	}
+	Granularity_value = map[string]int32{
+		"GRANULARITY_UNSPECIFIED": 0,
+		"GRANULARITY_HOUR":        1,
+		"GRANULARITY_DAY":         2,
+	}
+)
+
+func (x Granularity) Enum() *Granularity {
+	p := new(Granularity)
+	*p = x
+	return p
+}
+
+func (x Granularity) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Granularity) Descriptor() protoreflect.EnumDescriptor {
+	return file_stat_v1_stat_proto_enumTypes[0].Descriptor()
+}
+
+func (Granularity) Type() protoreflect.EnumType {
+	return &file_stat_v1_stat_proto_enumTypes[0]
+}
+
+func (x Granularity) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Granularity.Descriptor instead.
+func (Granularity) EnumDescriptor() ([]byte, []int) {
+	return file_stat_v1_stat_proto_rawDescGZIP(), []int{0}
+}
+
 // TrackEventRequest 上报事件请求
 type TrackEventRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	EventType     string                 `protobuf:"bytes,1,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"` // 事件类型
-	Timestamp     int64                  `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`                 // 事件时间戳（毫秒）
-	UserId        uint32                 `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`         // 用户 ID
-	Ip            string                 `protobuf:"bytes,4,opt,name=ip,proto3" json:"ip,omitempty"`                                // IP 地址
-	Source        string                 `protobuf:"bytes,5,opt,name=source,proto3" json:"source,omitempty"`                        // 来源（如 user_agent）
-	Properties    string                 `protobuf:"bytes,6,opt,name=properties,proto3" json:"properties,omitempty"`                // 事件属性（JSON 字符串）
+	EventType     string                 `protobuf:"bytes,1,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`               // 事件类型
+	Timestamp     int64                  `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`                               // 事件时间戳（毫秒）
+	UserId        uint32                 `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`                       // 用户 ID
+	Ip            string                 `protobuf:"bytes,4,opt,name=ip,proto3" json:"ip,omitempty"`                                              // IP 地址
+	Source        string                 `protobuf:"bytes,5,opt,name=source,proto3" json:"source,omitempty"`                                      // 来源（如 user_agent）
+	Properties    string                 `protobuf:"bytes,6,opt,name=properties,proto3" json:"properties,omitempty"`                              // 事件属性（JSON 字符串）
+	ClientEventId string                 `protobuf:"bytes,7,opt,name=client_event_id,json=clientEventId,proto3" json:"client_event_id,omitempty"` // 客户端生成的事件 ID，用于重试去重（可选）
+	User          *v1.UserRef            `protobuf:"bytes,8,opt,name=user,proto3" json:"user,omitempty"`                                          // 统一用户标识，设置时优先于 user_id
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -106,6 +159,20 @@ func (x *TrackEventRequest) GetProperties() string {
 	return ""
 }
 
+func (x *TrackEventRequest) GetClientEventId() string {
+	if x != nil {
+		return x.ClientEventId
+	}
+	return ""
+}
+
+func (x *TrackEventRequest) GetUser() *v1.UserRef {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
 // TrackEventResponse 上报事件响应
 type TrackEventResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -219,6 +286,7 @@ type TrackEventBatchResponse struct {
 	FailedCount   int32                  `protobuf:"varint,2,opt,name=failed_count,json=failedCount,proto3" json:"failed_count,omitempty"`    // 失败数量
 	EventIds      []string               `protobuf:"bytes,3,rep,name=event_ids,json=eventIds,proto3" json:"event_ids,omitempty"`              // 事件 ID 列表
 	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`                                // 消息
+	Results       []*TrackEventResult    `protobuf:"bytes,5,rep,name=results,proto3" json:"results,omitempty"`                                // 逐条结果（与 events 一一对应）
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -281,11 +349,700 @@ func (x *TrackEventBatchResponse) GetMessage() string {
 	return ""
 }
 
+func (x *TrackEventBatchResponse) GetResults() []*TrackEventResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// TrackEventResult 批量上报中单条事件的结果
+type TrackEventResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`                   // 对应 events 中的下标
+	EventId       string                 `protobuf:"bytes,2,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"` // 事件 ID（成功时）
+	Success       bool                   `protobuf:"varint,3,opt,name=success,proto3" json:"success,omitempty"`               // 是否成功
+	Retryable     bool                   `protobuf:"varint,4,opt,name=retryable,proto3" json:"retryable,omitempty"`           // 失败时是否可重试
+	Message       string                 `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`                // 失败原因
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrackEventResult) Reset() {
+	*x = TrackEventResult{}
+	mi := &file_stat_v1_stat_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrackEventResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrackEventResult) ProtoMessage() {}
+
+func (x *TrackEventResult) ProtoReflect() protoreflect.Message {
+	mi := &file_stat_v1_stat_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrackEventResult.ProtoReflect.Descriptor instead.
+func (*TrackEventResult) Descriptor() ([]byte, []int) {
+	return file_stat_v1_stat_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TrackEventResult) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *TrackEventResult) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *TrackEventResult) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *TrackEventResult) GetRetryable() bool {
+	if x != nil {
+		return x.Retryable
+	}
+	return false
+}
+
+func (x *TrackEventResult) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// CountEventsRequest 事件计数请求
+type CountEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventTypes    []string               `protobuf:"bytes,1,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`           // 事件类型，为空表示全部
+	StartTime     int64                  `protobuf:"varint,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`             // 起始时间戳（毫秒，含）
+	EndTime       int64                  `protobuf:"varint,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`                   // 结束时间戳（毫秒，不含）
+	Granularity   Granularity            `protobuf:"varint,4,opt,name=granularity,proto3,enum=stat.v1.Granularity" json:"granularity,omitempty"` // 时间桶粒度
+	Timezone      string                 `protobuf:"bytes,5,opt,name=timezone,proto3" json:"timezone,omitempty"`                                 // 按天分桶使用的时区（如 Asia/Shanghai），默认 UTC
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CountEventsRequest) Reset() {
+	*x = CountEventsRequest{}
+	mi := &file_stat_v1_stat_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountEventsRequest) ProtoMessage() {}
+
+func (x *CountEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stat_v1_stat_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountEventsRequest.ProtoReflect.Descriptor instead.
+func (*CountEventsRequest) Descriptor() ([]byte, []int) {
+	return file_stat_v1_stat_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CountEventsRequest) GetEventTypes() []string {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+func (x *CountEventsRequest) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *CountEventsRequest) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+func (x *CountEventsRequest) GetGranularity() Granularity {
+	if x != nil {
+		return x.Granularity
+	}
+	return Granularity_GRANULARITY_UNSPECIFIED
+}
+
+func (x *CountEventsRequest) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+// CountBucket 单个时间桶内某事件类型的统计
+type CountBucket struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BucketStart   int64                  `protobuf:"varint,1,opt,name=bucket_start,json=bucketStart,proto3" json:"bucket_start,omitempty"` // 桶起始时间戳（毫秒）
+	EventType     string                 `protobuf:"bytes,2,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`        // 事件类型
+	Count         int64                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`                                // 事件次数
+	UniqueUsers   int64                  `protobuf:"varint,4,opt,name=unique_users,json=uniqueUsers,proto3" json:"unique_users,omitempty"` // 独立用户数
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CountBucket) Reset() {
+	*x = CountBucket{}
+	mi := &file_stat_v1_stat_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountBucket) ProtoMessage() {}
+
+func (x *CountBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_stat_v1_stat_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountBucket.ProtoReflect.Descriptor instead.
+func (*CountBucket) Descriptor() ([]byte, []int) {
+	return file_stat_v1_stat_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CountBucket) GetBucketStart() int64 {
+	if x != nil {
+		return x.BucketStart
+	}
+	return 0
+}
+
+func (x *CountBucket) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *CountBucket) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *CountBucket) GetUniqueUsers() int64 {
+	if x != nil {
+		return x.UniqueUsers
+	}
+	return 0
+}
+
+// CountEventsResponse 事件计数响应
+type CountEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Buckets       []*CountBucket         `protobuf:"bytes,1,rep,name=buckets,proto3" json:"buckets,omitempty"` // 按 bucket_start、event_type 升序
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CountEventsResponse) Reset() {
+	*x = CountEventsResponse{}
+	mi := &file_stat_v1_stat_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountEventsResponse) ProtoMessage() {}
+
+func (x *CountEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stat_v1_stat_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountEventsResponse.ProtoReflect.Descriptor instead.
+func (*CountEventsResponse) Descriptor() ([]byte, []int) {
+	return file_stat_v1_stat_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CountEventsResponse) GetBuckets() []*CountBucket {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
+// TopPropertiesRequest 属性 Top N 请求
+type TopPropertiesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventType     string                 `protobuf:"bytes,1,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`  // 事件类型
+	Property      string                 `protobuf:"bytes,2,opt,name=property,proto3" json:"property,omitempty"`                     // 属性名（properties JSON 的顶层键）
+	StartTime     int64                  `protobuf:"varint,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"` // 起始时间戳（毫秒，含）
+	EndTime       int64                  `protobuf:"varint,4,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`       // 结束时间戳（毫秒，不含）
+	Limit         int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`                          // 返回数量，默认 10
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TopPropertiesRequest) Reset() {
+	*x = TopPropertiesRequest{}
+	mi := &file_stat_v1_stat_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopPropertiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopPropertiesRequest) ProtoMessage() {}
+
+func (x *TopPropertiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stat_v1_stat_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopPropertiesRequest.ProtoReflect.Descriptor instead.
+func (*TopPropertiesRequest) Descriptor() ([]byte, []int) {
+	return file_stat_v1_stat_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *TopPropertiesRequest) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *TopPropertiesRequest) GetProperty() string {
+	if x != nil {
+		return x.Property
+	}
+	return ""
+}
+
+func (x *TopPropertiesRequest) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *TopPropertiesRequest) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+func (x *TopPropertiesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// PropertyCount 属性值统计
+type PropertyCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`                                 // 属性值
+	Count         int64                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`                                // 事件次数
+	UniqueUsers   int64                  `protobuf:"varint,3,opt,name=unique_users,json=uniqueUsers,proto3" json:"unique_users,omitempty"` // 独立用户数
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PropertyCount) Reset() {
+	*x = PropertyCount{}
+	mi := &file_stat_v1_stat_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PropertyCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PropertyCount) ProtoMessage() {}
+
+func (x *PropertyCount) ProtoReflect() protoreflect.Message {
+	mi := &file_stat_v1_stat_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PropertyCount.ProtoReflect.Descriptor instead.
+func (*PropertyCount) Descriptor() ([]byte, []int) {
+	return file_stat_v1_stat_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PropertyCount) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *PropertyCount) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *PropertyCount) GetUniqueUsers() int64 {
+	if x != nil {
+		return x.UniqueUsers
+	}
+	return 0
+}
+
+// TopPropertiesResponse 属性 Top N 响应
+type TopPropertiesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*PropertyCount       `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"` // 按 count 降序
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TopPropertiesResponse) Reset() {
+	*x = TopPropertiesResponse{}
+	mi := &file_stat_v1_stat_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopPropertiesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopPropertiesResponse) ProtoMessage() {}
+
+func (x *TopPropertiesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stat_v1_stat_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopPropertiesResponse.ProtoReflect.Descriptor instead.
+func (*TopPropertiesResponse) Descriptor() ([]byte, []int) {
+	return file_stat_v1_stat_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *TopPropertiesResponse) GetItems() []*PropertyCount {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// GetUserTimelineRequest 用户时间线请求
+type GetUserTimelineRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        uint32                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`            // 用户 ID
+	EventTypes    []string               `protobuf:"bytes,2,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"` // 事件类型，为空表示全部
+	StartTime     int64                  `protobuf:"varint,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`   // 起始时间戳（毫秒，含）
+	EndTime       int64                  `protobuf:"varint,4,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`         // 结束时间戳（毫秒，不含）
+	Limit         int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`                            // 每页数量，默认 50
+	PageToken     string                 `protobuf:"bytes,6,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`    // 分页游标，首页为空
+	User          *v1.UserRef            `protobuf:"bytes,7,opt,name=user,proto3" json:"user,omitempty"`                               // 统一用户标识，设置时优先于 user_id
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserTimelineRequest) Reset() {
+	*x = GetUserTimelineRequest{}
+	mi := &file_stat_v1_stat_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserTimelineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserTimelineRequest) ProtoMessage() {}
+
+func (x *GetUserTimelineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stat_v1_stat_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserTimelineRequest.ProtoReflect.Descriptor instead.
+func (*GetUserTimelineRequest) Descriptor() ([]byte, []int) {
+	return file_stat_v1_stat_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetUserTimelineRequest) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *GetUserTimelineRequest) GetEventTypes() []string {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+func (x *GetUserTimelineRequest) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *GetUserTimelineRequest) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+func (x *GetUserTimelineRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetUserTimelineRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *GetUserTimelineRequest) GetUser() *v1.UserRef {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+// TimelineEvent 时间线中的单个事件
+type TimelineEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`       // 事件 ID
+	EventType     string                 `protobuf:"bytes,2,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"` // 事件类型
+	Timestamp     int64                  `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`                 // 事件时间戳（毫秒）
+	Ip            string                 `protobuf:"bytes,4,opt,name=ip,proto3" json:"ip,omitempty"`                                // IP 地址
+	Source        string                 `protobuf:"bytes,5,opt,name=source,proto3" json:"source,omitempty"`                        // 来源
+	Properties    string                 `protobuf:"bytes,6,opt,name=properties,proto3" json:"properties,omitempty"`                // 事件属性（JSON 字符串）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TimelineEvent) Reset() {
+	*x = TimelineEvent{}
+	mi := &file_stat_v1_stat_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimelineEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimelineEvent) ProtoMessage() {}
+
+func (x *TimelineEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_stat_v1_stat_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimelineEvent.ProtoReflect.Descriptor instead.
+func (*TimelineEvent) Descriptor() ([]byte, []int) {
+	return file_stat_v1_stat_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *TimelineEvent) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *TimelineEvent) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *TimelineEvent) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *TimelineEvent) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *TimelineEvent) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *TimelineEvent) GetProperties() string {
+	if x != nil {
+		return x.Properties
+	}
+	return ""
+}
+
+// GetUserTimelineResponse 用户时间线响应
+type GetUserTimelineResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*TimelineEvent       `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`                                      // 按时间倒序
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // 下一页游标，为空表示没有更多
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserTimelineResponse) Reset() {
+	*x = GetUserTimelineResponse{}
+	mi := &file_stat_v1_stat_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserTimelineResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserTimelineResponse) ProtoMessage() {}
+
+func (x *GetUserTimelineResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stat_v1_stat_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserTimelineResponse.ProtoReflect.Descriptor instead.
+func (*GetUserTimelineResponse) Descriptor() ([]byte, []int) {
+	return file_stat_v1_stat_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetUserTimelineResponse) GetEvents() []*TimelineEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *GetUserTimelineResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 var File_stat_v1_stat_proto protoreflect.FileDescriptor
 
 const file_stat_v1_stat_proto_rawDesc = "" +
 	"\n" +
-	"\x12stat/v1/stat.proto\x12\astat.v1\"\xb1\x01\n" +
+	"\x12stat/v1/stat.proto\x12\astat.v1\x1a\x16common/v1/common.proto\"\x81\x02\n" +
 	"\x11TrackEventRequest\x12\x1d\n" +
 	"\n" +
 	"event_type\x18\x01 \x01(\tR\teventType\x12\x1c\n" +
@@ -295,23 +1052,93 @@ const file_stat_v1_stat_proto_rawDesc = "" +
 	"\x06source\x18\x05 \x01(\tR\x06source\x12\x1e\n" +
 	"\n" +
 	"properties\x18\x06 \x01(\tR\n" +
-	"properties\"c\n" +
+	"properties\x12&\n" +
+	"\x0fclient_event_id\x18\a \x01(\tR\rclientEventId\x12&\n" +
+	"\x04user\x18\b \x01(\v2\x12.common.v1.UserRefR\x04user\"c\n" +
 	"\x12TrackEventResponse\x12\x19\n" +
 	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12\x18\n" +
 	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x03 \x01(\tR\amessage\"L\n" +
 	"\x16TrackEventBatchRequest\x122\n" +
-	"\x06events\x18\x01 \x03(\v2\x1a.stat.v1.TrackEventRequestR\x06events\"\x98\x01\n" +
+	"\x06events\x18\x01 \x03(\v2\x1a.stat.v1.TrackEventRequestR\x06events\"\xcd\x01\n" +
 	"\x17TrackEventBatchResponse\x12#\n" +
 	"\rsuccess_count\x18\x01 \x01(\x05R\fsuccessCount\x12!\n" +
 	"\ffailed_count\x18\x02 \x01(\x05R\vfailedCount\x12\x1b\n" +
 	"\tevent_ids\x18\x03 \x03(\tR\beventIds\x12\x18\n" +
-	"\amessage\x18\x04 \x01(\tR\amessage2\xaa\x01\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x123\n" +
+	"\aresults\x18\x05 \x03(\v2\x19.stat.v1.TrackEventResultR\aresults\"\x95\x01\n" +
+	"\x10TrackEventResult\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x19\n" +
+	"\bevent_id\x18\x02 \x01(\tR\aeventId\x12\x18\n" +
+	"\asuccess\x18\x03 \x01(\bR\asuccess\x12\x1c\n" +
+	"\tretryable\x18\x04 \x01(\bR\tretryable\x12\x18\n" +
+	"\amessage\x18\x05 \x01(\tR\amessage\"\xc3\x01\n" +
+	"\x12CountEventsRequest\x12\x1f\n" +
+	"\vevent_types\x18\x01 \x03(\tR\n" +
+	"eventTypes\x12\x1d\n" +
+	"\n" +
+	"start_time\x18\x02 \x01(\x03R\tstartTime\x12\x19\n" +
+	"\bend_time\x18\x03 \x01(\x03R\aendTime\x126\n" +
+	"\vgranularity\x18\x04 \x01(\x0e2\x14.stat.v1.GranularityR\vgranularity\x12\x1a\n" +
+	"\btimezone\x18\x05 \x01(\tR\btimezone\"\x88\x01\n" +
+	"\vCountBucket\x12!\n" +
+	"\fbucket_start\x18\x01 \x01(\x03R\vbucketStart\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x02 \x01(\tR\teventType\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x03R\x05count\x12!\n" +
+	"\funique_users\x18\x04 \x01(\x03R\vuniqueUsers\"E\n" +
+	"\x13CountEventsResponse\x12.\n" +
+	"\abuckets\x18\x01 \x03(\v2\x14.stat.v1.CountBucketR\abuckets\"\xa1\x01\n" +
+	"\x14TopPropertiesRequest\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x01 \x01(\tR\teventType\x12\x1a\n" +
+	"\bproperty\x18\x02 \x01(\tR\bproperty\x12\x1d\n" +
+	"\n" +
+	"start_time\x18\x03 \x01(\x03R\tstartTime\x12\x19\n" +
+	"\bend_time\x18\x04 \x01(\x03R\aendTime\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\"^\n" +
+	"\rPropertyCount\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\x12!\n" +
+	"\funique_users\x18\x03 \x01(\x03R\vuniqueUsers\"E\n" +
+	"\x15TopPropertiesResponse\x12,\n" +
+	"\x05items\x18\x01 \x03(\v2\x16.stat.v1.PropertyCountR\x05items\"\xe9\x01\n" +
+	"\x16GetUserTimelineRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\rR\x06userId\x12\x1f\n" +
+	"\vevent_types\x18\x02 \x03(\tR\n" +
+	"eventTypes\x12\x1d\n" +
+	"\n" +
+	"start_time\x18\x03 \x01(\x03R\tstartTime\x12\x19\n" +
+	"\bend_time\x18\x04 \x01(\x03R\aendTime\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x06 \x01(\tR\tpageToken\x12&\n" +
+	"\x04user\x18\a \x01(\v2\x12.common.v1.UserRefR\x04user\"\xaf\x01\n" +
+	"\rTimelineEvent\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x02 \x01(\tR\teventType\x12\x1c\n" +
+	"\ttimestamp\x18\x03 \x01(\x03R\ttimestamp\x12\x0e\n" +
+	"\x02ip\x18\x04 \x01(\tR\x02ip\x12\x16\n" +
+	"\x06source\x18\x05 \x01(\tR\x06source\x12\x1e\n" +
+	"\n" +
+	"properties\x18\x06 \x01(\tR\n" +
+	"properties\"q\n" +
+	"\x17GetUserTimelineResponse\x12.\n" +
+	"\x06events\x18\x01 \x03(\v2\x16.stat.v1.TimelineEventR\x06events\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken*U\n" +
+	"\vGranularity\x12\x1b\n" +
+	"\x17GRANULARITY_UNSPECIFIED\x10\x00\x12\x14\n" +
+	"\x10GRANULARITY_HOUR\x10\x01\x12\x13\n" +
+	"\x0fGRANULARITY_DAY\x10\x022\x9a\x03\n" +
 	"\vStatService\x12E\n" +
 	"\n" +
 	"TrackEvent\x12\x1a.stat.v1.TrackEventRequest\x1a\x1b.stat.v1.TrackEventResponse\x12T\n" +
-	"\x0fTrackEventBatch\x12\x1f.stat.v1.TrackEventBatchRequest\x1a .stat.v1.TrackEventBatchResponseB\x8e\x01\n" +
-	"\vcom.stat.v1B\tStatProtoP\x01Z7github.com/betterde/focusly/internal/gen/stat/v1;statv1\xa2\x02\x03SXX\xaa\x02\aStat.V1\xca\x02\aStat\\V1\xe2\x02\x13Stat\\V1\\GPBMetadata\xea\x02\bStat::V1b\x06proto3"
+	"\x0fTrackEventBatch\x12\x1f.stat.v1.TrackEventBatchRequest\x1a .stat.v1.TrackEventBatchResponse\x12H\n" +
+	"\vCountEvents\x12\x1b.stat.v1.CountEventsRequest\x1a\x1c.stat.v1.CountEventsResponse\x12N\n" +
+	"\rTopProperties\x12\x1d.stat.v1.TopPropertiesRequest\x1a\x1e.stat.v1.TopPropertiesResponse\x12T\n" +
+	"\x0fGetUserTimeline\x12\x1f.stat.v1.GetUserTimelineRequest\x1a .stat.v1.GetUserTimelineResponseB\x8a\x01\n" +
+	"\vcom.stat.v1B\tStatProtoP\x01Z3github.com/trancecho/mundo-proto-sdk/stat/v1;statv1\xa2\x02\x03SXX\xaa\x02\aStat.V1\xca\x02\aStat\\V1\xe2\x02\x13Stat\\V1\\GPBMetadata\xea\x02\bStat::V1b\x06proto3"
 
 var (
 	file_stat_v1_stat_proto_rawDescOnce sync.Once
@@ -325,24 +1152,50 @@ func file_stat_v1_stat_proto_rawDescGZIP() []byte {
 	return file_stat_v1_stat_proto_rawDescData
 }
 
-var file_stat_v1_stat_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_stat_v1_stat_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_stat_v1_stat_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
 var file_stat_v1_stat_proto_goTypes = []any{
-	(*TrackEventRequest)(nil),       // 0: stat.v1.TrackEventRequest
-	(*TrackEventResponse)(nil),      // 1: stat.v1.TrackEventResponse
-	(*TrackEventBatchRequest)(nil),  // 2: stat.v1.TrackEventBatchRequest
-	(*TrackEventBatchResponse)(nil), // 3: stat.v1.TrackEventBatchResponse
+	(Granularity)(0),                // 0: stat.v1.Granularity
+	(*TrackEventRequest)(nil),       // 1: stat.v1.TrackEventRequest
+	(*TrackEventResponse)(nil),      // 2: stat.v1.TrackEventResponse
+	(*TrackEventBatchRequest)(nil),  // 3: stat.v1.TrackEventBatchRequest
+	(*TrackEventBatchResponse)(nil), // 4: stat.v1.TrackEventBatchResponse
+	(*TrackEventResult)(nil),        // 5: stat.v1.TrackEventResult
+	(*CountEventsRequest)(nil),      // 6: stat.v1.CountEventsRequest
+	(*CountBucket)(nil),             // 7: stat.v1.CountBucket
+	(*CountEventsResponse)(nil),     // 8: stat.v1.CountEventsResponse
+	(*TopPropertiesRequest)(nil),    // 9: stat.v1.TopPropertiesRequest
+	(*PropertyCount)(nil),           // 10: stat.v1.PropertyCount
+	(*TopPropertiesResponse)(nil),   // 11: stat.v1.TopPropertiesResponse
+	(*GetUserTimelineRequest)(nil),  // 12: stat.v1.GetUserTimelineRequest
+	(*TimelineEvent)(nil),           // 13: stat.v1.TimelineEvent
+	(*GetUserTimelineResponse)(nil), // 14: stat.v1.GetUserTimelineResponse
+	(*v1.UserRef)(nil),              // 15: common.v1.UserRef
 }
 var file_stat_v1_stat_proto_depIdxs = []int32{
-	0, // 0: stat.v1.TrackEventBatchRequest.events:type_name -> stat.v1.TrackEventRequest
-	0, // 1: stat.v1.StatService.TrackEvent:input_type -> stat.v1.TrackEventRequest
-	2, // 2: stat.v1.StatService.TrackEventBatch:input_type -> stat.v1.TrackEventBatchRequest
-	1, // 3: stat.v1.StatService.TrackEvent:output_type -> stat.v1.TrackEventResponse
-	3, // 4: stat.v1.StatService.TrackEventBatch:output_type -> stat.v1.TrackEventBatchResponse
-	3, // [3:5] is the sub-list for method output_type
-	1, // [1:3] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	15, // 0: stat.v1.TrackEventRequest.user:type_name -> common.v1.UserRef
+	1,  // 1: stat.v1.TrackEventBatchRequest.events:type_name -> stat.v1.TrackEventRequest
+	5,  // 2: stat.v1.TrackEventBatchResponse.results:type_name -> stat.v1.TrackEventResult
+	0,  // 3: stat.v1.CountEventsRequest.granularity:type_name -> stat.v1.Granularity
+	7,  // 4: stat.v1.CountEventsResponse.buckets:type_name -> stat.v1.CountBucket
+	10, // 5: stat.v1.TopPropertiesResponse.items:type_name -> stat.v1.PropertyCount
+	15, // 6: stat.v1.GetUserTimelineRequest.user:type_name -> common.v1.UserRef
+	13, // 7: stat.v1.GetUserTimelineResponse.events:type_name -> stat.v1.TimelineEvent
+	1,  // 8: stat.v1.StatService.TrackEvent:input_type -> stat.v1.TrackEventRequest
+	3,  // 9: stat.v1.StatService.TrackEventBatch:input_type -> stat.v1.TrackEventBatchRequest
+	6,  // 10: stat.v1.StatService.CountEvents:input_type -> stat.v1.CountEventsRequest
+	9,  // 11: stat.v1.StatService.TopProperties:input_type -> stat.v1.TopPropertiesRequest
+	12, // 12: stat.v1.StatService.GetUserTimeline:input_type -> stat.v1.GetUserTimelineRequest
+	2,  // 13: stat.v1.StatService.TrackEvent:output_type -> stat.v1.TrackEventResponse
+	4,  // 14: stat.v1.StatService.TrackEventBatch:output_type -> stat.v1.TrackEventBatchResponse
+	8,  // 15: stat.v1.StatService.CountEvents:output_type -> stat.v1.CountEventsResponse
+	11, // 16: stat.v1.StatService.TopProperties:output_type -> stat.v1.TopPropertiesResponse
+	14, // 17: stat.v1.StatService.GetUserTimeline:output_type -> stat.v1.GetUserTimelineResponse
+	13, // [13:18] is the sub-list for method output_type
+	8,  // [8:13] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_stat_v1_stat_proto_init() }
@@ -355,13 +1208,14 @@ func file_stat_v1_stat_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_stat_v1_stat_proto_rawDesc), len(file_stat_v1_stat_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   4,
+			NumEnums:      1,
+			NumMessages:   14,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_stat_v1_stat_proto_goTypes,
 		DependencyIndexes: file_stat_v1_stat_proto_depIdxs,
+		EnumInfos:         file_stat_v1_stat_proto_enumTypes,
 		MessageInfos:      file_stat_v1_stat_proto_msgTypes,
 	}.Build()
 	File_stat_v1_stat_proto = out.File
@@ -21,6 +21,9 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	StatService_TrackEvent_FullMethodName      = "/stat.v1.StatService/TrackEvent"
 	StatService_TrackEventBatch_FullMethodName = "/stat.v1.StatService/TrackEventBatch"
+	StatService_CountEvents_FullMethodName     = "/stat.v1.StatService/CountEvents"
+	StatService_TopProperties_FullMethodName   = "/stat.v1.StatService/TopProperties"
+	StatService_GetUserTimeline_FullMethodName = "/stat.v1.StatService/GetUserTimeline"
 )
 
 // StatServiceClient is the client API for StatService service.
@@ -33,6 +36,12 @@ type StatServiceClient interface {
 	TrackEvent(ctx context.Context, in *TrackEventRequest, opts ...grpc.CallOption) (*TrackEventResponse, error)
 	// TrackEventBatch 批量上报事件
 	TrackEventBatch(ctx context.Context, in *TrackEventBatchRequest, opts ...grpc.CallOption) (*TrackEventBatchResponse, error)
+	// CountEvents 按时间桶统计事件次数与独立用户数
+	CountEvents(ctx context.Context, in *CountEventsRequest, opts ...grpc.CallOption) (*CountEventsResponse, error)
+	// TopProperties 统计某事件属性值的 Top N
+	TopProperties(ctx context.Context, in *TopPropertiesRequest, opts ...grpc.CallOption) (*TopPropertiesResponse, error)
+	// GetUserTimeline 查询用户的事件时间线
+	GetUserTimeline(ctx context.Context, in *GetUserTimelineRequest, opts ...grpc.CallOption) (*GetUserTimelineResponse, error)
 }
 
 type statServiceClient struct {
@@ -63,6 +72,36 @@ func (c *statServiceClient) TrackEventBatch(ctx context.Context, in *TrackEventB
 	return out, nil
 }
 
+func (c *statServiceClient) CountEvents(ctx context.Context, in *CountEventsRequest, opts ...grpc.CallOption) (*CountEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CountEventsResponse)
+	err := c.cc.Invoke(ctx, StatService_CountEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *statServiceClient) TopProperties(ctx context.Context, in *TopPropertiesRequest, opts ...grpc.CallOption) (*TopPropertiesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TopPropertiesResponse)
+	err := c.cc.Invoke(ctx, StatService_TopProperties_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *statServiceClient) GetUserTimeline(ctx context.Context, in *GetUserTimelineRequest, opts ...grpc.CallOption) (*GetUserTimelineResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUserTimelineResponse)
+	err := c.cc.Invoke(ctx, StatService_GetUserTimeline_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // StatServiceServer is the server API for StatService service.
 // All implementations must embed UnimplementedStatServiceServer
 // for forward compatibility.
@@ -73,6 +112,12 @@ type StatServiceServer interface {
 	TrackEvent(context.Context, *TrackEventRequest) (*TrackEventResponse, error)
 	// TrackEventBatch 批量上报事件
 	TrackEventBatch(context.Context, *TrackEventBatchRequest) (*TrackEventBatchResponse, error)
+	// CountEvents 按时间桶统计事件次数与独立用户数
+	CountEvents(context.Context, *CountEventsRequest) (*CountEventsResponse, error)
+	// TopProperties 统计某事件属性值的 Top N
+	TopProperties(context.Context, *TopPropertiesRequest) (*TopPropertiesResponse, error)
+	// GetUserTimeline 查询用户的事件时间线
+	GetUserTimeline(context.Context, *GetUserTimelineRequest) (*GetUserTimelineResponse, error)
 	mustEmbedUnimplementedStatServiceServer()
 }
 
@@ -89,6 +134,15 @@ func (UnimplementedStatServiceServer) TrackEvent(context.Context, *TrackEventReq
 func (UnimplementedStatServiceServer) TrackEventBatch(context.Context, *TrackEventBatchRequest) (*TrackEventBatchResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method TrackEventBatch not implemented")
 }
+func (UnimplementedStatServiceServer) CountEvents(context.Context, *CountEventsRequest) (*CountEventsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CountEvents not implemented")
+}
+func (UnimplementedStatServiceServer) TopProperties(context.Context, *TopPropertiesRequest) (*TopPropertiesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method TopProperties not implemented")
+}
+func (UnimplementedStatServiceServer) GetUserTimeline(context.Context, *GetUserTimelineRequest) (*GetUserTimelineResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetUserTimeline not implemented")
+}
 func (UnimplementedStatServiceServer) mustEmbedUnimplementedStatServiceServer() {}
 func (UnimplementedStatServiceServer) testEmbeddedByValue()                     {}
 
@@ -146,6 +200,60 @@ func _StatService_TrackEventBatch_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StatService_CountEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CountEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatServiceServer).CountEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StatService_CountEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatServiceServer).CountEvents(ctx, req.(*CountEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StatService_TopProperties_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TopPropertiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatServiceServer).TopProperties(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StatService_TopProperties_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatServiceServer).TopProperties(ctx, req.(*TopPropertiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StatService_GetUserTimeline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserTimelineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatServiceServer).GetUserTimeline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StatService_GetUserTimeline_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatServiceServer).GetUserTimeline(ctx, req.(*GetUserTimelineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // StatService_ServiceDesc is the grpc.ServiceDesc for StatService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -161,6 +269,18 @@ var StatService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "TrackEventBatch",
 			Handler:    _StatService_TrackEventBatch_Handler,
 		},
+		{
+			MethodName: "CountEvents",
+			Handler:    _StatService_CountEvents_Handler,
+		},
+		{
+			MethodName: "TopProperties",
+			Handler:    _StatService_TopProperties_Handler,
+		},
+		{
+			MethodName: "GetUserTimeline",
+			Handler:    _StatService_GetUserTimeline_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "stat/v1/stat.proto",
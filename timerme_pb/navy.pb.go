@@ -7,6 +7,7 @@
 package timerme_pb
 
 import (
+	v1 "github.com/trancecho/mundo-proto-sdk/common/v1"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
@@ -27,6 +28,7 @@ type CreateQuestionRequest struct {
 	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"` //问题内容
 	Tags          []string               `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`       //问题标签
 	Uid           uint64                 `protobuf:"varint,4,opt,name=uid,proto3" json:"uid,omitempty"`        //用户ID
+	User          *v1.UserRef            `protobuf:"bytes,5,opt,name=user,proto3" json:"user,omitempty"`       //统一用户标识，设置时优先于 uid
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -89,6 +91,13 @@ func (x *CreateQuestionRequest) GetUid() uint64 {
 	return 0
 }
 
+func (x *CreateQuestionRequest) GetUser() *v1.UserRef {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
 type CreateQuestionResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	QuestionId    uint64                 `protobuf:"varint,1,opt,name=question_id,json=questionId,proto3" json:"question_id,omitempty"` //问题ID
@@ -135,7 +144,9 @@ func (x *CreateQuestionResponse) GetQuestionId() uint64 {
 
 type ListQuestionIdsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Uid           uint64                 `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"` //用户ID
+	Uid           uint64                 `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`              //用户ID
+	User          *v1.UserRef            `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`             //统一用户标识，设置时优先于 uid
+	Pagination    *v1.PageRequest        `protobuf:"bytes,3,opt,name=pagination,proto3" json:"pagination,omitempty"` //分页，未设置时返回全部（兼容旧客户端）
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -177,9 +188,24 @@ func (x *ListQuestionIdsRequest) GetUid() uint64 {
 	return 0
 }
 
+func (x *ListQuestionIdsRequest) GetUser() *v1.UserRef {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *ListQuestionIdsRequest) GetPagination() *v1.PageRequest {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
 type ListQuestionIdsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	QuestionIds   []uint64               `protobuf:"varint,1,rep,packed,name=question_ids,json=questionIds,proto3" json:"question_ids,omitempty"` //问题ID列表
+	Pagination    *v1.PageResponse       `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`                              //请求设置了 pagination 时返回
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -221,6 +247,13 @@ func (x *ListQuestionIdsResponse) GetQuestionIds() []uint64 {
 	return nil
 }
 
+func (x *ListQuestionIdsResponse) GetPagination() *v1.PageResponse {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
 type CreateAnswerRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	QuestionId    uint64                 `protobuf:"varint,1,opt,name=question_id,json=questionId,proto3" json:"question_id,omitempty"` //问题ID
@@ -372,6 +405,7 @@ func (x *CreateUserRequest) GetPassword() string {
 type CreateUserResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        uint64                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` //用户ID
+	User          *v1.UserRef            `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`                    //统一用户标识
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -413,6 +447,13 @@ func (x *CreateUserResponse) GetUserId() uint64 {
 	return 0
 }
 
+func (x *CreateUserResponse) GetUser() *v1.UserRef {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
 type GetQuestionRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	QuestionId    uint64                 `protobuf:"varint,1,opt,name=question_id,json=questionId,proto3" json:"question_id,omitempty"` //问题ID
@@ -464,6 +505,7 @@ type GetQuestionResponse struct {
 	Tags          []string               `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`       //问题标签
 	Uid           uint64                 `protobuf:"varint,4,opt,name=uid,proto3" json:"uid,omitempty"`        //用户ID
 	Answers       []string               `protobuf:"bytes,5,rep,name=answers,proto3" json:"answers,omitempty"` //答案列表
+	User          *v1.UserRef            `protobuf:"bytes,6,opt,name=user,proto3" json:"user,omitempty"`       //统一用户标识
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -533,23 +575,38 @@ func (x *GetQuestionResponse) GetAnswers() []string {
 	return nil
 }
 
+func (x *GetQuestionResponse) GetUser() *v1.UserRef {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
 var File_timerme_pb_navy_proto protoreflect.FileDescriptor
 
 const file_timerme_pb_navy_proto_rawDesc = "" +
 	"\n" +
-	"\x15timerme_pb/navy.proto\x12\atimerme\"m\n" +
+	"\x15timerme_pb/navy.proto\x12\atimerme\x1a\x16common/v1/common.proto\"\x95\x01\n" +
 	"\x15CreateQuestionRequest\x12\x14\n" +
 	"\x05title\x18\x01 \x01(\tR\x05title\x12\x18\n" +
 	"\acontent\x18\x02 \x01(\tR\acontent\x12\x12\n" +
 	"\x04tags\x18\x03 \x03(\tR\x04tags\x12\x10\n" +
-	"\x03uid\x18\x04 \x01(\x04R\x03uid\"9\n" +
+	"\x03uid\x18\x04 \x01(\x04R\x03uid\x12&\n" +
+	"\x04user\x18\x05 \x01(\v2\x12.common.v1.UserRefR\x04user\"9\n" +
 	"\x16CreateQuestionResponse\x12\x1f\n" +
 	"\vquestion_id\x18\x01 \x01(\x04R\n" +
-	"questionId\"*\n" +
+	"questionId\"\x8a\x01\n" +
 	"\x16ListQuestionIdsRequest\x12\x10\n" +
-	"\x03uid\x18\x01 \x01(\x04R\x03uid\"<\n" +
+	"\x03uid\x18\x01 \x01(\x04R\x03uid\x12&\n" +
+	"\x04user\x18\x02 \x01(\v2\x12.common.v1.UserRefR\x04user\x126\n" +
+	"\n" +
+	"pagination\x18\x03 \x01(\v2\x16.common.v1.PageRequestR\n" +
+	"pagination\"u\n" +
 	"\x17ListQuestionIdsResponse\x12!\n" +
-	"\fquestion_ids\x18\x01 \x03(\x04R\vquestionIds\"P\n" +
+	"\fquestion_ids\x18\x01 \x03(\x04R\vquestionIds\x127\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2\x17.common.v1.PageResponseR\n" +
+	"pagination\"P\n" +
 	"\x13CreateAnswerRequest\x12\x1f\n" +
 	"\vquestion_id\x18\x01 \x01(\x04R\n" +
 	"questionId\x12\x18\n" +
@@ -558,26 +615,28 @@ const file_timerme_pb_navy_proto_rawDesc = "" +
 	"\tanswer_id\x18\x01 \x01(\x04R\banswerId\"K\n" +
 	"\x11CreateUserRequest\x12\x1a\n" +
 	"\busername\x18\x01 \x01(\tR\busername\x12\x1a\n" +
-	"\bpassword\x18\x02 \x01(\tR\bpassword\"-\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\"U\n" +
 	"\x12CreateUserResponse\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\x04R\x06userId\"5\n" +
+	"\auser_id\x18\x01 \x01(\x04R\x06userId\x12&\n" +
+	"\x04user\x18\x02 \x01(\v2\x12.common.v1.UserRefR\x04user\"5\n" +
 	"\x12GetQuestionRequest\x12\x1f\n" +
 	"\vquestion_id\x18\x01 \x01(\x04R\n" +
-	"questionId\"\x85\x01\n" +
+	"questionId\"\xad\x01\n" +
 	"\x13GetQuestionResponse\x12\x14\n" +
 	"\x05title\x18\x01 \x01(\tR\x05title\x12\x18\n" +
 	"\acontent\x18\x02 \x01(\tR\acontent\x12\x12\n" +
 	"\x04tags\x18\x03 \x03(\tR\x04tags\x12\x10\n" +
 	"\x03uid\x18\x04 \x01(\x04R\x03uid\x12\x18\n" +
-	"\aanswers\x18\x05 \x03(\tR\aanswers2\x92\x03\n" +
+	"\aanswers\x18\x05 \x03(\tR\aanswers\x12&\n" +
+	"\x04user\x18\x06 \x01(\v2\x12.common.v1.UserRefR\x04user2\x92\x03\n" +
 	"\tQAService\x12Q\n" +
 	"\x0eCreateQuestion\x12\x1e.timerme.CreateQuestionRequest\x1a\x1f.timerme.CreateQuestionResponse\x12T\n" +
 	"\x0fListQuestionIds\x12\x1f.timerme.ListQuestionIdsRequest\x1a .timerme.ListQuestionIdsResponse\x12K\n" +
 	"\fCreateAnswer\x12\x1c.timerme.CreateAnswerRequest\x1a\x1d.timerme.CreateAnswerResponse\x12E\n" +
 	"\n" +
 	"CreateUser\x12\x1a.timerme.CreateUserRequest\x1a\x1b.timerme.CreateUserResponse\x12H\n" +
-	"\vGetQuestion\x12\x1b.timerme.GetQuestionRequest\x1a\x1c.timerme.GetQuestionResponseB\x89\x01\n" +
-	"\vcom.timermeB\tNavyProtoP\x01Z3github.com/betterde/focusly/internal/gen/timerme_pb\xa2\x02\x03TXX\xaa\x02\aTimerme\xca\x02\aTimerme\xe2\x02\x13Timerme\\GPBMetadata\xea\x02\aTimermeb\x06proto3"
+	"\vGetQuestion\x12\x1b.timerme.GetQuestionRequest\x1a\x1c.timerme.GetQuestionResponseB\x85\x01\n" +
+	"\vcom.timermeB\tNavyProtoP\x01Z/github.com/trancecho/mundo-proto-sdk/timerme_pb\xa2\x02\x03TXX\xaa\x02\aTimerme\xca\x02\aTimerme\xe2\x02\x13Timerme\\GPBMetadata\xea\x02\aTimermeb\x06proto3"
 
 var (
 	file_timerme_pb_navy_proto_rawDescOnce sync.Once
@@ -603,23 +662,32 @@ var file_timerme_pb_navy_proto_goTypes = []any{
 	(*CreateUserResponse)(nil),      // 7: timerme.CreateUserResponse
 	(*GetQuestionRequest)(nil),      // 8: timerme.GetQuestionRequest
 	(*GetQuestionResponse)(nil),     // 9: timerme.GetQuestionResponse
+	(*v1.UserRef)(nil),              // 10: common.v1.UserRef
+	(*v1.PageRequest)(nil),          // 11: common.v1.PageRequest
+	(*v1.PageResponse)(nil),         // 12: common.v1.PageResponse
 }
 var file_timerme_pb_navy_proto_depIdxs = []int32{
-	0, // 0: timerme.QAService.CreateQuestion:input_type -> timerme.CreateQuestionRequest
-	2, // 1: timerme.QAService.ListQuestionIds:input_type -> timerme.ListQuestionIdsRequest
-	4, // 2: timerme.QAService.CreateAnswer:input_type -> timerme.CreateAnswerRequest
-	6, // 3: timerme.QAService.CreateUser:input_type -> timerme.CreateUserRequest
-	8, // 4: timerme.QAService.GetQuestion:input_type -> timerme.GetQuestionRequest
-	1, // 5: timerme.QAService.CreateQuestion:output_type -> timerme.CreateQuestionResponse
-	3, // 6: timerme.QAService.ListQuestionIds:output_type -> timerme.ListQuestionIdsResponse
-	5, // 7: timerme.QAService.CreateAnswer:output_type -> timerme.CreateAnswerResponse
-	7, // 8: timerme.QAService.CreateUser:output_type -> timerme.CreateUserResponse
-	9, // 9: timerme.QAService.GetQuestion:output_type -> timerme.GetQuestionResponse
-	5, // [5:10] is the sub-list for method output_type
-	0, // [0:5] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	10, // 0: timerme.CreateQuestionRequest.user:type_name -> common.v1.UserRef
+	10, // 1: timerme.ListQuestionIdsRequest.user:type_name -> common.v1.UserRef
+	11, // 2: timerme.ListQuestionIdsRequest.pagination:type_name -> common.v1.PageRequest
+	12, // 3: timerme.ListQuestionIdsResponse.pagination:type_name -> common.v1.PageResponse
+	10, // 4: timerme.CreateUserResponse.user:type_name -> common.v1.UserRef
+	10, // 5: timerme.GetQuestionResponse.user:type_name -> common.v1.UserRef
+	0,  // 6: timerme.QAService.CreateQuestion:input_type -> timerme.CreateQuestionRequest
+	2,  // 7: timerme.QAService.ListQuestionIds:input_type -> timerme.ListQuestionIdsRequest
+	4,  // 8: timerme.QAService.CreateAnswer:input_type -> timerme.CreateAnswerRequest
+	6,  // 9: timerme.QAService.CreateUser:input_type -> timerme.CreateUserRequest
+	8,  // 10: timerme.QAService.GetQuestion:input_type -> timerme.GetQuestionRequest
+	1,  // 11: timerme.QAService.CreateQuestion:output_type -> timerme.CreateQuestionResponse
+	3,  // 12: timerme.QAService.ListQuestionIds:output_type -> timerme.ListQuestionIdsResponse
+	5,  // 13: timerme.QAService.CreateAnswer:output_type -> timerme.CreateAnswerResponse
+	7,  // 14: timerme.QAService.CreateUser:output_type -> timerme.CreateUserResponse
+	9,  // 15: timerme.QAService.GetQuestion:output_type -> timerme.GetQuestionResponse
+	11, // [11:16] is the sub-list for method output_type
+	6,  // [6:11] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_timerme_pb_navy_proto_init() }